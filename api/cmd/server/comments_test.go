@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// foreignFeedbackDB behaves like a database where the requested feedback
+// belongs to another project: every project-scoped lookup comes back empty
+type foreignFeedbackDB struct {
+	queries []string
+	args    [][]any
+}
+
+func (db *foreignFeedbackDB) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.queries = append(db.queries, sql)
+	db.args = append(db.args, args)
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *foreignFeedbackDB) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	db.queries = append(db.queries, sql)
+	db.args = append(db.args, args)
+	return nil, pgx.ErrNoRows
+}
+
+func (db *foreignFeedbackDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	db.queries = append(db.queries, sql)
+	db.args = append(db.args, args)
+	return noRow{}
+}
+
+type noRow struct{}
+
+func (noRow) Scan(...any) error { return pgx.ErrNoRows }
+
+func commentRequest(method, body, projectID, feedbackID string) *http.Request {
+	req := httptest.NewRequest(method, "/community/projects/"+projectID+"/feature-requests/"+feedbackID+"/comments", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("projectId", projectID)
+	rctx.URLParams.Add("feedbackId", feedbackID)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = auth.ContextWithUserID(ctx, uuid.New())
+	return req.WithContext(ctx)
+}
+
+func TestCommentHandlers_FeedbackFromAnotherProject(t *testing.T) {
+	projectID := uuid.New().String()
+	otherFeedbackID := uuid.New().String()
+
+	t.Run("listing returns 404", func(t *testing.T) {
+		db := &foreignFeedbackDB{}
+		rr := httptest.NewRecorder()
+		listCommentsHandler(db)(rr, commentRequest(http.MethodGet, "", projectID, otherFeedbackID))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		require.Len(t, db.queries, 1, "comments are not read once the feedback is out of scope")
+		assert.Contains(t, db.queries[0], "project_id = $2")
+		assert.Equal(t, []any{otherFeedbackID, projectID}, db.args[0])
+	})
+
+	t.Run("commenting returns 404", func(t *testing.T) {
+		comments := &scopedCommentService{}
+		rr := httptest.NewRecorder()
+		createCommentHandler(comments)(rr, commentRequest(http.MethodPost, `{"body":"Me too"}`, projectID, otherFeedbackID))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		require.Len(t, comments.created, 1)
+		assert.Equal(t, projectID, comments.created[0].ProjectID.String())
+	})
+}
+
+func TestCommentHandlers_Visibility(t *testing.T) {
+	projectID := uuid.New()
+	feedbackID := uuid.New()
+
+	tests := []struct {
+		name    string
+		handler func(service.CommentService) http.HandlerFunc
+		want    domain.Visibility
+	}{
+		{"community comments are public", createCommentHandler, domain.VisibilityCommunity},
+		{"team notes are team only", createTeamNoteHandler, domain.VisibilityTeamOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &scopedCommentService{projectID: projectID}
+			rr := httptest.NewRecorder()
+			tt.handler(comments)(rr, commentRequest(http.MethodPost, `{"body":" Thanks @jane "}`, projectID.String(), feedbackID.String()))
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			require.Len(t, comments.created, 1, "the comment service records the mentions")
+			assert.Equal(t, tt.want, comments.created[0].Visibility)
+			assert.Equal(t, feedbackID, comments.created[0].FeedbackID)
+			assert.Equal(t, "Thanks @jane", comments.created[0].Body)
+		})
+	}
+
+	t.Run("empty bodies are rejected", func(t *testing.T) {
+		comments := &scopedCommentService{projectID: projectID}
+		rr := httptest.NewRecorder()
+		createTeamNoteHandler(comments)(rr, commentRequest(http.MethodPost, `{"body":"  "}`, projectID.String(), feedbackID.String()))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, comments.created)
+	})
+}
+
+// scopedCommentService records the comments it is asked to create, and
+// treats feedback outside projectID as not found like the real service
+type scopedCommentService struct {
+	service.CommentService
+	projectID uuid.UUID
+	created   []service.CreateCommentRequest
+}
+
+func (s *scopedCommentService) Create(_ context.Context, req service.CreateCommentRequest) (*domain.Comment, error) {
+	s.created = append(s.created, req)
+	if req.ProjectID != s.projectID {
+		return nil, domain.ErrFeedbackNotFound
+	}
+	return &domain.Comment{ID: uuid.New(), FeedbackID: req.FeedbackID, Body: req.Body, Visibility: req.Visibility}, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCreatorFeedbackHandler_InvalidFilters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		field string
+	}{
+		{"unknown type", "type=rant", "filter"},
+		{"unknown status", "status=done", "filter"},
+		{"malformed assignee", "assigned_to=me", "assigned_to"},
+		{"malformed tag", "tag=" + uuid.NewString() + "&tag=ux", "tag"},
+		{"unknown sort", "sort_by=title", "filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectID := uuid.NewString()
+			req := httptest.NewRequest(http.MethodGet, "/creator/projects/"+projectID+"/feedback?"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("projectId", projectID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			// Invalid filters are rejected before the database is queried
+			rr := httptest.NewRecorder()
+			listCreatorFeedbackHandler(nil)(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"`+tt.field+`"`)
+		})
+	}
+}
@@ -2,15 +2,15 @@ package main
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"math/rand/v2"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
 	"strings"
 	"syscall"
 	"time"
@@ -18,14 +18,19 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/fulldisclosure/api/internal/auth"
 	"github.com/fulldisclosure/api/internal/config"
+	"github.com/fulldisclosure/api/internal/domain"
 	"github.com/fulldisclosure/api/internal/handler"
 	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/service"
+	"github.com/fulldisclosure/api/internal/storage"
 )
 
 func main() {
@@ -55,44 +60,106 @@ func main() {
 	defer dbPool.Close()
 
 	// Initialize auth validators
-	supabaseValidator := auth.NewSupabaseValidator(cfg.SupabaseURL)
+	identityProvider, err := setupIdentityProvider(ctx, cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize identity provider")
+	}
 
 	// Initialize repositories
 	portalRepo := repository.NewPortalRepository(dbPool)
+	savedViewRepo := repository.NewSavedViewRepository(dbPool)
+	exportRepo := repository.NewExportRepository(dbPool)
+	importRepo := repository.NewImportRepository(dbPool)
+	projectRepo := repository.NewProjectRepository(dbPool)
+	analyticsRepo := repository.NewAnalyticsRepository(dbPool)
+	feedbackRepo := repository.NewFeedbackRepository(dbPool)
+	segmentRepo := repository.NewSegmentRepository(dbPool)
+	membershipRepo := repository.NewMembershipRepository(dbPool)
+	voteRepo := repository.NewVoteRepository(dbPool)
+	sdkBoardRepo := repository.NewSDKBoardRepository(dbPool)
+	subscriptionRepo := repository.NewSubscriptionRepository(dbPool)
+	notificationRepo := repository.NewNotificationRepository(dbPool)
+	inboxRepo := repository.NewInboxRepository(dbPool)
+	realtimeRepo := repository.NewRealtimeRepository(dbPool)
+	apiTokenRepo := repository.NewAPITokenRepository(dbPool)
+	projectLifecycleRepo := repository.NewProjectLifecycleRepository(dbPool)
+	customDomainRepo := repository.NewCustomDomainRepository(dbPool)
+	organizationRepo := repository.NewOrganizationRepository(dbPool)
+	projectRoleRepo := repository.NewProjectRoleRepository(dbPool)
+	commentRepo := repository.NewCommentRepository(dbPool)
+
+	// Object storage is optional locally; features that need it degrade.
+	// URLs served by the API itself (SDK uploads, local storage) share one signer.
+	urlSigner := storage.NewURLSigner(cfg.StorageSigningKey)
+	var objectStorage storage.ObjectStorage
+	var localStorage *storage.LocalStorage
+	switch cfg.StorageBackend {
+	case "local":
+		localStorage, err = storage.NewLocalStorage(cfg.LocalStorageDir, cfg.PublicAPIURL+"/api/storage", urlSigner)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize local storage")
+		}
+		objectStorage = localStorage
+	default:
+		if gcsClient, err := storage.NewGCSClient(ctx, cfg.GCSBucket); err != nil {
+			log.Warn().Err(err).Msg("GCS client unavailable, background exports and uploads disabled")
+		} else {
+			defer gcsClient.Close()
+			objectStorage = gcsClient
+		}
+	}
+
+	// Initialize services
+	exportSvc := service.NewExportService(exportRepo, objectStorage)
+	exportJobSweeper := service.NewExportJobSweeper(exportRepo)
+	importSvc := service.NewImportService(importRepo, projectRepo)
+	voteSvc := service.NewVoteService(voteRepo, feedbackRepo)
+	commentSvc := service.NewCommentService(commentRepo, feedbackRepo, membershipRepo)
+	notificationWorker := service.NewNotificationWorker(notificationRepo)
+	realtimeBroker := service.NewRealtimeBroker(realtimeRepo)
+	projectLifecycleSvc := service.NewProjectLifecycleService(projectLifecycleRepo, projectRepo, membershipRepo, cfg.ProjectDeletionGrace)
+	projectPurgeWorker := service.NewProjectPurgeWorker(projectLifecycleRepo, objectStorage)
+	brandingSvc := service.NewBrandingService(projectRepo, objectStorage)
+	customDomainSvc := service.NewCustomDomainService(customDomainRepo, net.DefaultResolver)
+	organizationSvc := service.NewOrganizationService(organizationRepo, cfg.OrgDefaultMaxProjects, cfg.OrgDefaultMaxMembers)
+	// Project roles include those inherited from the owning organization
+	membershipResolver := service.NewMembershipResolver(membershipRepo, organizationRepo)
+	projectRoleSvc := service.NewProjectRoleService(projectRoleRepo, membershipRepo)
 
 	// Initialize handlers
 	sdkTokenHandler := handler.NewSDKTokenHandler(dbPool)
 	portalHandlers := handler.NewPortalHandlers(portalRepo, log.Logger)
+	savedViewHandlers := handler.NewSavedViewHandlers(savedViewRepo, log.Logger)
+	exportHandlers := handler.NewExportHandlers(exportSvc, log.Logger)
+	importHandlers := handler.NewImportHandlers(importSvc, log.Logger)
+	analyticsHandlers := handler.NewAnalyticsHandlers(analyticsRepo, log.Logger)
+	segmentHandlers := handler.NewSegmentHandlers(segmentRepo, feedbackRepo, projectRepo, log.Logger)
+	portalFeedbackHandlers := handler.NewPortalFeedbackHandlers(portalRepo, projectRepo, commentSvc, objectStorage, log.Logger)
+	sdkBoardHandlers := handler.NewSDKBoardHandlers(sdkBoardRepo, projectRepo, voteSvc, log.Logger)
+	subscriptionHandlers := handler.NewSubscriptionHandlers(subscriptionRepo, portalRepo, sdkBoardRepo, log.Logger)
+	notificationHandlers := handler.NewNotificationHandlers(inboxRepo, log.Logger)
+	realtimeHandlers := handler.NewRealtimeHandlers(realtimeBroker, log.Logger)
+	apiTokenHandlers := handler.NewAPITokenHandlers(apiTokenRepo, log.Logger)
+	projectLifecycleHandlers := handler.NewProjectLifecycleHandlers(projectLifecycleSvc, log.Logger)
+	brandingHandlers := handler.NewBrandingHandlers(brandingSvc, log.Logger)
+	customDomainHandlers := handler.NewCustomDomainHandlers(customDomainSvc, log.Logger)
+	organizationHandlers := handler.NewOrganizationHandlers(organizationSvc, log.Logger)
+	projectRoleHandlers := handler.NewProjectRoleHandlers(projectRoleSvc, log.Logger)
 
 	// TODO: Initialize repositories (data layer)
-	// feedbackRepo := repository.NewFeedbackRepository(dbPool)
-	// voteRepo := repository.NewVoteRepository(dbPool)
-	// commentRepo := repository.NewCommentRepository(dbPool)
-	// membershipRepo := repository.NewMembershipRepository(dbPool)
-	// projectRepo := repository.NewProjectRepository(dbPool)
 	// inviteRepo := repository.NewInviteRepository(dbPool)
 	// tagRepo := repository.NewTagRepository(dbPool)
 	// attachmentRepo := repository.NewAttachmentRepository(dbPool)
 
-	// TODO: Initialize storage
-	// gcsClient, err := storage.NewGCSClient(ctx, cfg.GCSBucket)
-	// if err != nil {
-	//     log.Fatal().Err(err).Msg("Failed to initialize GCS client")
-	// }
-
 	// TODO: Initialize services (business layer)
 	// feedbackSvc := service.NewFeedbackService(feedbackRepo, voteRepo, tagRepo)
-	// voteSvc := service.NewVoteService(voteRepo, feedbackRepo)
-	// commentSvc := service.NewCommentService(commentRepo, feedbackRepo)
 	// membershipSvc := service.NewMembershipService(membershipRepo, inviteRepo)
 	// projectSvc := service.NewProjectService(projectRepo)
 	// inviteSvc := service.NewInviteService(inviteRepo, membershipRepo)
 	// tagSvc := service.NewTagService(tagRepo)
-	// attachmentSvc := service.NewAttachmentService(attachmentRepo, gcsClient)
+	// attachmentSvc := service.NewAttachmentService(attachmentRepo, objectStorage)
 
 	// TODO: Initialize auth
-	// jwtValidator := auth.NewSupabaseValidator(cfg.SupabaseJWTSecret)
-	// sdkTokenValidator := auth.NewSDKTokenValidator(dbPool)
 
 	// TODO: Initialize handlers (HTTP layer)
 	// sdkHandler := handler.NewSDKHandler(feedbackSvc, attachmentSvc, sdkTokenValidator)
@@ -101,7 +168,7 @@ func main() {
 	// inviteHandler := handler.NewInviteHandler(inviteSvc)
 
 	// Setup router
-	r := setupRouter(cfg)
+	r := setupRouter(cfg, customDomainSvc)
 
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -112,103 +179,114 @@ func main() {
 
 	// API routes
 	r.Route("/api", func(r chi.Router) {
-		// SDK upload endpoint (no auth - uses attachment ID as auth token)
-		// This is outside the SDK auth middleware because presigned URLs don't carry tokens
-		r.Put("/sdk/attachments/{attachmentId}/upload", sdkUploadFileHandler(dbPool))
+		// SDK upload endpoint, outside the SDK auth middleware because upload
+		// URLs don't carry tokens; the URL signature is the credential
+		r.Put("/sdk/attachments/{attachmentId}/upload", sdkUploadFileHandler(dbPool, objectStorage, urlSigner))
+
+		// Local storage backend objects, served through signed URLs
+		if localStorage != nil {
+			storageHandlers := handler.NewStorageHandlers(localStorage, log.Logger)
+			r.Get("/storage/*", storageHandlers.Download)
+			r.Put("/storage/*", storageHandlers.Upload)
+		}
 
 		// SDK routes (SDK token auth)
 		r.Route("/sdk", func(r chi.Router) {
-			r.Use(sdkAuthMiddleware(dbPool))
+			r.Use(sdkAuthMiddleware(auth.NewSDKTokenValidator(dbPool)))
 			r.Post("/identify", sdkIdentifyHandler(dbPool))
 			r.Post("/feedback", sdkSubmitFeedbackHandler(dbPool))
-			r.Post("/attachments/init", sdkInitiateUploadHandler(dbPool))
+			r.Post("/attachments/init", sdkInitiateUploadHandler(dbPool, urlSigner, cfg.PublicAPIURL, cfg.GCSUploadURLExpiry))
 			r.Post("/attachments/complete", sdkCompleteUploadHandler(dbPool))
-		})
 
-		// Community routes (Supabase JWT + membership required)
-		r.Route("/community", func(r chi.Router) {
-			// TODO: Add auth middleware
-			// r.Use(supabaseAuthMiddleware)
-			// r.Use(requireMembershipMiddleware)
-			r.Route("/projects/{projectId}", func(r chi.Router) {
-				r.Get("/feature-requests", listFeatureRequestsHandler(dbPool))
-				r.Post("/feature-requests", createFeatureRequestHandler(dbPool))
-				r.Get("/feature-requests/{feedbackId}", placeholderHandler("Get feature request"))
-				r.Delete("/feature-requests/{feedbackId}", deleteFeatureRequestHandler(dbPool))
-				r.Post("/feature-requests/{feedbackId}/vote", voteFeatureRequestHandler(dbPool))
-				r.Delete("/feature-requests/{feedbackId}/vote", unvoteFeatureRequestHandler(dbPool))
-				r.Get("/feature-requests/{feedbackId}/comments", listCommentsHandler(dbPool))
-				r.Post("/feature-requests/{feedbackId}/comments", createCommentHandler(dbPool))
-			})
+			// Voting board for in-app widgets; X-SDK-User names the identified user
+			r.Get("/feature-requests", sdkBoardHandlers.ListFeatures)
+			r.Get("/feature-requests/{feedbackId}", sdkBoardHandlers.GetFeature)
+			r.Get("/feature-requests/{feedbackId}/comments", sdkBoardHandlers.ListComments)
+			r.Post("/feature-requests/{feedbackId}/vote", sdkBoardHandlers.Vote)
+			r.Delete("/feature-requests/{feedbackId}/vote", sdkBoardHandlers.Unvote)
+			r.Get("/my-feedback", sdkBoardHandlers.ListMyFeedback)
+			r.Get("/my-votes", sdkBoardHandlers.GetVoteBudget)
+			r.Get("/feature-requests/{feedbackId}/subscription", subscriptionHandlers.SDKGet)
+			r.Post("/feature-requests/{feedbackId}/subscription", subscriptionHandlers.SDKSubscribe)
+			r.Delete("/feature-requests/{feedbackId}/subscription", subscriptionHandlers.SDKUnsubscribe)
 		})
 
-		// Creator routes (Supabase JWT + team role required)
-		r.Route("/creator", func(r chi.Router) {
-			// TODO: Add auth middleware
-			// r.Use(supabaseAuthMiddleware)
-			// r.Use(requireTeamRoleMiddleware)
-
-			// Project management
-			r.Get("/projects", listProjectsHandler(dbPool))
-			r.Post("/projects", createProjectHandler(dbPool))
-
-			r.Route("/projects/{projectId}", func(r chi.Router) {
-				// Project CRUD
-				r.Get("/", getProjectHandler(dbPool))
-				r.Delete("/", deleteProjectHandler(dbPool))
-
-				// Feedback
-				r.Get("/feedback", listCreatorFeedbackHandler(dbPool))
-				r.Post("/feedback", placeholderHandler("Create feedback"))
-				r.Get("/feedback/{feedbackId}", placeholderHandler("Get feedback"))
-				r.Patch("/feedback/{feedbackId}", placeholderHandler("Update feedback"))
-				r.Post("/feedback/{feedbackId}/merge", placeholderHandler("Merge feedback"))
-				r.Post("/feedback/{feedbackId}/notes", placeholderHandler("Add team note"))
-
-				// Tags
-				r.Get("/tags", placeholderHandler("List tags"))
-				r.Post("/tags", placeholderHandler("Create tag"))
-				r.Patch("/tags/{tagId}", placeholderHandler("Update tag"))
-				r.Delete("/tags/{tagId}", placeholderHandler("Delete tag"))
-
-				// Members
-				r.Get("/members", placeholderHandler("List members"))
-				r.Post("/members", placeholderHandler("Add member"))
-				r.Patch("/members/{memberId}", placeholderHandler("Update member"))
-				r.Delete("/members/{memberId}", placeholderHandler("Remove member"))
-				r.Post("/members/invite", placeholderHandler("Send invite"))
-
-				// Settings
-				r.Get("/settings", placeholderHandler("Get settings"))
-				r.Patch("/settings", placeholderHandler("Update settings"))
-
-				// SDK Tokens
-				r.Get("/sdk-tokens", sdkTokenHandler.List)
-				r.Post("/sdk-tokens", sdkTokenHandler.Create)
-				r.Delete("/sdk-tokens/{tokenId}", sdkTokenHandler.Revoke)
-
-				// Users (identified feedback submitters)
-				r.Get("/users", listProjectUsersHandler(dbPool))
-				r.Get("/users/{userId}/feedback", listUserFeedbackHandler(dbPool))
-			})
-		})
+		// Community and creator routes (JWT, personal access token or service
+		// account token + project membership and role)
+		memberAuth := auth.NewAPITokenProvider(identityProvider, apiTokenRepo)
+		mountMemberRoutes(r, auth.SupabaseAuthMiddleware(memberAuth), membershipResolver, projectLifecycleRepo, organizationRepo, newMemberRoutes(dbPool, routeHandlers{
+			sdkTokens:  sdkTokenHandler,
+			savedViews: savedViewHandlers,
+			exports:    exportHandlers,
+			imports:    importHandlers,
+			analytics:  analyticsHandlers,
+			segments:   segmentHandlers,
+			votes:      voteSvc,
+			teamVotes:  handler.NewVoteHandlers(voteSvc, log.Logger),
+			comments:   commentSvc,
+
+			subscriptions: subscriptionHandlers,
+			notifications: notificationHandlers,
+			realtime:      realtimeHandlers,
+			apiTokens:     apiTokenHandlers,
+			projects:      projectLifecycleHandlers,
+			branding:      brandingHandlers,
+			domains:       customDomainHandlers,
+			organizations: organizationHandlers,
+			roles:         projectRoleHandlers,
+		}))
+
+		// Public portal config by project slug, for the portal frontend and
+		// SDK widgets
+		r.Get("/public/projects/{slug}/config", brandingHandlers.PublicConfig)
 
 		// Portal routes (for feedback users)
-		r.Route("/portal/{projectId}", func(r chi.Router) {
-			// Public endpoint - list public features (optional auth for has_voted tracking)
-			r.Get("/feature-requests", portalHandlers.ListFeatures)
+		portalRoutes := func(r chi.Router) {
+			// Archived projects are read-only for portal users too
+			r.Use(auth.RequireWritableProjectMiddleware(projectLifecycleRepo, projectIDParam))
+
+			// Public endpoints (optional auth for has_voted tracking and attribution)
+			r.Group(func(r chi.Router) {
+				r.Use(auth.OptionalSupabaseAuthMiddleware(identityProvider))
+
+				r.Get("/feature-requests", portalHandlers.ListFeatures)
+				r.Post("/feedback", portalFeedbackHandlers.Submit)
+				r.Get("/feedback/{feedbackId}", portalFeedbackHandlers.Get)
+
+				// Live updates; only COMMUNITY changes are streamed here
+				r.Get("/events", realtimeHandlers.PublicStream)
+				r.Get("/feedback/{feedbackId}/events", realtimeHandlers.PublicStream)
+			})
 
 			// Protected endpoints (Supabase JWT required)
 			r.Group(func(r chi.Router) {
-				r.Use(auth.SupabaseAuthMiddleware(supabaseValidator))
+				r.Use(auth.SupabaseAuthMiddleware(identityProvider))
 				r.Use(handler.PortalAccessMiddleware(portalRepo, log.Logger))
 
 				r.Get("/me", portalHandlers.GetProfile)
 				r.Patch("/me/notifications", portalHandlers.UpdateNotificationPreferences)
 				r.Get("/my-feedback", portalHandlers.ListMyFeedback)
+				r.Get("/me/votes", portalHandlers.GetVoteBudget)
 				r.Post("/feature-requests/{feedbackId}/vote", portalHandlers.Vote)
 				r.Delete("/feature-requests/{feedbackId}/vote", portalHandlers.Unvote)
+				r.Post("/feedback/{feedbackId}/comments", portalFeedbackHandlers.Comment)
+				r.Get("/feedback/{feedbackId}/subscription", subscriptionHandlers.PortalGet)
+				r.Post("/feedback/{feedbackId}/subscription", subscriptionHandlers.PortalSubscribe)
+				r.Delete("/feedback/{feedbackId}/subscription", subscriptionHandlers.PortalUnsubscribe)
+				r.Get("/notifications", notificationHandlers.List)
+				r.Get("/notifications/unread-count", notificationHandlers.UnreadCount)
+				r.Post("/notifications/read-all", notificationHandlers.MarkAllRead)
+				r.Post("/notifications/{notificationId}/read", notificationHandlers.MarkRead)
 			})
+		}
+		r.Route("/portal/{projectId}", portalRoutes)
+
+		// The same portal and its config on a verified custom domain, where
+		// the project comes from the Host header
+		r.Route("/domain", func(r chi.Router) {
+			r.Use(handler.CustomDomainMiddleware(customDomainSvc))
+			r.Get("/config", brandingHandlers.PublicConfigForProject)
+			r.Route("/portal", portalRoutes)
 		})
 
 		// Invite acceptance (public with Supabase JWT)
@@ -227,6 +305,20 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Fan notification events out to subscribers, relay realtime events to
+	// SSE clients, fail exports lost to restarts and keep identity provider
+	// signing keys fresh until shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go notificationWorker.Run(workerCtx)
+	go projectPurgeWorker.Run(workerCtx)
+	go exportJobSweeper.Run(workerCtx)
+	go realtimeBroker.Run(workerCtx)
+	go identityProvider.Run(workerCtx)
+
+	// Open event streams would otherwise hold up graceful shutdown
+	srv.RegisterOnShutdown(realtimeBroker.DisconnectAll)
+
 	// Start server in goroutine
 	go func() {
 		log.Info().Msgf("Server listening on :%d", cfg.Port)
@@ -241,6 +333,7 @@ func main() {
 	<-quit
 
 	log.Info().Msg("Shutting down server...")
+	stopWorkers()
 
 	// Graceful shutdown with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -267,6 +360,28 @@ func setupLogging(cfg *config.Config) {
 	}
 }
 
+func setupIdentityProvider(ctx context.Context, cfg *config.Config) (auth.IdentityProvider, error) {
+	if cfg.AuthProvider == "oidc" {
+		return auth.NewOIDCProvider(ctx, auth.OIDCConfig{
+			Issuer:   cfg.OIDCIssuer,
+			Audience: cfg.OIDCAudience,
+			Claims: auth.ClaimMapping{
+				Subject: cfg.OIDCSubjectClaim,
+				Email:   cfg.OIDCEmailClaim,
+				Name:    cfg.OIDCNameClaim,
+			},
+			KeyTTL: cfg.OIDCJWKSRefresh,
+		})
+	}
+
+	return auth.NewSupabaseValidator(auth.SupabaseConfig{
+		URL:       cfg.SupabaseURL,
+		JWTSecret: cfg.SupabaseJWTSecret,
+		JWKSFile:  cfg.SupabaseJWKSFile,
+		KeyTTL:    cfg.SupabaseJWKSRefresh,
+	})
+}
+
 func setupDatabase(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
 	poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL)
 	if err != nil {
@@ -291,7 +406,7 @@ func setupDatabase(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, erro
 	return pool, nil
 }
 
-func setupRouter(cfg *config.Config) *chi.Mux {
+func setupRouter(cfg *config.Config, customDomains service.CustomDomainService) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware stack
@@ -301,11 +416,11 @@ func setupRouter(cfg *config.Config) *chi.Mux {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 
-	// CORS
+	// CORS; verified custom domains are allowed as they are added
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowOriginFunc:  allowOrigin(cfg.AllowedOrigins, customDomains),
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-SDK-Token", "X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-SDK-Token", "X-SDK-User", "X-SDK-Bundle-ID", "X-Request-ID", "Last-Event-ID"},
 		ExposedHeaders:   []string{"Link", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -314,6 +429,25 @@ func setupRouter(cfg *config.Config) *chi.Mux {
 	return r
 }
 
+// allowOrigin accepts the configured origins, which may contain one "*"
+// wildcard, and https origins on verified custom domains
+func allowOrigin(allowed []string, customDomains service.CustomDomainService) func(r *http.Request, origin string) bool {
+	return func(r *http.Request, origin string) bool {
+		lower := strings.ToLower(origin)
+		for _, o := range allowed {
+			o = strings.ToLower(o)
+			if o == "*" || o == lower {
+				return true
+			}
+			if prefix, suffix, ok := strings.Cut(o, "*"); ok &&
+				len(lower) >= len(prefix)+len(suffix) && strings.HasPrefix(lower, prefix) && strings.HasSuffix(lower, suffix) {
+				return true
+			}
+		}
+		return customDomains.IsVerifiedOrigin(r.Context(), origin)
+	}
+}
+
 // placeholderHandler returns a handler that returns a placeholder response
 // This will be replaced with actual handlers once the service layer is implemented
 func placeholderHandler(name string) http.HandlerFunc {
@@ -324,15 +458,25 @@ func placeholderHandler(name string) http.HandlerFunc {
 	}
 }
 
-// listProjectsHandler handles GET /creator/projects
+// listProjectsHandler handles GET /creator/projects. Archived projects are
+// listed instead with ?archived=true.
 func listProjectsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.MustUserIDFromContext(r.Context())
+		archived := r.URL.Query().Get("archived") == "true"
+
+		// Only projects the caller belongs to as a team member, directly or
+		// through the organization that owns them
 		rows, err := dbPool.Query(r.Context(), `
-			SELECT id, name, slug, project_key, primary_color, created_at, updated_at
-			FROM projects
-			WHERE archived_at IS NULL
-			ORDER BY created_at DESC
-		`)
+			SELECT p.id, p.name, p.slug, p.project_key, p.primary_color, p.created_at, p.updated_at
+			FROM projects p
+			WHERE (p.archived_at IS NOT NULL) = $2
+				AND (
+					EXISTS (SELECT 1 FROM memberships m WHERE m.project_id = p.id AND m.user_id = $1 AND m.role <> 'community')
+					OR EXISTS (SELECT 1 FROM organization_members om WHERE om.organization_id = p.organization_id AND om.user_id = $1)
+				)
+			ORDER BY p.created_at DESC
+		`, userID, archived)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to list projects")
 			w.Header().Set("Content-Type", "application/json")
@@ -386,11 +530,14 @@ func listProjectsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
-// createProjectHandler handles POST /creator/projects
+// createProjectHandler handles POST /creator/projects. Projects belong to
+// an organization the caller administers; organization_id may be omitted
+// when the caller administers exactly one.
 func createProjectHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
-			Name string `json:"name"`
+			Name           string     `json:"name"`
+			OrganizationID *uuid.UUID `json:"organization_id"`
 		}
 
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -426,18 +573,40 @@ func createProjectHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			UpdatedAt    time.Time `json:"updated_at"`
 		}
 
-		err := dbPool.QueryRow(r.Context(), `
-			INSERT INTO projects (name, slug, project_key, primary_color, settings)
-			VALUES ($1, $2, $3, $4, $5)
+		userID := auth.MustUserIDFromContext(r.Context())
+
+		// The project and its owner membership are created together so the
+		// creator can reach the project through the membership checks
+		tx, err := dbPool.Begin(r.Context())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to begin transaction")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Failed to create project"}`))
+			return
+		}
+		defer tx.Rollback(r.Context())
+
+		organizationID, status, message := organizationForNewProject(r.Context(), tx, userID, req.OrganizationID)
+		if status != 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"error": message})
+			return
+		}
+
+		err = tx.QueryRow(r.Context(), `
+			INSERT INTO projects (name, slug, project_key, primary_color, organization_id, settings)
+			VALUES ($1, $2, $3, $4, $5, $6)
 			RETURNING id, name, slug, project_key, primary_color, created_at, updated_at
-		`, req.Name, slug, projectKey, "#6366f1", `{
+		`, req.Name, slug, projectKey, "#6366f1", organizationID, `{
 			"default_visibility": {"bug": "TEAM_ONLY", "feature": "COMMUNITY", "general": "TEAM_ONLY"},
 			"allow_anonymous_feedback": true,
 			"require_email_for_anonymous": false,
 			"voting_enabled": true,
 			"community_comments_enabled": true,
 			"auto_close_duplicates": true,
-			"notification_preferences": {"new_feedback": true, "status_changes": true, "new_comments": true}
+			"notification_preferences": {"new_feedback": true, "status_changes": true, "new_comments": true, "mentions": true, "assignments": true, "saved_view_matches": false, "inbox": {"mentions": true, "assignments": true, "status_changes": true, "saved_view_matches": true}}
 		}`).Scan(
 			&project.ID, &project.Name, &project.Slug, &project.ProjectKey,
 			&project.PrimaryColor, &project.CreatedAt, &project.UpdatedAt,
@@ -458,17 +627,104 @@ func createProjectHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
+		if _, err := tx.Exec(r.Context(), `
+			INSERT INTO memberships (project_id, user_id, role)
+			VALUES ($1, $2, 'owner')
+		`, project.ID, userID); err != nil {
+			log.Error().Err(err).Msg("Failed to create owner membership")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Failed to create project"}`))
+			return
+		}
+
+		if err := tx.Commit(r.Context()); err != nil {
+			log.Error().Err(err).Msg("Failed to commit project")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Failed to create project"}`))
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(project)
 	}
 }
 
+// organizationForNewProject picks the organization a new project is created
+// in and checks the caller may create it there: they must be an organization
+// admin or owner and the organization must be below its project limit. The
+// organization row is locked so concurrent creations can't both pass the
+// limit. On failure it returns an HTTP status and message.
+func organizationForNewProject(ctx context.Context, tx pgx.Tx, userID uuid.UUID, requested *uuid.UUID) (uuid.UUID, int, string) {
+	if requested == nil {
+		rows, err := tx.Query(ctx, `
+			SELECT organization_id FROM organization_members
+			WHERE user_id = $1 AND role IN ('admin', 'owner')
+		`, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list organizations")
+			return uuid.Nil, http.StatusInternalServerError, "Failed to create project"
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list organizations")
+			return uuid.Nil, http.StatusInternalServerError, "Failed to create project"
+		}
+		switch len(ids) {
+		case 0:
+			return uuid.Nil, http.StatusForbidden, "Creating a project requires being an organization admin"
+		case 1:
+			requested = &ids[0]
+		default:
+			return uuid.Nil, http.StatusBadRequest, "organization_id is required"
+		}
+	}
+
+	var maxProjects *int
+	err := tx.QueryRow(ctx, `SELECT max_projects FROM organizations WHERE id = $1 FOR UPDATE`, *requested).Scan(&maxProjects)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, http.StatusNotFound, "Organization not found"
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to lock organization")
+		return uuid.Nil, http.StatusInternalServerError, "Failed to create project"
+	}
+
+	var role domain.Role
+	err = tx.QueryRow(ctx, `
+		SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2
+	`, *requested, userID).Scan(&role)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Err(err).Msg("Failed to get organization role")
+		return uuid.Nil, http.StatusInternalServerError, "Failed to create project"
+	}
+	if !role.IsAdminOrOwner() {
+		return uuid.Nil, http.StatusForbidden, "Creating a project requires being an organization admin"
+	}
+
+	if maxProjects != nil {
+		var count int
+		if err := tx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM projects WHERE organization_id = $1 AND deletion_scheduled_at IS NULL
+		`, *requested).Scan(&count); err != nil {
+			log.Error().Err(err).Msg("Failed to count organization projects")
+			return uuid.Nil, http.StatusInternalServerError, "Failed to create project"
+		}
+		if count >= *maxProjects {
+			return uuid.Nil, http.StatusConflict, "The organization has reached its project limit"
+		}
+	}
+
+	return *requested, 0, ""
+}
+
 // listFeatureRequestsHandler handles GET /community/projects/:projectId/feature-requests
 func listFeatureRequestsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projectId := chi.URLParam(r, "projectId")
-		userId := "00000000-0000-0000-0000-000000000001" // Hardcoded for now
+		userId := auth.MustUserIDFromContext(r.Context()).String()
 
 		// Get query parameters
 		sort := r.URL.Query().Get("sort")
@@ -487,13 +743,17 @@ func listFeatureRequestsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			orderBy = "f.vote_count DESC, f.created_at DESC"
 		}
 
+		// Authors may delete their own feature requests; anyone else needs
+		// the feedback.delete permission, as in deleteFeatureRequestHandler
+		canDeleteAny := auth.MustMembershipFromContext(r.Context()).HasPermission(domain.PermissionFeedbackDelete)
+
 		// Build status filter
 		var statusFilter string
 		var args []interface{}
-		args = append(args, projectId, userId)
+		args = append(args, projectId, userId, canDeleteAny)
 
 		if status != "" && status != "all" {
-			statusFilter = " AND f.status = $3"
+			statusFilter = " AND f.status = $4"
 			args = append(args, status)
 		}
 
@@ -501,10 +761,9 @@ func listFeatureRequestsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			SELECT f.id, f.project_id, f.author_id, f.title, f.description, f.type, f.status, f.visibility,
 			       f.vote_count, f.comment_count, f.created_at, f.updated_at,
 			       CASE WHEN v.user_id IS NOT NULL THEN true ELSE false END as has_voted,
-			       CASE WHEN f.author_id = $2 OR m.role IN ('admin', 'owner') THEN true ELSE false END as can_delete
+			       CASE WHEN f.author_id = $2 OR $3::boolean THEN true ELSE false END as can_delete
 			FROM feedback f
 			LEFT JOIN votes v ON f.id = v.feedback_id AND v.user_id = $2
-			LEFT JOIN memberships m ON f.project_id = m.project_id AND m.user_id = $2
 			WHERE f.project_id = $1
 			  AND f.type = 'feature'
 			  AND f.visibility = 'COMMUNITY'
@@ -594,7 +853,7 @@ func listFeatureRequestsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 func createFeatureRequestHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projectId := chi.URLParam(r, "projectId")
-		userId := "00000000-0000-0000-0000-000000000001" // Hardcoded for now
+		userId := auth.MustUserIDFromContext(r.Context()).String()
 
 		var req struct {
 			Title       string `json:"title"`
@@ -656,85 +915,75 @@ func createFeatureRequestHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 }
 
 // voteFeatureRequestHandler handles POST /community/projects/:projectId/feature-requests/:feedbackId/vote
-func voteFeatureRequestHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+func voteFeatureRequestHandler(voteSvc service.VoteService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		feedbackId := chi.URLParam(r, "feedbackId")
-
-		// For now, use a random user ID since auth isn't implemented
-		// In production, this would come from the JWT
-		userId := "00000000-0000-0000-0000-000000000001"
-
-		// Try to insert vote (will fail if already voted due to unique constraint)
-		_, err := dbPool.Exec(r.Context(), `
-			INSERT INTO votes (feedback_id, user_id)
-			VALUES ($1, $2)
-			ON CONFLICT (feedback_id, user_id) DO NOTHING
-		`, feedbackId, userId)
+		projectID, feedbackID, ok := parseFeedbackRoute(w, r)
+		if !ok {
+			return
+		}
 
+		details, err := handler.DecodeVoteDetails(r)
 		if err != nil {
-			log.Error().Err(err).Msg("Failed to vote")
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(`{"error":"Failed to vote"}`))
+			handler.HandleError(w, err)
 			return
 		}
 
-		// Get updated vote count
-		var voteCount int
-		err = dbPool.QueryRow(r.Context(), `
-			SELECT vote_count FROM feedback WHERE id = $1
-		`, feedbackId).Scan(&voteCount)
-
+		voter := domain.UserVoter(auth.MustUserIDFromContext(r.Context()))
+		result, err := voteSvc.Vote(r.Context(), projectID, feedbackID, voter, details)
 		if err != nil {
-			log.Error().Err(err).Msg("Failed to get vote count")
+			log.Error().Err(err).Msg("Failed to vote")
+			handler.HandleError(w, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"feedback_id": feedbackId,
-			"vote_count":  voteCount,
-			"has_voted":   true,
-		})
+		writeVoteResult(w, result)
 	}
 }
 
 // unvoteFeatureRequestHandler handles DELETE /community/projects/:projectId/feature-requests/:feedbackId/vote
-func unvoteFeatureRequestHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+func unvoteFeatureRequestHandler(voteSvc service.VoteService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		feedbackId := chi.URLParam(r, "feedbackId")
-
-		// For now, use a random user ID since auth isn't implemented
-		userId := "00000000-0000-0000-0000-000000000001"
-
-		_, err := dbPool.Exec(r.Context(), `
-			DELETE FROM votes WHERE feedback_id = $1 AND user_id = $2
-		`, feedbackId, userId)
+		projectID, feedbackID, ok := parseFeedbackRoute(w, r)
+		if !ok {
+			return
+		}
 
+		voter := domain.UserVoter(auth.MustUserIDFromContext(r.Context()))
+		result, err := voteSvc.Unvote(r.Context(), projectID, feedbackID, voter)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to unvote")
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(`{"error":"Failed to unvote"}`))
+			handler.HandleError(w, err)
 			return
 		}
 
-		// Get updated vote count
-		var voteCount int
-		err = dbPool.QueryRow(r.Context(), `
-			SELECT vote_count FROM feedback WHERE id = $1
-		`, feedbackId).Scan(&voteCount)
+		writeVoteResult(w, result)
+	}
+}
 
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to get vote count")
-		}
+func parseFeedbackRoute(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		handler.Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return uuid.Nil, uuid.Nil, false
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"feedback_id": feedbackId,
-			"vote_count":  voteCount,
-			"has_voted":   false,
-		})
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		handler.Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return uuid.Nil, uuid.Nil, false
 	}
+
+	return projectID, feedbackID, true
+}
+
+func writeVoteResult(w http.ResponseWriter, result *service.VoteResult) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"feedback_id": result.FeedbackID,
+		"vote_count":  result.VoteCount,
+		"vote_score":  result.VoteScore,
+		"has_voted":   result.HasVoted,
+	})
 }
 
 // getProjectHandler handles GET /creator/projects/:projectId
@@ -743,22 +992,27 @@ func getProjectHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 		projectId := chi.URLParam(r, "projectId")
 
 		var project struct {
-			ID           string    `json:"id"`
-			Name         string    `json:"name"`
-			Slug         string    `json:"slug"`
-			ProjectKey   string    `json:"project_key"`
-			PrimaryColor string    `json:"primary_color"`
-			CreatedAt    time.Time `json:"created_at"`
-			UpdatedAt    time.Time `json:"updated_at"`
-		}
-
+			ID                  string     `json:"id"`
+			Name                string     `json:"name"`
+			Slug                string     `json:"slug"`
+			ProjectKey          string     `json:"project_key"`
+			PrimaryColor        string     `json:"primary_color"`
+			CreatedAt           time.Time  `json:"created_at"`
+			UpdatedAt           time.Time  `json:"updated_at"`
+			ArchivedAt          *time.Time `json:"archived_at,omitempty"`
+			DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+		}
+
+		// Archived projects stay readable until they are purged
 		err := dbPool.QueryRow(r.Context(), `
-			SELECT id, name, slug, project_key, primary_color, created_at, updated_at
+			SELECT id, name, slug, project_key, primary_color, created_at, updated_at,
+			       archived_at, deletion_scheduled_at
 			FROM projects
-			WHERE id = $1 AND archived_at IS NULL
+			WHERE id = $1
 		`, projectId).Scan(
 			&project.ID, &project.Name, &project.Slug, &project.ProjectKey,
 			&project.PrimaryColor, &project.CreatedAt, &project.UpdatedAt,
+			&project.ArchivedAt, &project.DeletionScheduledAt,
 		)
 
 		if err != nil {
@@ -774,36 +1028,6 @@ func getProjectHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
-// deleteProjectHandler handles DELETE /creator/projects/:projectId
-func deleteProjectHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		projectId := chi.URLParam(r, "projectId")
-
-		result, err := dbPool.Exec(r.Context(), `
-			DELETE FROM projects WHERE id = $1
-		`, projectId)
-
-		if err != nil {
-			log.Error().Err(err).Str("projectId", projectId).Msg("Failed to delete project")
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(`{"error":"Failed to delete project"}`))
-			return
-		}
-
-		if result.RowsAffected() == 0 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			w.Write([]byte(`{"error":"Project not found"}`))
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"message":"Project deleted"}`))
-	}
-}
-
 func generateRandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, length)
@@ -814,18 +1038,41 @@ func generateRandomString(length int) string {
 }
 
 // listCommentsHandler handles GET /community/projects/:projectId/feature-requests/:feedbackId/comments
-func listCommentsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+func listCommentsHandler(dbPool repository.DBTX) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		projectId := chi.URLParam(r, "projectId")
 		feedbackId := chi.URLParam(r, "feedbackId")
 
+		// The feedback must belong to the project in the URL, since
+		// membership was only checked for that project
+		var found int
+		err := dbPool.QueryRow(r.Context(), `
+			SELECT 1 FROM feedback WHERE id = $1 AND project_id = $2
+		`, feedbackId, projectId).Scan(&found)
+		if errors.Is(err, pgx.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Feature request not found"}`))
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to look up feature request")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Failed to list comments"}`))
+			return
+		}
+
 		rows, err := dbPool.Query(r.Context(), `
-			SELECT id, feedback_id, author_id, body, is_edited, created_at, updated_at
-			FROM comments
-			WHERE feedback_id = $1
-			  AND visibility = 'COMMUNITY'
-			  AND deleted_at IS NULL
-			ORDER BY created_at ASC
-		`, feedbackId)
+			SELECT c.id, c.feedback_id, c.author_id, c.body, c.is_edited, c.created_at, c.updated_at
+			FROM comments c
+			JOIN feedback f ON f.id = c.feedback_id
+			WHERE c.feedback_id = $1
+			  AND f.project_id = $2
+			  AND c.visibility = 'COMMUNITY'
+			  AND c.deleted_at IS NULL
+			ORDER BY c.created_at ASC
+		`, feedbackId, projectId)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to list comments")
 			w.Header().Set("Content-Type", "application/json")
@@ -880,58 +1127,54 @@ func listCommentsHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 }
 
 // createCommentHandler handles POST /community/projects/:projectId/feature-requests/:feedbackId/comments
-func createCommentHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+func createCommentHandler(commentSvc service.CommentService) http.HandlerFunc {
+	return commentHandler(commentSvc, domain.VisibilityCommunity)
+}
+
+// createTeamNoteHandler handles POST /creator/projects/:projectId/feedback/:feedbackId/notes
+func createTeamNoteHandler(commentSvc service.CommentService) http.HandlerFunc {
+	return commentHandler(commentSvc, domain.VisibilityTeamOnly)
+}
+
+// commentHandler creates a comment with the given visibility through the
+// comment service, which also records the members it @mentions
+func commentHandler(commentSvc service.CommentService, visibility domain.Visibility) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		feedbackId := chi.URLParam(r, "feedbackId")
-		userId := "00000000-0000-0000-0000-000000000001" // Hardcoded for now
+		projectID, feedbackID, ok := parseFeedbackRoute(w, r)
+		if !ok {
+			return
+		}
 
 		var input struct {
-			Body string `json:"body"`
+			Body     string     `json:"body"`
+			ParentID *uuid.UUID `json:"parent_id"`
 		}
-		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(`{"error":"Invalid request body"}`))
+		if err := handler.DecodeJSON(r, &input); err != nil {
+			handler.HandleError(w, err)
 			return
 		}
 
+		input.Body = strings.TrimSpace(input.Body)
 		if input.Body == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(`{"error":"Comment body is required"}`))
+			handler.ValidationError(w, map[string]string{"body": "is required"})
 			return
 		}
 
-		var comment struct {
-			ID        string    `json:"id"`
-			FeedbackID string   `json:"feedback_id"`
-			AuthorID  string    `json:"author_id"`
-			Body      string    `json:"body"`
-			IsEdited  bool      `json:"is_edited"`
-			CreatedAt time.Time `json:"created_at"`
-			UpdatedAt time.Time `json:"updated_at"`
-		}
-
-		err := dbPool.QueryRow(r.Context(), `
-			INSERT INTO comments (feedback_id, author_id, body, visibility)
-			VALUES ($1, $2, $3, 'COMMUNITY')
-			RETURNING id, feedback_id, author_id, body, is_edited, created_at, updated_at
-		`, feedbackId, userId, input.Body).Scan(
-			&comment.ID, &comment.FeedbackID, &comment.AuthorID,
-			&comment.Body, &comment.IsEdited, &comment.CreatedAt, &comment.UpdatedAt,
-		)
-
+		comment, err := commentSvc.Create(r.Context(), service.CreateCommentRequest{
+			ProjectID:  projectID,
+			FeedbackID: feedbackID,
+			AuthorID:   auth.MustUserIDFromContext(r.Context()),
+			ParentID:   input.ParentID,
+			Body:       input.Body,
+			Visibility: visibility,
+		})
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to create comment")
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(`{"error":"Failed to create comment"}`))
+			handler.HandleError(w, err)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(comment)
+		handler.Created(w, comment)
 	}
 }
 
@@ -940,17 +1183,18 @@ func deleteFeatureRequestHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projectId := chi.URLParam(r, "projectId")
 		feedbackId := chi.URLParam(r, "feedbackId")
-		userId := "00000000-0000-0000-0000-000000000001" // Hardcoded for now
+		userId := auth.MustUserIDFromContext(r.Context()).String()
+		membership := auth.MustMembershipFromContext(r.Context())
 
-		// Check if user can delete (author or admin/owner)
-		var canDelete bool
+		// Authors may delete their own feature requests; anyone else needs
+		// the feedback.delete permission
+		var isAuthor bool
 		err := dbPool.QueryRow(r.Context(), `
-			SELECT
-				CASE WHEN f.author_id = $3 OR m.role IN ('admin', 'owner') THEN true ELSE false END
+			SELECT f.author_id IS NOT DISTINCT FROM $3::uuid
 			FROM feedback f
-			LEFT JOIN memberships m ON f.project_id = m.project_id AND m.user_id = $3
 			WHERE f.id = $2 AND f.project_id = $1
-		`, projectId, feedbackId, userId).Scan(&canDelete)
+		`, projectId, feedbackId, userId).Scan(&isAuthor)
+		canDelete := isAuthor || membership.HasPermission(domain.PermissionFeedbackDelete)
 
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to check delete permission")
@@ -993,8 +1237,56 @@ func deleteFeatureRequestHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
-// sdkAuthMiddleware validates SDK tokens
-func sdkAuthMiddleware(dbPool *pgxpool.Pool) func(http.Handler) http.Handler {
+// deleteCommentHandler handles DELETE /community/projects/:projectId/feature-requests/:feedbackId/comments/:commentId.
+// Authors may delete their own comments; anyone else needs the
+// comments.moderate permission.
+func deleteCommentHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projectId := chi.URLParam(r, "projectId")
+		feedbackId := chi.URLParam(r, "feedbackId")
+		commentId := chi.URLParam(r, "commentId")
+		userId := auth.MustUserIDFromContext(r.Context())
+		membership := auth.MustMembershipFromContext(r.Context())
+
+		var authorID uuid.UUID
+		err := dbPool.QueryRow(r.Context(), `
+			SELECT c.author_id
+			FROM comments c
+			JOIN feedback f ON f.id = c.feedback_id
+			WHERE c.id = $3 AND c.feedback_id = $2 AND f.project_id = $1
+		`, projectId, feedbackId, commentId).Scan(&authorID)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Error().Err(err).Msg("Failed to get comment")
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Comment not found"}`))
+			return
+		}
+
+		if authorID != userId && !membership.HasPermission(domain.PermissionCommentsModerate) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":"You don't have permission to delete this comment"}`))
+			return
+		}
+
+		if _, err := dbPool.Exec(r.Context(), `DELETE FROM comments WHERE id = $1`, commentId); err != nil {
+			log.Error().Err(err).Msg("Failed to delete comment")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Failed to delete comment"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// sdkAuthMiddleware validates SDK tokens and checks the caller's Origin or
+// native bundle ID against the token's allow-lists
+func sdkAuthMiddleware(validator *auth.SDKTokenValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := r.Header.Get("X-SDK-Token")
@@ -1005,40 +1297,24 @@ func sdkAuthMiddleware(dbPool *pgxpool.Pool) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Hash the token to compare with stored hash
-			tokenHash := hashToken(token)
-
-			// Look up token in database
-			var projectID string
-			var isActive bool
-			err := dbPool.QueryRow(r.Context(), `
-				SELECT project_id, is_active
-				FROM sdk_tokens
-				WHERE token_hash = $1
-			`, tokenHash).Scan(&projectID, &isActive)
-
-			if err != nil {
-				log.Debug().Err(err).Msg("SDK token lookup failed")
+			projectID, err := validator.ValidateToken(r.Context(), token, r.Header.Get("Origin"), r.Header.Get(auth.SDKBundleIDHeader))
+			if errors.Is(err, auth.ErrOriginNotAllowed) {
 				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"error":"Invalid SDK token","code":"INVALID_TOKEN"}`))
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":"Origin not allowed for this SDK token","code":"ORIGIN_NOT_ALLOWED"}`))
 				return
 			}
-
-			if !isActive {
+			if err != nil {
+				log.Debug().Err(err).Msg("SDK token lookup failed")
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"error":"SDK token is inactive","code":"TOKEN_INACTIVE"}`))
+				w.Write([]byte(`{"error":"Invalid SDK token","code":"INVALID_TOKEN"}`))
 				return
 			}
 
-			// Update last_used_at
-			_, _ = dbPool.Exec(r.Context(), `
-				UPDATE sdk_tokens SET last_used_at = NOW() WHERE token_hash = $1
-			`, tokenHash)
-
-			// Store project ID in context
-			ctx := context.WithValue(r.Context(), sdkProjectIDKey, projectID)
+			// Store project ID in context, also in the form internal handlers read
+			ctx := context.WithValue(r.Context(), sdkProjectIDKey, projectID.String())
+			ctx = auth.ContextWithSDKProject(ctx, projectID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -1048,12 +1324,6 @@ type contextKey string
 
 const sdkProjectIDKey contextKey = "sdkProjectID"
 
-func hashToken(token string) string {
-	h := sha256.New()
-	h.Write([]byte(token))
-	return hex.EncodeToString(h.Sum(nil))
-}
-
 // SDKUser represents an identified user from the SDK
 type SDKUser struct {
 	ID         string                 `json:"id"`
@@ -1117,29 +1387,51 @@ func findOrCreateSDKUser(ctx context.Context, dbPool *pgxpool.Pool, projectID, e
 // listCreatorFeedbackHandler handles GET /creator/projects/{projectId}/feedback
 func listCreatorFeedbackHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		projectID := chi.URLParam(r, "projectId")
-		userFilter := r.URL.Query().Get("user")
+		projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+		if err != nil {
+			handler.Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+			return
+		}
 
-		query := `
+		// Filters mirror domain.SavedViewFilter so a saved view can be applied as query params
+		viewFilter, fields := handler.ParseFeedbackFilter(r)
+		if fields != nil {
+			handler.ValidationError(w, fields)
+			return
+		}
+		filter := repository.FeedbackFilterFromView(viewFilter)
+
+		// The filter is applied in a subquery so its unqualified column names
+		// don't clash with the joined sdk_users columns
+		whereClause, args, argIndex := repository.FeedbackConditions(projectID, filter)
+		if userFilter := r.URL.Query().Get("user"); userFilter != "" {
+			whereClause += fmt.Sprintf(" AND (submitter_identifier = $%d OR sdk_user_id IN (SELECT id FROM sdk_users WHERE project_id = $1 AND external_id = $%d))", argIndex, argIndex)
+			args = append(args, userFilter)
+		}
+
+		sortBy := "created_at"
+		switch filter.SortBy {
+		case "updated_at", "vote_count", "vote_score":
+			sortBy = filter.SortBy
+		}
+		sortOrder := "DESC"
+		if filter.SortOrder == "asc" {
+			sortOrder = "ASC"
+		}
+
+		query := fmt.Sprintf(`
 			SELECT
 				f.id, f.title, f.description, f.type, f.status,
-				f.vote_count, f.comment_count, f.source,
+				f.vote_count, f.vote_score, f.comment_count, f.source,
 				f.submitter_email, f.submitter_name, f.submitter_identifier,
 				f.source_metadata, f.created_at, f.updated_at,
 				su.id as sdk_user_id, su.external_id, su.email as sdk_user_email,
 				su.name as sdk_user_name, su.traits as sdk_user_traits
-			FROM feedback f
+			FROM (SELECT * FROM feedback WHERE %s) f
 			LEFT JOIN sdk_users su ON f.sdk_user_id = su.id
-			WHERE f.project_id = $1 AND f.canonical_id IS NULL
-		`
-		args := []interface{}{projectID}
-
-		if userFilter != "" {
-			query += " AND (su.external_id = $2 OR f.submitter_identifier = $2)"
-			args = append(args, userFilter)
-		}
-
-		query += " ORDER BY f.created_at DESC LIMIT 100"
+			ORDER BY f.%s %s
+			LIMIT 100
+		`, whereClause, sortBy, sortOrder)
 
 		rows, err := dbPool.Query(r.Context(), query, args...)
 
@@ -1167,6 +1459,7 @@ func listCreatorFeedbackHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			Type                string                 `json:"type"`
 			Status              string                 `json:"status"`
 			VoteCount           int                    `json:"vote_count"`
+			VoteScore           int                    `json:"vote_score"`
 			CommentCount        int                    `json:"comment_count"`
 			Source              string                 `json:"source"`
 			SubmitterEmail      *string                `json:"submitter_email,omitempty"`
@@ -1189,7 +1482,7 @@ func listCreatorFeedbackHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 
 			err := rows.Scan(
 				&item.ID, &item.Title, &item.Description,
-				&item.Type, &item.Status, &item.VoteCount,
+				&item.Type, &item.Status, &item.VoteCount, &item.VoteScore,
 				&item.CommentCount, &item.Source,
 				&submitterEmail, &submitterName, &submitterIdentifier,
 				&sourceMetadataJSON, &createdAt, &updatedAt,
@@ -1632,9 +1925,24 @@ func sdkIdentifyHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
-// sdkInitiateUploadHandler handles POST /sdk/attachments/init
-func sdkInitiateUploadHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+// sdkAttachmentResource is the signed resource name for an attachment upload
+func sdkAttachmentResource(attachmentID string) string {
+	return "attachments/" + attachmentID
+}
+
+// sdkInitiateUploadHandler handles POST /sdk/attachments/init. The returned
+// upload URL is signed for this attachment, content type and size, and
+// expires after uploadExpiry.
+func sdkInitiateUploadHandler(dbPool *pgxpool.Pool, signer *storage.URLSigner, publicURL string, uploadExpiry time.Duration) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		projectID, ok := r.Context().Value(sdkProjectIDKey).(string)
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"SDK authentication required","code":"UNAUTHORIZED"}`))
+			return
+		}
+
 		var req struct {
 			FeedbackID  string `json:"feedback_id"`
 			Filename    string `json:"filename"`
@@ -1668,18 +1976,42 @@ func sdkInitiateUploadHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			w.Write([]byte(`{"error":"content_type is required","code":"VALIDATION_ERROR"}`))
 			return
 		}
+		if !domain.IsContentTypeAllowed(req.ContentType) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"This file type is not allowed","code":"INVALID_CONTENT_TYPE"}`))
+			return
+		}
+		if req.SizeBytes <= 0 || req.SizeBytes > domain.MaxAttachmentSize {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"size_bytes must be between 1 byte and 25MB","code":"FILE_TOO_LARGE"}`))
+			return
+		}
 
-		// Generate storage path for local development
-		storagePath := fmt.Sprintf("uploads/%s/%s", req.FeedbackID, req.Filename)
+		// Generate storage path; each attachment gets its own directory so
+		// uploads with the same filename don't overwrite each other, and the
+		// filename is reduced to its base name so it can't walk out of it
+		attachmentID := uuid.New()
+		storagePath := fmt.Sprintf("uploads/%s/%s/%s", req.FeedbackID, attachmentID, path.Base(req.Filename))
+		expiresAt := time.Now().Add(uploadExpiry)
 
-		// Create attachment record
+		// Create attachment record, only for feedback in the token's project
 		var id string
 		err := dbPool.QueryRow(r.Context(), `
-			INSERT INTO attachments (feedback_id, filename, content_type, size_bytes, status, gcs_bucket, gcs_path)
-			VALUES ($1, $2, $3, $4, 'pending', 'local', $5)
+			INSERT INTO attachments (id, feedback_id, filename, content_type, size_bytes, status, gcs_bucket, gcs_path, upload_expires_at)
+			SELECT $8, f.id, $2, $3, $4, 'pending', 'local', $5, $6
+			FROM feedback f
+			WHERE f.id = $1 AND f.project_id = $7
 			RETURNING id
-		`, req.FeedbackID, req.Filename, req.ContentType, req.SizeBytes, storagePath).Scan(&id)
+		`, req.FeedbackID, req.Filename, req.ContentType, req.SizeBytes, storagePath, expiresAt, projectID, attachmentID).Scan(&id)
 
+		if errors.Is(err, pgx.ErrNoRows) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Feedback not found","code":"NOT_FOUND"}`))
+			return
+		}
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to create attachment record")
 			w.Header().Set("Content-Type", "application/json")
@@ -1688,16 +2020,20 @@ func sdkInitiateUploadHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		// For local development, we'll use a simple upload endpoint
-		// In production, this would be a presigned cloud storage URL
-		uploadURL := fmt.Sprintf("http://localhost:8080/api/sdk/attachments/%s/upload", id)
-		expiresAt := time.Now().Add(1 * time.Hour).Format(time.RFC3339)
+		q := signer.Sign(storage.SignedRequest{
+			Method:      http.MethodPut,
+			Resource:    sdkAttachmentResource(id),
+			ContentType: req.ContentType,
+			MaxBytes:    req.SizeBytes,
+			ExpiresAt:   expiresAt,
+		})
+		uploadURL := fmt.Sprintf("%s/api/sdk/attachments/%s/upload?%s", strings.TrimRight(publicURL, "/"), id, q.Encode())
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"attachment_id": id,
 			"upload_url":    uploadURL,
-			"expires_at":    expiresAt,
+			"expires_at":    expiresAt.Format(time.RFC3339),
 		})
 	}
 }
@@ -1755,14 +2091,30 @@ func sdkCompleteUploadHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 	}
 }
 
-// sdkUploadFileHandler handles PUT /sdk/attachments/{attachmentId}/upload
-func sdkUploadFileHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
+// sdkUploadFileHandler handles PUT /sdk/attachments/{attachmentId}/upload.
+// The signed query parameters are checked, along with the Content-Type and
+// Content-Length they allow, before any of the body is read.
+func sdkUploadFileHandler(dbPool *pgxpool.Pool, objectStorage storage.ObjectStorage, signer *storage.URLSigner) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		attachmentID := chi.URLParam(r, "attachmentId")
 
+		grant, err := signer.Verify(http.MethodPut, sdkAttachmentResource(attachmentID), r.URL.Query())
+		if err != nil {
+			handler.SignedURLError(w, err)
+			return
+		}
+		if !handler.CheckSignedUpload(w, r, grant.ContentType, grant.MaxBytes) {
+			return
+		}
+
+		if objectStorage == nil {
+			handler.Error(w, http.StatusServiceUnavailable, "STORAGE_UNAVAILABLE", "File storage is not configured")
+			return
+		}
+
 		// Verify attachment exists and is pending
 		var gcsPath string
-		err := dbPool.QueryRow(r.Context(), `
+		err = dbPool.QueryRow(r.Context(), `
 			SELECT gcs_path FROM attachments WHERE id = $1 AND status = 'pending'
 		`, attachmentID).Scan(&gcsPath)
 
@@ -1774,22 +2126,21 @@ func sdkUploadFileHandler(dbPool *pgxpool.Pool) http.HandlerFunc {
 			return
 		}
 
-		// For local development, just read and discard the body
-		// In production, this would upload to cloud storage
-		body, err := io.ReadAll(io.LimitReader(r.Body, 25*1024*1024)) // 25MB limit
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to read upload body")
+		body := http.MaxBytesReader(w, r.Body, grant.MaxBytes)
+		if err := objectStorage.Upload(r.Context(), gcsPath, grant.ContentType, body); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				_ = objectStorage.Delete(r.Context(), gcsPath)
+				handler.Error(w, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Upload exceeds the signed size limit")
+				return
+			}
+			log.Error().Err(err).Str("attachment_id", attachmentID).Msg("Failed to store upload")
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(`{"error":"Failed to read upload data","code":"UPLOAD_FAILED"}`))
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Failed to store upload data","code":"UPLOAD_FAILED"}`))
 			return
 		}
 
-		log.Info().
-			Str("attachment_id", attachmentID).
-			Int("size_bytes", len(body)).
-			Msg("Received file upload (local dev - file not persisted)")
-
 		// Update attachment status
 		_, err = dbPool.Exec(r.Context(), `
 			UPDATE attachments SET status = 'uploaded', uploaded_at = NOW() WHERE id = $1
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/handler"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// protectedRoute is a route and the permission needed to call it. An empty
+// permission only requires a membership; it is used by the community routes
+// and by organization routes that every member may call.
+type protectedRoute struct {
+	method     string
+	pattern    string
+	permission domain.Permission
+	handler    http.HandlerFunc
+}
+
+// memberRoutes are the routes that require a signed-in user
+type memberRoutes struct {
+	// Routes under /community/projects/{projectId}
+	community []protectedRoute
+	// Routes under /creator that are not scoped to a project; any signed-in
+	// user may call them, so permission is unused. Service accounts may not.
+	creator []protectedRoute
+	// Routes under /creator/projects/{projectId}; read-only while the
+	// project is archived
+	creatorProject []protectedRoute
+	// Routes under /creator/projects/{projectId} that stay writable while
+	// the project is archived
+	projectLifecycle []protectedRoute
+	// Routes under /creator/organizations/{orgId}; permissions are those of
+	// the caller's organization role
+	organization []protectedRoute
+}
+
+// routeHandlers holds the handler structs referenced by the route tables
+type routeHandlers struct {
+	sdkTokens  *handler.SDKTokenHandler
+	savedViews *handler.SavedViewHandlers
+	exports    *handler.ExportHandlers
+	imports    *handler.ImportHandlers
+	analytics  *handler.AnalyticsHandlers
+	segments   *handler.SegmentHandlers
+	votes      service.VoteService
+	teamVotes  *handler.VoteHandlers
+	comments   service.CommentService
+
+	subscriptions *handler.SubscriptionHandlers
+	notifications *handler.NotificationHandlers
+	realtime      *handler.RealtimeHandlers
+	apiTokens     *handler.APITokenHandlers
+	projects      *handler.ProjectLifecycleHandlers
+	branding      *handler.BrandingHandlers
+	domains       *handler.CustomDomainHandlers
+	organizations *handler.OrganizationHandlers
+	roles         *handler.ProjectRoleHandlers
+}
+
+// newMemberRoutes builds the community and creator route tables. Community
+// routes are open to every member; creator routes need a permission. The
+// built-in roles' defaults (domain.RolePermissions) leave viewers read-only,
+// let members write, let admins manage settings, members and tokens, and
+// only let owners archive, delete or transfer the project. Custom roles
+// grant their own permission sets.
+func newMemberRoutes(dbPool *pgxpool.Pool, h routeHandlers) memberRoutes {
+	return memberRoutes{
+		community: []protectedRoute{
+			{http.MethodGet, "/feature-requests", "", listFeatureRequestsHandler(dbPool)},
+			{http.MethodPost, "/feature-requests", "", createFeatureRequestHandler(dbPool)},
+			{http.MethodGet, "/feature-requests/{feedbackId}", "", placeholderHandler("Get feature request")},
+			// Authors may delete their own requests; the handler checks ownership
+			{http.MethodDelete, "/feature-requests/{feedbackId}", "", deleteFeatureRequestHandler(dbPool)},
+			{http.MethodPost, "/feature-requests/{feedbackId}/vote", "", voteFeatureRequestHandler(h.votes)},
+			{http.MethodDelete, "/feature-requests/{feedbackId}/vote", "", unvoteFeatureRequestHandler(h.votes)},
+			{http.MethodGet, "/feature-requests/{feedbackId}/comments", "", listCommentsHandler(dbPool)},
+			{http.MethodPost, "/feature-requests/{feedbackId}/comments", "", createCommentHandler(h.comments)},
+			// Authors may delete their own comments; moderators any comment
+			{http.MethodDelete, "/feature-requests/{feedbackId}/comments/{commentId}", "", deleteCommentHandler(dbPool)},
+		},
+
+		creator: []protectedRoute{
+			{method: http.MethodGet, pattern: "/projects", handler: listProjectsHandler(dbPool)},
+			{method: http.MethodPost, pattern: "/projects", handler: createProjectHandler(dbPool)},
+
+			// Personal access tokens
+			{method: http.MethodGet, pattern: "/tokens", handler: h.apiTokens.ListPersonal},
+			{method: http.MethodPost, pattern: "/tokens", handler: h.apiTokens.CreatePersonal},
+			{method: http.MethodDelete, pattern: "/tokens/{tokenId}", handler: h.apiTokens.RevokePersonal},
+
+			// Organizations the caller belongs to; creating one makes them its owner
+			{method: http.MethodGet, pattern: "/organizations", handler: h.organizations.List},
+			{method: http.MethodPost, pattern: "/organizations", handler: h.organizations.Create},
+		},
+
+		creatorProject: []protectedRoute{
+			// Project CRUD
+			{http.MethodGet, "/", domain.PermissionFeedbackView, getProjectHandler(dbPool)},
+
+			// Ownership transfer; the new owner accepts it
+			{http.MethodGet, "/transfer", domain.PermissionFeedbackView, h.projects.GetTransfer},
+			{http.MethodPost, "/transfer", domain.PermissionProjectManage, h.projects.RequestTransfer},
+			{http.MethodDelete, "/transfer", domain.PermissionProjectManage, h.projects.CancelTransfer},
+			{http.MethodPost, "/transfer/accept", domain.PermissionFeedbackView, h.projects.AcceptTransfer},
+
+			// Feedback
+			{http.MethodGet, "/feedback", domain.PermissionFeedbackView, listCreatorFeedbackHandler(dbPool)},
+			{http.MethodPost, "/feedback", domain.PermissionFeedbackCreate, placeholderHandler("Create feedback")},
+			{http.MethodGet, "/feedback/{feedbackId}", domain.PermissionFeedbackView, placeholderHandler("Get feedback")},
+			{http.MethodPatch, "/feedback/{feedbackId}", domain.PermissionFeedbackTriage, placeholderHandler("Update feedback")},
+			{http.MethodPost, "/feedback/{feedbackId}/merge", domain.PermissionFeedbackTriage, placeholderHandler("Merge feedback")},
+			{http.MethodPost, "/feedback/{feedbackId}/notes", domain.PermissionFeedbackCreate, createTeamNoteHandler(h.comments)},
+			{http.MethodGet, "/feedback/{feedbackId}/voters", domain.PermissionFeedbackView, h.segments.ListVoters},
+			{http.MethodPost, "/feedback/{feedbackId}/votes", domain.PermissionFeedbackCreate, h.teamVotes.Record},
+
+			// Watching items is personal; viewers may watch too
+			{http.MethodGet, "/feedback/{feedbackId}/subscription", domain.PermissionFeedbackView, h.subscriptions.TeamGet},
+			{http.MethodPost, "/feedback/{feedbackId}/subscription", domain.PermissionFeedbackView, h.subscriptions.TeamSubscribe},
+			{http.MethodDelete, "/feedback/{feedbackId}/subscription", domain.PermissionFeedbackView, h.subscriptions.TeamUnsubscribe},
+
+			// Live updates for the dashboard
+			{http.MethodGet, "/events", domain.PermissionFeedbackView, h.realtime.TeamStream},
+			{http.MethodGet, "/feedback/{feedbackId}/events", domain.PermissionFeedbackView, h.realtime.TeamStream},
+
+			// The notification inbox and its preferences are personal
+			{http.MethodGet, "/notifications", domain.PermissionFeedbackView, h.notifications.List},
+			{http.MethodGet, "/notifications/unread-count", domain.PermissionFeedbackView, h.notifications.UnreadCount},
+			{http.MethodPost, "/notifications/read-all", domain.PermissionFeedbackView, h.notifications.MarkAllRead},
+			{http.MethodPost, "/notifications/{notificationId}/read", domain.PermissionFeedbackView, h.notifications.MarkRead},
+			{http.MethodGet, "/notifications/preferences", domain.PermissionFeedbackView, h.notifications.GetPreferences},
+			{http.MethodPatch, "/notifications/preferences", domain.PermissionFeedbackView, h.notifications.UpdatePreferences},
+
+			// Saved views are personal; viewers may track what they have seen
+			{http.MethodGet, "/views", domain.PermissionFeedbackView, h.savedViews.List},
+			{http.MethodPost, "/views", domain.PermissionFeedbackView, h.savedViews.Create},
+			{http.MethodGet, "/views/counts", domain.PermissionFeedbackView, h.savedViews.Counts},
+			{http.MethodPatch, "/views/{viewId}", domain.PermissionFeedbackView, h.savedViews.Update},
+			{http.MethodDelete, "/views/{viewId}", domain.PermissionFeedbackView, h.savedViews.Delete},
+			{http.MethodPost, "/views/{viewId}/seen", domain.PermissionFeedbackView, h.savedViews.MarkSeen},
+
+			// Export and import
+			{http.MethodGet, "/export", domain.PermissionExport, h.exports.Export},
+			{http.MethodGet, "/exports", domain.PermissionExport, h.exports.ListJobs},
+			{http.MethodGet, "/exports/{jobId}", domain.PermissionExport, h.exports.GetJob},
+			{http.MethodPost, "/import", domain.PermissionFeedbackCreate, h.imports.ImportJSON},
+			{http.MethodPost, "/import/csv", domain.PermissionFeedbackCreate, h.imports.ImportCSV},
+
+			// Segments and prioritization
+			{http.MethodGet, "/segments", domain.PermissionFeedbackView, h.segments.List},
+			{http.MethodPost, "/segments", domain.PermissionFeedbackTriage, h.segments.Create},
+			{http.MethodGet, "/segments/{segmentId}", domain.PermissionFeedbackView, h.segments.Get},
+			{http.MethodPut, "/segments/{segmentId}", domain.PermissionFeedbackTriage, h.segments.Update},
+			{http.MethodDelete, "/segments/{segmentId}", domain.PermissionFeedbackTriage, h.segments.Delete},
+			{http.MethodGet, "/segments/{segmentId}/feedback", domain.PermissionFeedbackView, h.segments.ListFeedback},
+			{http.MethodGet, "/priorities", domain.PermissionFeedbackView, h.segments.Priorities},
+			{http.MethodGet, "/prioritization", domain.PermissionFeedbackView, h.segments.GetPrioritization},
+			{http.MethodPatch, "/prioritization", domain.PermissionSettingsEdit, h.segments.UpdatePrioritization},
+
+			// Tags
+			{http.MethodGet, "/tags", domain.PermissionFeedbackView, placeholderHandler("List tags")},
+			{http.MethodPost, "/tags", domain.PermissionFeedbackTriage, placeholderHandler("Create tag")},
+			{http.MethodPatch, "/tags/{tagId}", domain.PermissionFeedbackTriage, placeholderHandler("Update tag")},
+			{http.MethodDelete, "/tags/{tagId}", domain.PermissionFeedbackTriage, placeholderHandler("Delete tag")},
+
+			// Members
+			{http.MethodGet, "/members", domain.PermissionFeedbackView, placeholderHandler("List members")},
+			{http.MethodPost, "/members", domain.PermissionMembersManage, placeholderHandler("Add member")},
+			{http.MethodPatch, "/members/{memberId}", domain.PermissionMembersManage, placeholderHandler("Update member")},
+			{http.MethodDelete, "/members/{memberId}", domain.PermissionMembersManage, placeholderHandler("Remove member")},
+			{http.MethodPost, "/members/invite", domain.PermissionMembersManage, placeholderHandler("Send invite")},
+			{http.MethodPut, "/members/{memberId}/role", domain.PermissionMembersManage, h.roles.Assign},
+
+			// Permissions and custom roles
+			{http.MethodGet, "/permissions", domain.PermissionFeedbackView, h.roles.MyPermissions},
+			{http.MethodGet, "/roles", domain.PermissionFeedbackView, h.roles.List},
+			{http.MethodPost, "/roles", domain.PermissionMembersManage, h.roles.Create},
+			{http.MethodPatch, "/roles/{roleId}", domain.PermissionMembersManage, h.roles.Update},
+			{http.MethodDelete, "/roles/{roleId}", domain.PermissionMembersManage, h.roles.Delete},
+
+			// Settings
+			{http.MethodGet, "/settings", domain.PermissionFeedbackView, placeholderHandler("Get settings")},
+			{http.MethodPatch, "/settings", domain.PermissionSettingsEdit, placeholderHandler("Update settings")},
+
+			// Branding and public portal config; the logo is uploaded to a
+			// signed URL from /branding/logo/init, then confirmed with PUT
+			{http.MethodGet, "/branding", domain.PermissionFeedbackView, h.branding.Get},
+			{http.MethodPatch, "/branding", domain.PermissionSettingsEdit, h.branding.Update},
+			{http.MethodPost, "/branding/logo/init", domain.PermissionSettingsEdit, h.branding.InitiateLogoUpload},
+			{http.MethodPut, "/branding/logo", domain.PermissionSettingsEdit, h.branding.CompleteLogoUpload},
+			{http.MethodDelete, "/branding/logo", domain.PermissionSettingsEdit, h.branding.RemoveLogo},
+
+			// Custom portal domain, verified through a DNS TXT record
+			{http.MethodGet, "/domain", domain.PermissionFeedbackView, h.domains.Get},
+			{http.MethodPut, "/domain", domain.PermissionSettingsEdit, h.domains.Register},
+			{http.MethodPost, "/domain/verify", domain.PermissionSettingsEdit, h.domains.Verify},
+			{http.MethodDelete, "/domain", domain.PermissionSettingsEdit, h.domains.Remove},
+
+			// SDK Tokens
+			{http.MethodGet, "/sdk-tokens", domain.PermissionTokensManage, h.sdkTokens.List},
+			{http.MethodPost, "/sdk-tokens", domain.PermissionTokensManage, h.sdkTokens.Create},
+			{http.MethodDelete, "/sdk-tokens/{tokenId}", domain.PermissionTokensManage, h.sdkTokens.Revoke},
+
+			// Service accounts
+			{http.MethodGet, "/service-accounts", domain.PermissionTokensManage, h.apiTokens.ListServiceAccounts},
+			{http.MethodPost, "/service-accounts", domain.PermissionTokensManage, h.apiTokens.CreateServiceAccount},
+			{http.MethodDelete, "/service-accounts/{accountId}", domain.PermissionTokensManage, h.apiTokens.DisableServiceAccount},
+			{http.MethodGet, "/service-accounts/{accountId}/tokens", domain.PermissionTokensManage, h.apiTokens.ListServiceAccountTokens},
+			{http.MethodPost, "/service-accounts/{accountId}/tokens", domain.PermissionTokensManage, h.apiTokens.CreateServiceAccountToken},
+			{http.MethodDelete, "/service-accounts/{accountId}/tokens/{tokenId}", domain.PermissionTokensManage, h.apiTokens.RevokeServiceAccountToken},
+
+			// Analytics (chart-ready series)
+			{http.MethodGet, "/analytics/volume", domain.PermissionFeedbackView, h.analytics.Volume},
+			{http.MethodGet, "/analytics/resolution", domain.PermissionFeedbackView, h.analytics.Resolution},
+			{http.MethodGet, "/analytics/funnel", domain.PermissionFeedbackView, h.analytics.Funnel},
+			{http.MethodGet, "/analytics/top-requests", domain.PermissionFeedbackView, h.analytics.TopRequests},
+			{http.MethodGet, "/analytics/vote-velocity", domain.PermissionFeedbackView, h.analytics.VoteVelocity},
+			{http.MethodGet, "/analytics/active-users", domain.PermissionFeedbackView, h.analytics.ActiveUsers},
+
+			// Users (identified feedback submitters)
+			{http.MethodGet, "/users", domain.PermissionFeedbackView, listProjectUsersHandler(dbPool)},
+			{http.MethodGet, "/users/{userId}/feedback", domain.PermissionFeedbackView, listUserFeedbackHandler(dbPool)},
+		},
+
+		projectLifecycle: []protectedRoute{
+			{http.MethodPost, "/archive", domain.PermissionProjectManage, h.projects.Archive},
+			{http.MethodPost, "/restore", domain.PermissionProjectManage, h.projects.Restore},
+			// Archives the project and schedules its purge
+			{http.MethodDelete, "/", domain.PermissionProjectManage, h.projects.Delete},
+		},
+
+		organization: []protectedRoute{
+			{http.MethodGet, "/", "", h.organizations.Get},
+			{http.MethodPatch, "/", domain.PermissionSettingsEdit, h.organizations.Update},
+			{http.MethodGet, "/projects", "", h.organizations.ListProjects},
+			{http.MethodGet, "/search", "", h.organizations.Search},
+
+			// Members; the service also checks the caller outranks the
+			// member whose role changes
+			{http.MethodGet, "/members", "", h.organizations.ListMembers},
+			{http.MethodPost, "/members", domain.PermissionMembersManage, h.organizations.AddMember},
+			{http.MethodPatch, "/members/{userId}", domain.PermissionMembersManage, h.organizations.UpdateMember},
+			// Members may remove themselves; the service checks the role otherwise
+			{http.MethodDelete, "/members/{userId}", "", h.organizations.RemoveMember},
+		},
+	}
+}
+
+// mountMemberRoutes registers the /community and /creator route groups.
+// Every route requires authentication; project routes also require a
+// membership in the project and the route's permission. Archived projects
+// only accept reads, apart from the lifecycle routes. Organization routes
+// require an organization membership instead and are closed to service
+// accounts.
+func mountMemberRoutes(r chi.Router, authenticate func(http.Handler) http.Handler, memberships auth.MembershipLoader, projects auth.ArchivedProjectChecker, orgMembers auth.OrganizationMemberLoader, routes memberRoutes) {
+	writable := auth.RequireWritableProjectMiddleware(projects, projectIDParam)
+
+	r.Route("/community", func(r chi.Router) {
+		r.Use(authenticate)
+		r.Route("/projects/{projectId}", func(r chi.Router) {
+			r.Use(auth.RequireMembershipMiddleware(memberships, projectIDParam))
+			mountProjectRoutes(r, routes.community, writable)
+		})
+	})
+
+	r.Route("/creator", func(r chi.Router) {
+		r.Use(authenticate)
+		for _, route := range routes.creator {
+			r.With(auth.RequireUserMiddleware()).Method(route.method, route.pattern, route.handler)
+		}
+		r.Route("/projects/{projectId}", func(r chi.Router) {
+			r.Use(auth.RequireMembershipMiddleware(memberships, projectIDParam))
+			mountProjectRoutes(r, routes.creatorProject, writable)
+			mountProjectRoutes(r, routes.projectLifecycle)
+		})
+		r.Route("/organizations/{orgId}", func(r chi.Router) {
+			r.Use(auth.RequireUserMiddleware())
+			r.Use(auth.RequireOrganizationMembershipMiddleware(orgMembers, organizationIDParam))
+			for _, route := range routes.organization {
+				r.With(permissionMiddleware(route.permission, auth.RequireOrganizationPermissionMiddleware)...).Method(route.method, route.pattern, route.handler)
+			}
+		})
+	})
+}
+
+func mountProjectRoutes(r chi.Router, routes []protectedRoute, middlewares ...func(http.Handler) http.Handler) {
+	for _, route := range routes {
+		r.With(permissionMiddleware(route.permission, auth.RequirePermissionMiddleware)...).With(middlewares...).Method(route.method, route.pattern, route.handler)
+	}
+}
+
+// permissionMiddleware returns the check for a route's permission, or none
+// when the route only requires a membership
+func permissionMiddleware(permission domain.Permission, require func(domain.Permission) func(http.Handler) http.Handler) []func(http.Handler) http.Handler {
+	if permission == "" {
+		return nil
+	}
+	return []func(http.Handler) http.Handler{require(permission)}
+}
+
+func projectIDParam(r *http.Request) string {
+	return chi.URLParam(r, "projectId")
+}
+
+func organizationIDParam(r *http.Request) string {
+	return chi.URLParam(r, "orgId")
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// expectedPolicy is the authorization matrix: the permission needed for
+// every project route ("" = any member). Adding a route without an entry
+// here fails the tests.
+var expectedPolicy = map[string]domain.Permission{
+	"GET /community/feature-requests":                                      "",
+	"POST /community/feature-requests":                                     "",
+	"GET /community/feature-requests/{feedbackId}":                         "",
+	"DELETE /community/feature-requests/{feedbackId}":                      "",
+	"POST /community/feature-requests/{feedbackId}/vote":                   "",
+	"DELETE /community/feature-requests/{feedbackId}/vote":                 "",
+	"GET /community/feature-requests/{feedbackId}/comments":                "",
+	"POST /community/feature-requests/{feedbackId}/comments":               "",
+	"DELETE /community/feature-requests/{feedbackId}/comments/{commentId}": "",
+
+	"GET /creator/":                                                 domain.PermissionFeedbackView,
+	"GET /creator/transfer":                                         domain.PermissionFeedbackView,
+	"POST /creator/transfer":                                        domain.PermissionProjectManage,
+	"DELETE /creator/transfer":                                      domain.PermissionProjectManage,
+	"POST /creator/transfer/accept":                                 domain.PermissionFeedbackView,
+	"GET /creator/feedback":                                         domain.PermissionFeedbackView,
+	"POST /creator/feedback":                                        domain.PermissionFeedbackCreate,
+	"GET /creator/feedback/{feedbackId}":                            domain.PermissionFeedbackView,
+	"PATCH /creator/feedback/{feedbackId}":                          domain.PermissionFeedbackTriage,
+	"POST /creator/feedback/{feedbackId}/merge":                     domain.PermissionFeedbackTriage,
+	"POST /creator/feedback/{feedbackId}/notes":                     domain.PermissionFeedbackCreate,
+	"GET /creator/feedback/{feedbackId}/voters":                     domain.PermissionFeedbackView,
+	"POST /creator/feedback/{feedbackId}/votes":                     domain.PermissionFeedbackCreate,
+	"GET /creator/feedback/{feedbackId}/subscription":               domain.PermissionFeedbackView,
+	"POST /creator/feedback/{feedbackId}/subscription":              domain.PermissionFeedbackView,
+	"DELETE /creator/feedback/{feedbackId}/subscription":            domain.PermissionFeedbackView,
+	"GET /creator/events":                                           domain.PermissionFeedbackView,
+	"GET /creator/feedback/{feedbackId}/events":                     domain.PermissionFeedbackView,
+	"GET /creator/notifications":                                    domain.PermissionFeedbackView,
+	"GET /creator/notifications/unread-count":                       domain.PermissionFeedbackView,
+	"POST /creator/notifications/read-all":                          domain.PermissionFeedbackView,
+	"POST /creator/notifications/{notificationId}/read":             domain.PermissionFeedbackView,
+	"GET /creator/notifications/preferences":                        domain.PermissionFeedbackView,
+	"PATCH /creator/notifications/preferences":                      domain.PermissionFeedbackView,
+	"GET /creator/views":                                            domain.PermissionFeedbackView,
+	"POST /creator/views":                                           domain.PermissionFeedbackView,
+	"GET /creator/views/counts":                                     domain.PermissionFeedbackView,
+	"PATCH /creator/views/{viewId}":                                 domain.PermissionFeedbackView,
+	"DELETE /creator/views/{viewId}":                                domain.PermissionFeedbackView,
+	"POST /creator/views/{viewId}/seen":                             domain.PermissionFeedbackView,
+	"GET /creator/export":                                           domain.PermissionExport,
+	"GET /creator/exports":                                          domain.PermissionExport,
+	"GET /creator/exports/{jobId}":                                  domain.PermissionExport,
+	"POST /creator/import":                                          domain.PermissionFeedbackCreate,
+	"POST /creator/import/csv":                                      domain.PermissionFeedbackCreate,
+	"GET /creator/segments":                                         domain.PermissionFeedbackView,
+	"POST /creator/segments":                                        domain.PermissionFeedbackTriage,
+	"GET /creator/segments/{segmentId}":                             domain.PermissionFeedbackView,
+	"PUT /creator/segments/{segmentId}":                             domain.PermissionFeedbackTriage,
+	"DELETE /creator/segments/{segmentId}":                          domain.PermissionFeedbackTriage,
+	"GET /creator/segments/{segmentId}/feedback":                    domain.PermissionFeedbackView,
+	"GET /creator/priorities":                                       domain.PermissionFeedbackView,
+	"GET /creator/prioritization":                                   domain.PermissionFeedbackView,
+	"PATCH /creator/prioritization":                                 domain.PermissionSettingsEdit,
+	"GET /creator/tags":                                             domain.PermissionFeedbackView,
+	"POST /creator/tags":                                            domain.PermissionFeedbackTriage,
+	"PATCH /creator/tags/{tagId}":                                   domain.PermissionFeedbackTriage,
+	"DELETE /creator/tags/{tagId}":                                  domain.PermissionFeedbackTriage,
+	"GET /creator/members":                                          domain.PermissionFeedbackView,
+	"POST /creator/members":                                         domain.PermissionMembersManage,
+	"PATCH /creator/members/{memberId}":                             domain.PermissionMembersManage,
+	"DELETE /creator/members/{memberId}":                            domain.PermissionMembersManage,
+	"POST /creator/members/invite":                                  domain.PermissionMembersManage,
+	"PUT /creator/members/{memberId}/role":                          domain.PermissionMembersManage,
+	"GET /creator/permissions":                                      domain.PermissionFeedbackView,
+	"GET /creator/roles":                                            domain.PermissionFeedbackView,
+	"POST /creator/roles":                                           domain.PermissionMembersManage,
+	"PATCH /creator/roles/{roleId}":                                 domain.PermissionMembersManage,
+	"DELETE /creator/roles/{roleId}":                                domain.PermissionMembersManage,
+	"GET /creator/settings":                                         domain.PermissionFeedbackView,
+	"PATCH /creator/settings":                                       domain.PermissionSettingsEdit,
+	"GET /creator/branding":                                         domain.PermissionFeedbackView,
+	"PATCH /creator/branding":                                       domain.PermissionSettingsEdit,
+	"POST /creator/branding/logo/init":                              domain.PermissionSettingsEdit,
+	"PUT /creator/branding/logo":                                    domain.PermissionSettingsEdit,
+	"DELETE /creator/branding/logo":                                 domain.PermissionSettingsEdit,
+	"GET /creator/domain":                                           domain.PermissionFeedbackView,
+	"PUT /creator/domain":                                           domain.PermissionSettingsEdit,
+	"POST /creator/domain/verify":                                   domain.PermissionSettingsEdit,
+	"DELETE /creator/domain":                                        domain.PermissionSettingsEdit,
+	"GET /creator/sdk-tokens":                                       domain.PermissionTokensManage,
+	"POST /creator/sdk-tokens":                                      domain.PermissionTokensManage,
+	"DELETE /creator/sdk-tokens/{tokenId}":                          domain.PermissionTokensManage,
+	"GET /creator/service-accounts":                                 domain.PermissionTokensManage,
+	"POST /creator/service-accounts":                                domain.PermissionTokensManage,
+	"DELETE /creator/service-accounts/{accountId}":                  domain.PermissionTokensManage,
+	"GET /creator/service-accounts/{accountId}/tokens":              domain.PermissionTokensManage,
+	"POST /creator/service-accounts/{accountId}/tokens":             domain.PermissionTokensManage,
+	"DELETE /creator/service-accounts/{accountId}/tokens/{tokenId}": domain.PermissionTokensManage,
+	"GET /creator/analytics/volume":                                 domain.PermissionFeedbackView,
+	"GET /creator/analytics/resolution":                             domain.PermissionFeedbackView,
+	"GET /creator/analytics/funnel":                                 domain.PermissionFeedbackView,
+	"GET /creator/analytics/top-requests":                           domain.PermissionFeedbackView,
+	"GET /creator/analytics/vote-velocity":                          domain.PermissionFeedbackView,
+	"GET /creator/analytics/active-users":                           domain.PermissionFeedbackView,
+	"GET /creator/users":                                            domain.PermissionFeedbackView,
+	"GET /creator/users/{userId}/feedback":                          domain.PermissionFeedbackView,
+	"POST /creator/archive":                                         domain.PermissionProjectManage,
+	"POST /creator/restore":                                         domain.PermissionProjectManage,
+	"DELETE /creator/":                                              domain.PermissionProjectManage,
+
+	// Organization routes; permissions come from the organization role
+	"GET /creator/organizations/":                    "",
+	"PATCH /creator/organizations/":                  domain.PermissionSettingsEdit,
+	"GET /creator/organizations/projects":            "",
+	"GET /creator/organizations/search":              "",
+	"GET /creator/organizations/members":             "",
+	"POST /creator/organizations/members":            domain.PermissionMembersManage,
+	"PATCH /creator/organizations/members/{userId}":  domain.PermissionMembersManage,
+	"DELETE /creator/organizations/members/{userId}": "",
+}
+
+const testUserHeader = "X-Test-User"
+
+// fakeMemberships maps user IDs to their role in every project
+type fakeMemberships map[string]domain.Role
+
+func (f fakeMemberships) GetByProjectAndUser(_ context.Context, projectID, userID string) (*domain.Membership, error) {
+	role, ok := f[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.Membership{ProjectID: uuid.MustParse(projectID), UserID: uuid.MustParse(userID), Role: role}, nil
+}
+
+// fakeOrgMembers maps user IDs to their role in every organization
+type fakeOrgMembers map[string]domain.Role
+
+func (f fakeOrgMembers) GetMember(_ context.Context, organizationID, userID string) (*domain.OrganizationMember, error) {
+	role, ok := f[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.OrganizationMember{OrganizationID: uuid.MustParse(organizationID), UserID: uuid.MustParse(userID), Role: role}, nil
+}
+
+// fakeMembership gives every user the same membership in every project
+type fakeMembership struct{ membership *domain.Membership }
+
+func (f fakeMembership) GetByProjectAndUser(_ context.Context, projectID, userID string) (*domain.Membership, error) {
+	m := *f.membership
+	m.ProjectID, m.UserID = uuid.MustParse(projectID), uuid.MustParse(userID)
+	return &m, nil
+}
+
+// permits reports whether the membership may call a route needing p
+func permits(m *domain.Membership, p domain.Permission) bool {
+	return p == "" || m.HasPermission(p)
+}
+
+// fakeProjects maps project IDs to whether they are archived
+type fakeProjects map[string]bool
+
+func (f fakeProjects) IsArchived(_ context.Context, projectID string) (bool, error) {
+	return f[projectID], nil
+}
+
+// testAuthenticate stands in for Supabase JWT validation
+func testAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.Header.Get(testUserHeader))
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.ContextWithUserID(r.Context(), id)))
+	})
+}
+
+// stubbedRoutes returns the real route tables with every handler replaced
+// by one that reports success
+func stubbedRoutes() memberRoutes {
+	routes := newMemberRoutes(nil, routeHandlers{})
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	for _, table := range [][]protectedRoute{routes.community, routes.creator, routes.creatorProject, routes.projectLifecycle, routes.organization} {
+		for i := range table {
+			table[i].handler = ok
+		}
+	}
+	return routes
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+func projectPath(group string, projectID uuid.UUID, pattern string) string {
+	path := "/" + group + "/projects/" + projectID.String() + pattern
+	path = strings.TrimSuffix(path, "/")
+	return pathParam.ReplaceAllStringFunc(path, func(string) string { return uuid.NewString() })
+}
+
+func organizationPath(orgID uuid.UUID, pattern string) string {
+	path := strings.TrimSuffix("/creator/organizations/"+orgID.String()+pattern, "/")
+	return pathParam.ReplaceAllStringFunc(path, func(string) string { return uuid.NewString() })
+}
+
+func TestMemberRoutes_PolicyCoversEveryRoute(t *testing.T) {
+	routes := newMemberRoutes(nil, routeHandlers{})
+
+	seen := make(map[string]bool)
+	check := func(group string, table []protectedRoute) {
+		for _, route := range table {
+			key := route.method + " /" + group + route.pattern
+			seen[key] = true
+
+			want, ok := expectedPolicy[key]
+			if assert.True(t, ok, "no expected policy for %s", key) {
+				assert.Equal(t, want, route.permission, key)
+			}
+		}
+	}
+	check("community", routes.community)
+	check("creator", routes.creatorProject)
+	check("creator", routes.projectLifecycle)
+	check("creator/organizations", routes.organization)
+
+	// Project routes for the team must never be open to community members
+	for _, route := range append(routes.creatorProject, routes.projectLifecycle...) {
+		assert.NotEmpty(t, route.permission, "%s %s has no permission", route.method, route.pattern)
+	}
+
+	for key := range expectedPolicy {
+		assert.True(t, seen[key], "expected policy for unregistered route %s", key)
+	}
+}
+
+func TestMemberRoutes_AuthorizationMatrix(t *testing.T) {
+	memberships := fakeMemberships{}
+	callers := map[string]string{} // caller name -> user ID ("" = anonymous)
+	callers["anonymous"] = ""
+	callers["non-member"] = uuid.NewString()
+	for _, role := range domain.AllRoles() {
+		id := uuid.NewString()
+		memberships[id] = role
+		callers[string(role)] = id
+	}
+
+	r := chi.NewRouter()
+	routes := stubbedRoutes()
+	mountMemberRoutes(r, testAuthenticate, memberships, fakeProjects{}, fakeOrgMembers{}, routes)
+
+	projectID := uuid.New()
+	groups := []struct {
+		name  string
+		table []protectedRoute
+	}{
+		{"community", routes.community},
+		{"creator", routes.creatorProject},
+		{"creator", routes.projectLifecycle},
+	}
+
+	for _, group := range groups {
+		for _, route := range group.table {
+			path := projectPath(group.name, projectID, route.pattern)
+			for caller, userID := range callers {
+				want := http.StatusOK
+				switch {
+				case userID == "":
+					want = http.StatusUnauthorized
+				case caller == "non-member":
+					want = http.StatusForbidden
+				case !permits(&domain.Membership{Role: domain.Role(caller)}, route.permission):
+					want = http.StatusForbidden
+				}
+
+				req := httptest.NewRequest(route.method, path, nil)
+				if userID != "" {
+					req.Header.Set(testUserHeader, userID)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				assert.Equal(t, want, rr.Code, "%s %s as %s", route.method, path, caller)
+			}
+		}
+	}
+}
+
+func TestMemberRoutes_CreatorAccountRoutes(t *testing.T) {
+	r := chi.NewRouter()
+	routes := stubbedRoutes()
+	mountMemberRoutes(r, testAuthenticate, fakeMemberships{}, fakeProjects{}, fakeOrgMembers{}, routes)
+
+	require.NotEmpty(t, routes.creator)
+	for _, route := range routes.creator {
+		// Signed-in users without any membership may list and create projects
+		req := httptest.NewRequest(route.method, "/creator"+route.pattern, nil)
+		req.Header.Set(testUserHeader, uuid.NewString())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code, "%s %s", route.method, route.pattern)
+
+		req = httptest.NewRequest(route.method, "/creator"+route.pattern, nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, "%s %s anonymous", route.method, route.pattern)
+	}
+}
+
+func TestMemberRoutes_ServiceAccounts(t *testing.T) {
+	projectID := uuid.New()
+	account := &domain.ServiceAccount{ID: uuid.New(), ProjectID: projectID, Name: "CI", Role: domain.RoleMember}
+
+	// Service accounts carry their membership instead of a memberships row
+	authenticate := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := auth.ContextWithUserID(r.Context(), account.ID)
+			ctx = auth.ContextWithMembership(ctx, account.Membership())
+			ctx = auth.ContextWithAuthMethod(ctx, auth.AuthMethodServiceAccount)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	r := chi.NewRouter()
+	routes := stubbedRoutes()
+	mountMemberRoutes(r, authenticate, fakeMemberships{}, fakeProjects{}, fakeOrgMembers{}, routes)
+
+	for _, route := range append(routes.creatorProject, routes.projectLifecycle...) {
+		want := http.StatusOK
+		if !permits(account.Membership(), route.permission) {
+			want = http.StatusForbidden
+		}
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(route.method, projectPath("creator", projectID, route.pattern), nil))
+		assert.Equal(t, want, rr.Code, "%s %s in own project", route.method, route.pattern)
+
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(route.method, projectPath("creator", uuid.New(), route.pattern), nil))
+		assert.Equal(t, http.StatusForbidden, rr.Code, "%s %s in another project", route.method, route.pattern)
+	}
+
+	for _, route := range routes.creator {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(route.method, "/creator"+route.pattern, nil))
+		assert.Equal(t, http.StatusForbidden, rr.Code, "%s %s", route.method, route.pattern)
+	}
+
+	for _, route := range routes.organization {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(route.method, organizationPath(uuid.New(), route.pattern), nil))
+		assert.Equal(t, http.StatusForbidden, rr.Code, "%s %s", route.method, route.pattern)
+	}
+}
+
+func TestMemberRoutes_OrganizationAuthorization(t *testing.T) {
+	orgMembers := fakeOrgMembers{}
+	callers := map[string]string{"anonymous": "", "non-member": uuid.NewString()}
+	for _, role := range domain.AllRoles() {
+		if !role.IsTeamRole() {
+			continue
+		}
+		id := uuid.NewString()
+		orgMembers[id] = role
+		callers[string(role)] = id
+	}
+
+	r := chi.NewRouter()
+	routes := stubbedRoutes()
+	mountMemberRoutes(r, testAuthenticate, fakeMemberships{}, fakeProjects{}, orgMembers, routes)
+
+	require.NotEmpty(t, routes.organization)
+	orgID := uuid.New()
+	for _, route := range routes.organization {
+		path := organizationPath(orgID, route.pattern)
+		for caller, userID := range callers {
+			want := http.StatusOK
+			switch {
+			case userID == "":
+				want = http.StatusUnauthorized
+			case caller == "non-member":
+				want = http.StatusForbidden
+			case !permits(&domain.Membership{Role: domain.Role(caller)}, route.permission):
+				want = http.StatusForbidden
+			}
+
+			req := httptest.NewRequest(route.method, path, nil)
+			if userID != "" {
+				req.Header.Set(testUserHeader, userID)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, want, rr.Code, "%s %s as %s", route.method, path, caller)
+		}
+	}
+}
+
+func TestMemberRoutes_ArchivedProjectIsReadOnly(t *testing.T) {
+	owner := uuid.NewString()
+	projectID := uuid.New()
+
+	r := chi.NewRouter()
+	routes := stubbedRoutes()
+	mountMemberRoutes(r, testAuthenticate, fakeMemberships{owner: domain.RoleOwner}, fakeProjects{projectID.String(): true}, fakeOrgMembers{}, routes)
+
+	check := func(group string, table []protectedRoute, writable bool) {
+		for _, route := range table {
+			want := http.StatusOK
+			if !writable && route.method != http.MethodGet {
+				want = http.StatusConflict
+			}
+
+			req := httptest.NewRequest(route.method, projectPath(group, projectID, route.pattern), nil)
+			req.Header.Set(testUserHeader, owner)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, want, rr.Code, "%s /%s%s", route.method, group, route.pattern)
+		}
+	}
+	check("community", routes.community, false)
+	check("creator", routes.creatorProject, false)
+	check("creator", routes.projectLifecycle, true)
+}
+
+func TestMemberRoutes_CustomRole(t *testing.T) {
+	// A triager who may also moderate, built on a viewer membership
+	triager := &domain.Membership{
+		Role: domain.RoleViewer,
+		CustomRole: &domain.ProjectRole{
+			Name:        "Triager",
+			Permissions: []domain.Permission{domain.PermissionFeedbackView, domain.PermissionFeedbackTriage, domain.PermissionCommentsModerate},
+		},
+	}
+
+	r := chi.NewRouter()
+	routes := stubbedRoutes()
+	mountMemberRoutes(r, testAuthenticate, fakeMembership{triager}, fakeProjects{}, fakeOrgMembers{}, routes)
+
+	projectID := uuid.New()
+	for _, route := range append(routes.creatorProject, routes.projectLifecycle...) {
+		want := http.StatusForbidden
+		if triager.CustomRole.HasPermission(route.permission) {
+			want = http.StatusOK
+		}
+
+		req := httptest.NewRequest(route.method, projectPath("creator", projectID, route.pattern), nil)
+		req.Header.Set(testUserHeader, uuid.NewString())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, want, rr.Code, "%s %s", route.method, route.pattern)
+	}
+
+	// The custom role replaces the viewer defaults rather than adding to them
+	assert.False(t, triager.HasPermission(domain.PermissionExport))
+	assert.True(t, triager.HasPermission(domain.PermissionFeedbackTriage))
+}
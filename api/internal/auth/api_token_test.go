@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// fakeTokenStore serves tokens keyed by the hash of their plaintext
+type fakeTokenStore map[string]*domain.APIToken
+
+func (f fakeTokenStore) GetByHash(_ context.Context, tokenHash string) (*domain.APIToken, error) {
+	if t, ok := f[tokenHash]; ok {
+		return t, nil
+	}
+	return nil, domain.ErrAPITokenNotFound
+}
+
+func (f fakeTokenStore) TouchLastUsed(context.Context, uuid.UUID) error { return nil }
+
+// stubProvider accepts any non-API token as a fixed user
+type stubProvider struct{ userID uuid.UUID }
+
+func (p stubProvider) Authenticate(context.Context, string) (*Identity, error) {
+	return &Identity{UserID: p.userID, Method: AuthMethodSupabase}, nil
+}
+
+func (stubProvider) Run(context.Context) {}
+
+func TestAPITokenProvider_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	jwtUser := uuid.New()
+	store := fakeTokenStore{}
+	p := NewAPITokenProvider(stubProvider{userID: jwtUser}, store)
+
+	addToken := func(prefix string, token *domain.APIToken) string {
+		plaintext, hash, err := GenerateAPIToken(prefix)
+		require.NoError(t, err)
+		token.ID = uuid.New()
+		store[hash] = token
+		return plaintext
+	}
+
+	t.Run("delegates JWTs to the wrapped provider", func(t *testing.T) {
+		identity, err := p.Authenticate(ctx, "eyJhbGciOi.payload.signature")
+		require.NoError(t, err)
+		assert.Equal(t, jwtUser, identity.UserID)
+		assert.Equal(t, AuthMethodSupabase, identity.Method)
+	})
+
+	t.Run("personal access tokens act as their owner", func(t *testing.T) {
+		owner := uuid.New()
+		token := addToken(PersonalTokenPrefix, &domain.APIToken{UserID: &owner})
+
+		identity, err := p.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, owner, identity.UserID)
+		assert.Equal(t, AuthMethodPersonalToken, identity.Method)
+		assert.Nil(t, identity.Membership)
+	})
+
+	t.Run("service account tokens carry a project membership", func(t *testing.T) {
+		account := &domain.ServiceAccount{ID: uuid.New(), ProjectID: uuid.New(), Name: "CI", Role: domain.RoleMember}
+		token := addToken(ServiceAccountTokenPrefix, &domain.APIToken{ServiceAccountID: &account.ID, ServiceAccount: account})
+
+		identity, err := p.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, account.ID, identity.UserID)
+		assert.Equal(t, AuthMethodServiceAccount, identity.Method)
+		require.NotNil(t, identity.Membership)
+		assert.Equal(t, account.ProjectID, identity.Membership.ProjectID)
+		assert.Equal(t, domain.RoleMember, identity.Membership.Role)
+	})
+
+	t.Run("rejects expired tokens", func(t *testing.T) {
+		owner := uuid.New()
+		expired := time.Now().Add(-time.Minute)
+		token := addToken(PersonalTokenPrefix, &domain.APIToken{UserID: &owner, ExpiresAt: &expired})
+
+		_, err := p.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("rejects unknown or revoked tokens", func(t *testing.T) {
+		_, err := p.Authenticate(ctx, PersonalTokenPrefix+"0000")
+		assert.True(t, errors.Is(err, ErrInvalidToken))
+	})
+}
@@ -11,11 +11,13 @@ import (
 type contextKey string
 
 const (
-	userIDKey      contextKey = "user_id"
-	userEmailKey   contextKey = "user_email"
-	membershipKey  contextKey = "membership"
-	sdkProjectKey  contextKey = "sdk_project_id"
-	authMethodKey  contextKey = "auth_method"
+	userIDKey     contextKey = "user_id"
+	userEmailKey  contextKey = "user_email"
+	userNameKey   contextKey = "user_name"
+	membershipKey contextKey = "membership"
+	orgMemberKey  contextKey = "organization_member"
+	sdkProjectKey contextKey = "sdk_project_id"
+	authMethodKey contextKey = "auth_method"
 )
 
 // AuthMethod indicates how the request was authenticated
@@ -23,8 +25,13 @@ type AuthMethod string
 
 const (
 	AuthMethodSupabase AuthMethod = "supabase"
-	AuthMethodSDK      AuthMethod = "sdk"
-	AuthMethodNone     AuthMethod = "none"
+	AuthMethodOIDC     AuthMethod = "oidc"
+	// Personal access tokens act as the user who created them
+	AuthMethodPersonalToken AuthMethod = "personal_token"
+	// Service account tokens act as a project-scoped robot identity
+	AuthMethodServiceAccount AuthMethod = "service_account"
+	AuthMethodSDK            AuthMethod = "sdk"
+	AuthMethodNone           AuthMethod = "none"
 )
 
 // ContextWithUserID adds the user ID to the context
@@ -67,6 +74,17 @@ func MustUserEmailFromContext(ctx context.Context) string {
 	return email
 }
 
+// ContextWithUserName adds the user's display name to the context
+func ContextWithUserName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, userNameKey, name)
+}
+
+// UserNameFromContext retrieves the user's display name from context
+func UserNameFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(userNameKey).(string)
+	return name, ok
+}
+
 // ContextWithMembership adds the membership to the context
 func ContextWithMembership(ctx context.Context, membership *domain.Membership) context.Context {
 	return context.WithValue(ctx, membershipKey, membership)
@@ -87,6 +105,26 @@ func MustMembershipFromContext(ctx context.Context) *domain.Membership {
 	return membership
 }
 
+// ContextWithOrganizationMember adds the organization membership to the context
+func ContextWithOrganizationMember(ctx context.Context, member *domain.OrganizationMember) context.Context {
+	return context.WithValue(ctx, orgMemberKey, member)
+}
+
+// OrganizationMemberFromContext retrieves the organization membership from context
+func OrganizationMemberFromContext(ctx context.Context) (*domain.OrganizationMember, bool) {
+	member, ok := ctx.Value(orgMemberKey).(*domain.OrganizationMember)
+	return member, ok
+}
+
+// MustOrganizationMemberFromContext retrieves the organization membership from context or panics
+func MustOrganizationMemberFromContext(ctx context.Context) *domain.OrganizationMember {
+	member, ok := OrganizationMemberFromContext(ctx)
+	if !ok {
+		panic("organization member not found in context")
+	}
+	return member
+}
+
 // ContextWithSDKProject adds the SDK project ID to the context
 func ContextWithSDKProject(ctx context.Context, projectID uuid.UUID) context.Context {
 	return context.WithValue(ctx, sdkProjectKey, projectID)
@@ -112,6 +150,13 @@ func AuthMethodFromContext(ctx context.Context) AuthMethod {
 	return method
 }
 
+// IsSessionAuth reports whether the request carries an interactive user
+// session (a JWT) rather than an API token
+func IsSessionAuth(ctx context.Context) bool {
+	method := AuthMethodFromContext(ctx)
+	return method == AuthMethodSupabase || method == AuthMethodOIDC
+}
+
 // IsAuthenticated checks if the request has valid authentication
 func IsAuthenticated(ctx context.Context) bool {
 	return AuthMethodFromContext(ctx) != AuthMethodNone
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// Identity is the provider-independent view of an authenticated user.
+// UserID is what memberships, portal profiles and votes key on.
+type Identity struct {
+	UserID  uuid.UUID
+	Subject string
+	Email   string
+	Name    string
+	Method  AuthMethod
+
+	// Membership is set for service accounts, which act with a fixed role
+	// in a single project instead of a memberships row
+	Membership *domain.Membership
+}
+
+// IdentityProvider turns a bearer token into an Identity
+type IdentityProvider interface {
+	// Authenticate validates the token and returns the caller's identity
+	Authenticate(ctx context.Context, token string) (*Identity, error)
+	// Run keeps signing keys fresh until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+var (
+	_ IdentityProvider = (*SupabaseValidator)(nil)
+	_ IdentityProvider = (*OIDCProvider)(nil)
+)
+
+// ClaimMapping names the token claims holding the subject, email and
+// display name. Dotted names reach into nested objects
+// (e.g. "user_metadata.full_name").
+type ClaimMapping struct {
+	Subject string
+	Email   string
+	Name    string
+}
+
+// DefaultClaimMapping returns the standard OIDC claim names
+func DefaultClaimMapping() ClaimMapping {
+	return ClaimMapping{Subject: "sub", Email: "email", Name: "name"}
+}
+
+// OIDCConfig configures a generic OpenID Connect provider
+type OIDCConfig struct {
+	// Issuer is the provider's issuer URL; discovery is read from
+	// {Issuer}/.well-known/openid-configuration
+	Issuer string
+	// Audience is the required aud claim (the API identifier or client ID)
+	Audience string
+	// Claims maps provider claims onto the identity; empty fields use the defaults
+	Claims ClaimMapping
+	// KeyTTL controls how often keys are refreshed from the provider's JWKS
+	KeyTTL time.Duration
+}
+
+// OIDCProvider validates tokens issued by any OpenID Connect provider
+// (Auth0, Keycloak, Dex, ...) using its discovery document and JWKS
+type OIDCProvider struct {
+	issuer   string
+	audience string
+	claims   ClaimMapping
+	keys     *keySet
+}
+
+// oidcDiscovery is the subset of the discovery document we rely on
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// NewOIDCProvider runs discovery against the issuer and returns a provider
+// that validates its tokens
+func NewOIDCProvider(ctx context.Context, cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("OIDC issuer is required")
+	}
+	if cfg.Audience == "" {
+		return nil, errors.New("OIDC audience is required")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	discoveryURL := strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+
+	var discovery oidcDiscovery
+	if err := fetchJSON(ctx, client, discoveryURL, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	// The discovery document must describe the configured issuer, otherwise
+	// tokens from a different tenant could validate
+	if discovery.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("OIDC discovery issuer %q does not match configured issuer %q", discovery.Issuer, cfg.Issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document has no jwks_uri")
+	}
+
+	claims := DefaultClaimMapping()
+	if cfg.Claims.Subject != "" {
+		claims.Subject = cfg.Claims.Subject
+	}
+	if cfg.Claims.Email != "" {
+		claims.Email = cfg.Claims.Email
+	}
+	if cfg.Claims.Name != "" {
+		claims.Name = cfg.Claims.Name
+	}
+
+	return &OIDCProvider{
+		issuer:   discovery.Issuer,
+		audience: cfg.Audience,
+		claims:   claims,
+		keys:     newRemoteKeySet(discovery.JWKSURI, cfg.KeyTTL, client),
+	}, nil
+}
+
+// Run keeps the JWKS fresh until ctx is cancelled
+func (p *OIDCProvider) Run(ctx context.Context) {
+	p.keys.Run(ctx)
+}
+
+// Authenticate implements IdentityProvider
+func (p *OIDCProvider) Authenticate(ctx context.Context, tokenString string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return p.keys.verificationKey(ctx, token)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	if !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	subject := claimString(claims, p.claims.Subject)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidToken, p.claims.Subject)
+	}
+
+	identity := &Identity{
+		UserID:  p.userID(subject),
+		Subject: subject,
+		Name:    claimString(claims, p.claims.Name),
+		Method:  AuthMethodOIDC,
+	}
+	// Emails drive SDK user linking, so only trust ones the provider
+	// hasn't flagged as unverified
+	if verified, ok := claims["email_verified"].(bool); !ok || verified {
+		identity.Email = claimString(claims, p.claims.Email)
+	}
+
+	return identity, nil
+}
+
+// userID maps a provider subject onto a stable user UUID. Subjects that are
+// already UUIDs (Keycloak, Supabase) are used as-is; others (Auth0's
+// "auth0|123", Dex) get a name-based UUID scoped to the issuer.
+func (p *OIDCProvider) userID(subject string) uuid.UUID {
+	if id, err := uuid.Parse(subject); err == nil {
+		return id
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(p.issuer+"#"+subject))
+}
+
+// claimString resolves a dotted claim path to a string value
+func claimString(claims jwt.MapClaims, path string) string {
+	if path == "" {
+		return ""
+	}
+
+	var value interface{} = map[string]interface{}(claims)
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = obj[part]
+	}
+
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestOIDCServer serves a discovery document and JWKS for key
+func newTestOIDCServer(t *testing.T, key *ecdsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   srv.URL,
+			"jwks_uri": srv.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(JWKS{Keys: []JWK{ecJWK("k1", &key.PublicKey)}})
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func oidcClaims(issuer string, extra jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss": issuer,
+		"aud": "https://api.example.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestOIDCProvider_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	srv := newTestOIDCServer(t, key)
+
+	p, err := NewOIDCProvider(ctx, OIDCConfig{Issuer: srv.URL, Audience: "https://api.example.com"})
+	require.NoError(t, err)
+
+	t.Run("maps non-UUID subjects to a stable user ID", func(t *testing.T) {
+		token := mintToken(t, jwt.SigningMethodES256, "k1", key, oidcClaims(srv.URL, jwt.MapClaims{
+			"sub":   "auth0|12345",
+			"email": "dev@example.com",
+			"name":  "Dev",
+		}))
+
+		identity, err := p.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, uuid.NewSHA1(uuid.NameSpaceURL, []byte(srv.URL+"#auth0|12345")), identity.UserID)
+		assert.Equal(t, "auth0|12345", identity.Subject)
+		assert.Equal(t, "dev@example.com", identity.Email)
+		assert.Equal(t, "Dev", identity.Name)
+		assert.Equal(t, AuthMethodOIDC, identity.Method)
+
+		again, err := p.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, identity.UserID, again.UserID)
+	})
+
+	t.Run("keeps UUID subjects", func(t *testing.T) {
+		userID := uuid.New()
+		token := mintToken(t, jwt.SigningMethodES256, "k1", key, oidcClaims(srv.URL, jwt.MapClaims{"sub": userID.String()}))
+
+		identity, err := p.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, identity.UserID)
+	})
+
+	t.Run("drops unverified emails", func(t *testing.T) {
+		token := mintToken(t, jwt.SigningMethodES256, "k1", key, oidcClaims(srv.URL, jwt.MapClaims{
+			"sub":            "user-1",
+			"email":          "someone-else@example.com",
+			"email_verified": false,
+		}))
+
+		identity, err := p.Authenticate(ctx, token)
+		require.NoError(t, err)
+		assert.Empty(t, identity.Email)
+	})
+
+	t.Run("rejects tokens for another audience", func(t *testing.T) {
+		claims := oidcClaims(srv.URL, jwt.MapClaims{"sub": "user-1"})
+		claims["aud"] = "another-api"
+		_, err := p.Authenticate(ctx, mintToken(t, jwt.SigningMethodES256, "k1", key, claims))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("rejects tokens without exp", func(t *testing.T) {
+		claims := oidcClaims(srv.URL, jwt.MapClaims{"sub": "user-1"})
+		delete(claims, "exp")
+		_, err := p.Authenticate(ctx, mintToken(t, jwt.SigningMethodES256, "k1", key, claims))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("rejects HS256 tokens", func(t *testing.T) {
+		token := mintToken(t, jwt.SigningMethodHS256, "k1", []byte("secret"), oidcClaims(srv.URL, jwt.MapClaims{"sub": "user-1"}))
+		_, err := p.Authenticate(ctx, token)
+		assert.Error(t, err)
+	})
+}
+
+func TestOIDCProvider_ClaimMapping(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	srv := newTestOIDCServer(t, key)
+
+	p, err := NewOIDCProvider(ctx, OIDCConfig{
+		Issuer:   srv.URL,
+		Audience: "https://api.example.com",
+		Claims:   ClaimMapping{Subject: "oid", Email: "upn", Name: "profile.display_name"},
+	})
+	require.NoError(t, err)
+
+	token := mintToken(t, jwt.SigningMethodES256, "k1", key, oidcClaims(srv.URL, jwt.MapClaims{
+		"sub":     "ignored",
+		"oid":     "user-42",
+		"upn":     "user42@example.com",
+		"profile": map[string]interface{}{"display_name": "User 42"},
+	}))
+
+	identity, err := p.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", identity.Subject)
+	assert.Equal(t, "user42@example.com", identity.Email)
+	assert.Equal(t, "User 42", identity.Name)
+}
+
+func TestNewOIDCProvider_IssuerMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   "https://evil.example.com",
+			"jwks_uri": "https://evil.example.com/keys",
+		})
+	}))
+	defer srv.Close()
+
+	_, err := NewOIDCProvider(context.Background(), OIDCConfig{Issuer: srv.URL, Audience: "api"})
+	assert.ErrorContains(t, err, "does not match")
+}
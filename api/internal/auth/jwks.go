@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultKeyTTL is how long fetched signing keys are trusted before a refresh
+	defaultKeyTTL = 10 * time.Minute
+	// minKeyRefreshInterval limits on-demand refreshes triggered by unknown kids
+	minKeyRefreshInterval = 30 * time.Second
+)
+
+// JWKS represents a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// keySet caches the signing keys published at a JWKS URL, or a fixed set
+// loaded from disk
+type keySet struct {
+	url       string
+	static    bool
+	ttl       time.Duration
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	keysMutex sync.RWMutex
+	fetchMu   sync.Mutex
+	client    *http.Client
+}
+
+// newRemoteKeySet creates a key set fetched lazily from url
+func newRemoteKeySet(url string, ttl time.Duration, client *http.Client) *keySet {
+	if ttl <= 0 {
+		ttl = defaultKeyTTL
+	}
+	return &keySet{
+		url:    url,
+		ttl:    ttl,
+		keys:   make(map[string]crypto.PublicKey),
+		client: client,
+	}
+}
+
+// loadStaticKeySet reads a JWKS file; the keys are never refreshed
+func loadStaticKeySet(path string) (*keySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var jwks JWKS
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file: %w", err)
+	}
+	return &keySet{static: true, keys: parseJWKS(jwks)}, nil
+}
+
+// Run refreshes the keys in the background until ctx is cancelled so key
+// rotations are picked up before tokens signed with the new key arrive
+func (k *keySet) Run(ctx context.Context) {
+	if k.static {
+		return
+	}
+
+	ticker := time.NewTicker(k.ttl)
+	defer ticker.Stop()
+
+	for {
+		if err := k.refresh(ctx, 0); err != nil && ctx.Err() == nil {
+			log.Warn().Err(err).Str("jwks_url", k.url).Msg("Failed to refresh JWKS")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// verificationKey returns the public key named by the token's kid, checking
+// that its type matches the token's signing method
+func (k *keySet) verificationKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, errors.New("missing kid in token header")
+	}
+
+	key, err := k.get(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		if rsaKey, ok := key.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+	case *jwt.SigningMethodECDSA:
+		if ecKey, ok := key.(*ecdsa.PublicKey); ok {
+			return ecKey, nil
+		}
+	}
+	return nil, fmt.Errorf("key %s does not match signing method %v", kid, token.Header["alg"])
+}
+
+// get retrieves the public key for the given kid
+func (k *keySet) get(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	// Check cache first
+	k.keysMutex.RLock()
+	key, ok := k.keys[kid]
+	stale := time.Since(k.fetchedAt) > k.ttl
+	k.keysMutex.RUnlock()
+	if ok && (k.static || !stale) {
+		return key, nil
+	}
+	if k.static {
+		return nil, fmt.Errorf("key with kid %s not found", kid)
+	}
+
+	// Unknown kids usually mean the keys were rotated; refresh, but not more
+	// often than minKeyRefreshInterval so bogus kids can't hammer the issuer
+	if err := k.refresh(ctx, minKeyRefreshInterval); err != nil {
+		if ok {
+			// Keep serving the cached key while the issuer is unreachable
+			return key, nil
+		}
+		return nil, err
+	}
+
+	// Check cache again after fetch
+	k.keysMutex.RLock()
+	key, ok = k.keys[kid]
+	k.keysMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("key with kid %s not found", kid)
+	}
+
+	return key, nil
+}
+
+// refresh fetches the JWKS unless it was fetched within minAge
+func (k *keySet) refresh(ctx context.Context, minAge time.Duration) error {
+	k.fetchMu.Lock()
+	defer k.fetchMu.Unlock()
+
+	k.keysMutex.RLock()
+	fresh := time.Since(k.fetchedAt) < minAge
+	k.keysMutex.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	var jwks JWKS
+	if err := fetchJSON(ctx, k.client, k.url, &jwks); err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	// Replace rather than merge so revoked keys stop validating
+	keys := parseJWKS(jwks)
+	k.keysMutex.Lock()
+	k.keys = keys
+	k.fetchedAt = time.Now()
+	k.keysMutex.Unlock()
+
+	return nil
+}
+
+// fetchJSON GETs url and decodes the JSON response into v
+func fetchJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// parseJWKS extracts the RSA and EC signing keys from a key set, skipping
+// keys it can't use
+func parseJWKS(jwks JWKS) map[string]crypto.PublicKey {
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key crypto.PublicKey
+		var err error
+		switch jwk.Kty {
+		case "RSA":
+			key, err = parseRSAPublicKey(jwk)
+		case "EC":
+			key, err = parseECDSAPublicKey(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+	return keys
+}
+
+// parseRSAPublicKey parses an RSA public key from a JWK
+func parseRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
+	// Decode N (modulus)
+	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).SetBytes(nBytes)
+
+	// Decode E (exponent)
+	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	e := 0
+	for _, b := range eBytes {
+		e = e<<8 + int(b)
+	}
+
+	return &rsa.PublicKey{N: n, E: e}, nil
+}
+
+// parseECDSAPublicKey parses an elliptic curve public key from a JWK
+func parseECDSAPublicKey(jwk JWK) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+
+	xBytes, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	yBytes, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(xBytes),
+		Y:     new(big.Int).SetBytes(yBytes),
+	}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("point is not on curve")
+	}
+
+	return key, nil
+}
@@ -2,8 +2,10 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
 	"github.com/fulldisclosure/api/internal/domain"
@@ -14,8 +16,19 @@ type MembershipLoader interface {
 	GetByProjectAndUser(ctx context.Context, projectID, userID string) (*domain.Membership, error)
 }
 
-// SupabaseAuthMiddleware creates middleware that validates Supabase JWT tokens
-func SupabaseAuthMiddleware(validator *SupabaseValidator) func(http.Handler) http.Handler {
+// OrganizationMemberLoader loads a user's membership in an organization
+type OrganizationMemberLoader interface {
+	GetMember(ctx context.Context, organizationID, userID string) (*domain.OrganizationMember, error)
+}
+
+// ArchivedProjectChecker reports whether a project is archived
+type ArchivedProjectChecker interface {
+	IsArchived(ctx context.Context, projectID string) (bool, error)
+}
+
+// SupabaseAuthMiddleware creates middleware that requires a valid bearer
+// token from the configured identity provider (Supabase or OIDC)
+func SupabaseAuthMiddleware(provider IdentityProvider) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token, err := ExtractTokenFromHeader(r)
@@ -24,31 +37,27 @@ func SupabaseAuthMiddleware(validator *SupabaseValidator) func(http.Handler) htt
 				return
 			}
 
-			userID, claims, err := validator.ValidateToken(r.Context(), token)
+			identity, err := provider.Authenticate(r.Context(), token)
 			if err != nil {
 				log.Warn().Err(err).Msg("JWT validation failed")
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
 
-			// Add user info to context
-			ctx := ContextWithUserID(r.Context(), userID)
-			ctx = ContextWithUserEmail(ctx, claims.Email)
-			ctx = ContextWithAuthMethod(ctx, AuthMethodSupabase)
-
 			// Log successful auth
 			log.Debug().
-				Str("user_id", userID.String()).
-				Str("email", claims.Email).
-				Msg("User authenticated via Supabase JWT")
+				Str("user_id", identity.UserID.String()).
+				Str("email", identity.Email).
+				Str("auth_method", string(identity.Method)).
+				Msg("User authenticated via JWT")
 
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(contextWithIdentity(r.Context(), identity)))
 		})
 	}
 }
 
-// OptionalSupabaseAuthMiddleware validates Supabase JWT if present but doesn't require it
-func OptionalSupabaseAuthMiddleware(validator *SupabaseValidator) func(http.Handler) http.Handler {
+// OptionalSupabaseAuthMiddleware validates the bearer token if present but doesn't require it
+func OptionalSupabaseAuthMiddleware(provider IdentityProvider) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token, err := ExtractTokenFromHeader(r)
@@ -58,22 +67,31 @@ func OptionalSupabaseAuthMiddleware(validator *SupabaseValidator) func(http.Hand
 				return
 			}
 
-			userID, claims, err := validator.ValidateToken(r.Context(), token)
+			identity, err := provider.Authenticate(r.Context(), token)
 			if err != nil {
 				// Invalid token, continue without auth (optional auth)
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Add user info to context
-			ctx := ContextWithUserID(r.Context(), userID)
-			ctx = ContextWithUserEmail(ctx, claims.Email)
-			ctx = ContextWithAuthMethod(ctx, AuthMethodSupabase)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(contextWithIdentity(r.Context(), identity)))
 		})
 	}
 }
 
+// contextWithIdentity adds the authenticated user's info to the context
+func contextWithIdentity(ctx context.Context, identity *Identity) context.Context {
+	ctx = ContextWithUserID(ctx, identity.UserID)
+	ctx = ContextWithUserEmail(ctx, identity.Email)
+	if identity.Name != "" {
+		ctx = ContextWithUserName(ctx, identity.Name)
+	}
+	if identity.Membership != nil {
+		ctx = ContextWithMembership(ctx, identity.Membership)
+	}
+	return ContextWithAuthMethod(ctx, identity.Method)
+}
+
 // SDKAuthMiddleware creates middleware that validates SDK tokens
 func SDKAuthMiddleware(validator *SDKTokenValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -85,7 +103,13 @@ func SDKAuthMiddleware(validator *SDKTokenValidator) func(http.Handler) http.Han
 			}
 
 			origin := r.Header.Get("Origin")
-			projectID, err := validator.ValidateToken(r.Context(), token, origin)
+			bundleID := r.Header.Get(SDKBundleIDHeader)
+			projectID, err := validator.ValidateToken(r.Context(), token, origin, bundleID)
+			if errors.Is(err, ErrOriginNotAllowed) {
+				log.Warn().Str("origin", origin).Str("bundle_id", bundleID).Msg("SDK token used from a disallowed client")
+				http.Error(w, "Forbidden: origin not allowed for this SDK token", http.StatusForbidden)
+				return
+			}
 			if err != nil {
 				log.Warn().Err(err).Msg("SDK token validation failed")
 				http.Error(w, "Unauthorized: invalid SDK token", http.StatusUnauthorized)
@@ -122,6 +146,19 @@ func RequireMembershipMiddleware(loader MembershipLoader, projectIDExtractor fun
 				return
 			}
 
+			// Service accounts carry their membership and are confined to
+			// their own project
+			if AuthMethodFromContext(r.Context()) == AuthMethodServiceAccount {
+				membership, ok := MembershipFromContext(r.Context())
+				id, err := uuid.Parse(projectID)
+				if !ok || err != nil || membership.ProjectID != id {
+					http.Error(w, "Forbidden: not a project member", http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			membership, err := loader.GetByProjectAndUser(r.Context(), projectID, userID.String())
 			if err != nil {
 				log.Warn().
@@ -145,6 +182,108 @@ func RequireMembershipMiddleware(loader MembershipLoader, projectIDExtractor fun
 	}
 }
 
+// RequireOrganizationMembershipMiddleware ensures the user is a member of
+// the organization. The organization ID must be extracted from the URL path.
+func RequireOrganizationMembershipMiddleware(loader OrganizationMemberLoader, organizationIDExtractor func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := UserIDFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			organizationID := organizationIDExtractor(r)
+			if _, err := uuid.Parse(organizationID); err != nil {
+				http.Error(w, "Bad Request: invalid organization ID", http.StatusBadRequest)
+				return
+			}
+
+			member, err := loader.GetMember(r.Context(), organizationID, userID.String())
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("user_id", userID.String()).
+					Str("organization_id", organizationID).
+					Msg("Organization membership lookup failed")
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			if member == nil {
+				http.Error(w, "Forbidden: not an organization member", http.StatusForbidden)
+				return
+			}
+
+			ctx := ContextWithOrganizationMember(r.Context(), member)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireOrganizationPermissionMiddleware ensures the user's organization
+// role grants the permission
+func RequireOrganizationPermissionMiddleware(permission domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			member, ok := OrganizationMemberFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Forbidden: no organization membership", http.StatusForbidden)
+				return
+			}
+
+			if !member.Role.HasPermission(permission) {
+				http.Error(w, "Forbidden: insufficient permissions", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireWritableProjectMiddleware makes archived projects read-only:
+// requests other than GET, HEAD and OPTIONS are rejected with 409 Conflict
+func RequireWritableProjectMiddleware(checker ArchivedProjectChecker, projectIDExtractor func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			archived, err := checker.IsArchived(r.Context(), projectIDExtractor(r))
+			if err != nil {
+				log.Error().Err(err).Msg("Project archive check failed")
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			if archived {
+				http.Error(w, "Conflict: project is archived and read-only", http.StatusConflict)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireUserMiddleware rejects service accounts on routes that act on the
+// caller's own account rather than a single project
+func RequireUserMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if AuthMethodFromContext(r.Context()) == AuthMethodServiceAccount {
+				http.Error(w, "Forbidden: not available to service accounts", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireTeamRoleMiddleware ensures the user has a team role (not community)
 func RequireTeamRoleMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -185,6 +324,27 @@ func RequireRoleMiddleware(minRole domain.Role) func(http.Handler) http.Handler
 	}
 }
 
+// RequirePermissionMiddleware ensures the user's effective permissions in
+// the project, from their built-in or custom role, include the permission
+func RequirePermissionMiddleware(permission domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			membership, ok := MembershipFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Forbidden: no membership", http.StatusForbidden)
+				return
+			}
+
+			if !membership.HasPermission(permission) {
+				http.Error(w, "Forbidden: missing permission "+string(permission), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireAdminMiddleware ensures the user is an admin or owner
 func RequireAdminMiddleware() func(http.Handler) http.Handler {
 	return RequireRoleMiddleware(domain.RoleAdmin)
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// OriginPattern is one entry of an SDK token's origin allow-list.
+//
+// Patterns are "scheme://host[:port]". The scheme may be omitted to allow
+// both http and https. A host of "*.example.com" matches any subdomain of
+// example.com at any depth, but not example.com itself or lookalikes such as
+// evilexample.com. A missing port means the scheme's default port.
+type OriginPattern struct {
+	scheme   string // "" matches http and https
+	host     string // lowercase; without the "*." for wildcards
+	wildcard bool
+	port     string // "" means the scheme's default
+}
+
+// ParseOriginPattern parses an allowed origin entry
+func ParseOriginPattern(s string) (OriginPattern, error) {
+	raw := strings.TrimSpace(s)
+	if raw == "" {
+		return OriginPattern{}, fmt.Errorf("origin is empty")
+	}
+	if raw == "*" {
+		return OriginPattern{}, fmt.Errorf(`"*" is not a valid origin; allow any origin explicitly instead`)
+	}
+
+	var p OriginPattern
+	rest := raw
+	if scheme, after, ok := strings.Cut(raw, "://"); ok {
+		p.scheme = strings.ToLower(scheme)
+		if p.scheme != "http" && p.scheme != "https" {
+			return OriginPattern{}, fmt.Errorf("origin %q must use http or https", raw)
+		}
+		rest = after
+	}
+	if strings.ContainsAny(rest, "/?#@") {
+		return OriginPattern{}, fmt.Errorf("origin %q must not contain a path, query or credentials", raw)
+	}
+
+	host := rest
+	if h, port, err := net.SplitHostPort(rest); err == nil {
+		host, p.port = h, port
+	}
+	host = strings.ToLower(strings.Trim(host, "[]"))
+
+	if strings.HasPrefix(host, "*.") {
+		p.wildcard = true
+		host = host[2:]
+	}
+	if host == "" || strings.Contains(host, "*") {
+		return OriginPattern{}, fmt.Errorf("origin %q has an invalid host; wildcards are only allowed as a leading \"*.\"", raw)
+	}
+	if p.wildcard && !strings.Contains(host, ".") {
+		return OriginPattern{}, fmt.Errorf("origin %q is too broad; wildcards need a registrable domain", raw)
+	}
+	p.host = host
+
+	return p, nil
+}
+
+// Matches reports whether a browser Origin header value is allowed by the pattern
+func (p OriginPattern) Matches(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" || u.Path != "" || u.User != nil {
+		return false
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return false
+	}
+	if p.scheme != "" && p.scheme != scheme {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if p.wildcard {
+		if !strings.HasSuffix(host, "."+p.host) {
+			return false
+		}
+	} else if host != p.host {
+		return false
+	}
+
+	return effectivePort(u.Port(), scheme) == effectivePort(p.port, scheme)
+}
+
+// MatchOrigin reports whether origin matches any of the allowed patterns.
+// Unparseable entries never match.
+func MatchOrigin(allowed []string, origin string) bool {
+	for _, entry := range allowed {
+		p, err := ParseOriginPattern(entry)
+		if err != nil {
+			continue
+		}
+		if p.Matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBundleID checks a native app bundle identifier such as
+// com.example.app
+func ValidateBundleID(id string) error {
+	labels := strings.Split(id, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("bundle ID %q must be reverse-DNS, e.g. com.example.app", id)
+	}
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("bundle ID %q has an empty component", id)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return fmt.Errorf("bundle ID %q contains invalid character %q", id, c)
+			}
+		}
+	}
+	return nil
+}
+
+// MatchBundleID reports whether id is in the allowed list. Bundle IDs are
+// compared case-insensitively, as the App Store treats them.
+func MatchBundleID(allowed []string, id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, entry := range allowed {
+		if strings.EqualFold(entry, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func effectivePort(port, scheme string) string {
+	if port != "" {
+		return port
+	}
+	if scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
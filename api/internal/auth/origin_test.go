@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOriginPattern_Rejects(t *testing.T) {
+	for _, entry := range []string{
+		"",
+		"*",
+		"ftp://example.com",
+		"https://example.com/path",
+		"https://user@example.com",
+		"https://*example.com",
+		"https://app.*.example.com",
+		"*.com",
+	} {
+		_, err := ParseOriginPattern(entry)
+		assert.Error(t, err, entry)
+	}
+}
+
+func TestOriginPattern_Matches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		origin  string
+		want    bool
+	}{
+		{"https://example.com", "https://example.com", true},
+		{"https://example.com", "https://EXAMPLE.com", true},
+		{"https://example.com", "http://example.com", false},
+		{"https://example.com", "https://evilexample.com", false},
+		{"https://example.com", "https://example.com.evil.io", false},
+		{"example.com", "http://example.com", true},
+		{"example.com", "https://example.com", true},
+
+		// Ports default per scheme
+		{"https://example.com", "https://example.com:443", true},
+		{"https://example.com", "https://example.com:8443", false},
+		{"http://localhost:3000", "http://localhost:3000", true},
+		{"http://localhost:3000", "http://localhost:3001", false},
+		{"http://localhost", "http://localhost:3000", false},
+
+		// Wildcards match subdomains at any depth, never the apex or lookalikes
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://a.b.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://evilexample.com", false},
+		{"https://*.example.com", "https://app.example.com:8080", false},
+
+		// IPv6 hosts
+		{"http://[::1]:8080", "http://[::1]:8080", true},
+
+		// Malformed Origin headers never match
+		{"https://example.com", "null", false},
+		{"https://example.com", "https://example.com/", false},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseOriginPattern(tt.pattern)
+		require.NoError(t, err, tt.pattern)
+		assert.Equal(t, tt.want, p.Matches(tt.origin), "%s vs %s", tt.pattern, tt.origin)
+	}
+}
+
+func TestValidateBundleID(t *testing.T) {
+	assert.NoError(t, ValidateBundleID("com.example.app"))
+	assert.NoError(t, ValidateBundleID("io.example.My-App_2"))
+	assert.Error(t, ValidateBundleID("app"))
+	assert.Error(t, ValidateBundleID("com..app"))
+	assert.Error(t, ValidateBundleID("com.example.app/"))
+}
+
+func TestSDKToken_CheckClient(t *testing.T) {
+	token := &SDKToken{
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowedBundleIDs: []string{"com.example.app"},
+	}
+
+	assert.NoError(t, token.CheckClient("https://app.example.com", ""))
+	assert.ErrorIs(t, token.CheckClient("https://other.io", ""), ErrOriginNotAllowed)
+	assert.ErrorIs(t, token.CheckClient("", ""), ErrOriginNotAllowed)
+
+	assert.NoError(t, token.CheckClient("", "COM.example.app"))
+	assert.ErrorIs(t, token.CheckClient("", "com.example.other"), ErrOriginNotAllowed)
+	// A bundle ID is checked on its own, even alongside an allowed origin
+	assert.ErrorIs(t, token.CheckClient("https://app.example.com", "com.example.other"), ErrOriginNotAllowed)
+	// and an allowed bundle ID can't vouch for a disallowed origin
+	assert.ErrorIs(t, token.CheckClient("https://other.io", "com.example.app"), ErrOriginNotAllowed)
+	assert.NoError(t, token.CheckClient("https://app.example.com", "com.example.app"))
+
+	anyOrigin := &SDKToken{AllowAnyOrigin: true}
+	assert.NoError(t, anyOrigin.CheckClient("https://anything.io", ""))
+	assert.NoError(t, anyOrigin.CheckClient("", ""))
+	assert.ErrorIs(t, anyOrigin.CheckClient("", "com.example.app"), ErrOriginNotAllowed)
+}
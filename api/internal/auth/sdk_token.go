@@ -2,17 +2,15 @@ package auth
 
 import (
 	"context"
-	"crypto/rand"
 	"crypto/sha256"
-	"database/sql"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,6 +21,10 @@ var (
 	ErrMissingSDKToken  = errors.New("missing SDK token")
 )
 
+// SDKBundleIDHeader carries a native app's bundle identifier. Native apps
+// send no Origin, so tokens issued to them are checked against this instead.
+const SDKBundleIDHeader = "X-SDK-Bundle-ID"
+
 // SDKTokenValidator validates SDK tokens for anonymous SDK submissions
 type SDKTokenValidator struct {
 	db *pgxpool.Pool
@@ -30,15 +32,19 @@ type SDKTokenValidator struct {
 
 // SDKToken represents an SDK token from the database
 type SDKToken struct {
-	ID             uuid.UUID
-	ProjectID      uuid.UUID
-	Name           string
-	TokenHash      string
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	Name      string
+	// AllowedOrigins are OriginPattern entries for browser clients
 	AllowedOrigins []string
-	RateLimit      int
-	LastUsedAt     *time.Time
-	ExpiresAt      *time.Time
-	CreatedAt      time.Time
+	// AllowAnyOrigin skips the origin check entirely
+	AllowAnyOrigin bool
+	// AllowedBundleIDs are the native apps (iOS bundle IDs, Android
+	// application IDs) the token may be used from
+	AllowedBundleIDs []string
+	RateLimit        int
+	LastUsedAt       *time.Time
+	CreatedAt        time.Time
 }
 
 // NewSDKTokenValidator creates a new SDK token validator
@@ -46,49 +52,47 @@ func NewSDKTokenValidator(db *pgxpool.Pool) *SDKTokenValidator {
 	return &SDKTokenValidator{db: db}
 }
 
-// ValidateToken validates an SDK token and returns the associated project ID
-func (v *SDKTokenValidator) ValidateToken(ctx context.Context, token string, origin string) (uuid.UUID, error) {
+// ValidateToken validates an SDK token for a request from the given Origin
+// or native bundle ID and returns the associated project ID
+func (v *SDKTokenValidator) ValidateToken(ctx context.Context, token, origin, bundleID string) (uuid.UUID, error) {
 	// Hash the token for lookup
 	tokenHash := hashToken(token)
 
-	// Look up the token in the database
+	// Look up the token in the database; tokens of archived projects are rejected
 	query := `
-		SELECT id, project_id, name, allowed_origins, rate_limit, last_used_at, expires_at, created_at
-		FROM sdk_tokens
-		WHERE token_hash = $1
+		SELECT t.id, t.project_id, t.name, t.allowed_origins, t.allow_any_origin, t.allowed_bundle_ids,
+		       t.rate_limit_per_minute, t.last_used_at, t.created_at
+		FROM sdk_tokens t
+		JOIN projects p ON p.id = t.project_id AND p.archived_at IS NULL
+		WHERE t.token_hash = $1 AND t.is_active AND t.revoked_at IS NULL
 	`
 
 	var sdkToken SDKToken
-	var allowedOriginsJSON []byte
-	var lastUsedAt, expiresAt sql.NullTime
+	var rateLimit *int
 
 	err := v.db.QueryRow(ctx, query, tokenHash).Scan(
 		&sdkToken.ID,
 		&sdkToken.ProjectID,
 		&sdkToken.Name,
-		&allowedOriginsJSON,
-		&sdkToken.RateLimit,
-		&lastUsedAt,
-		&expiresAt,
+		&sdkToken.AllowedOrigins,
+		&sdkToken.AllowAnyOrigin,
+		&sdkToken.AllowedBundleIDs,
+		&rateLimit,
+		&sdkToken.LastUsedAt,
 		&sdkToken.CreatedAt,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return uuid.Nil, ErrInvalidSDKToken
 		}
 		return uuid.Nil, fmt.Errorf("failed to lookup SDK token: %w", err)
 	}
-
-	// Check expiration
-	if expiresAt.Valid && time.Now().After(expiresAt.Time) {
-		return uuid.Nil, ErrSDKTokenExpired
+	if rateLimit != nil {
+		sdkToken.RateLimit = *rateLimit
 	}
 
-	// Check origin if allowed origins are configured
-	if len(allowedOriginsJSON) > 0 && origin != "" {
-		if !isOriginAllowed(allowedOriginsJSON, origin) {
-			return uuid.Nil, ErrOriginNotAllowed
-		}
+	if err := sdkToken.CheckClient(origin, bundleID); err != nil {
+		return uuid.Nil, err
 	}
 
 	// Update last used timestamp (non-blocking)
@@ -97,6 +101,24 @@ func (v *SDKTokenValidator) ValidateToken(ctx context.Context, token string, ori
 	return sdkToken.ProjectID, nil
 }
 
+// CheckClient checks the caller against the token's allow-lists. Browsers
+// identify themselves by Origin, which must match an allowed origin unless
+// the token allows any origin; native apps by bundle ID. Each one sent must
+// be allowed, so a bundle ID can't vouch for a disallowed Origin. A request
+// with neither is only accepted by tokens that allow any origin.
+func (t *SDKToken) CheckClient(origin, bundleID string) error {
+	if origin != "" && !t.AllowAnyOrigin && !MatchOrigin(t.AllowedOrigins, origin) {
+		return ErrOriginNotAllowed
+	}
+	if bundleID != "" && !MatchBundleID(t.AllowedBundleIDs, bundleID) {
+		return ErrOriginNotAllowed
+	}
+	if origin == "" && bundleID == "" && !t.AllowAnyOrigin {
+		return ErrOriginNotAllowed
+	}
+	return nil
+}
+
 // updateLastUsed updates the last_used_at timestamp for a token
 func (v *SDKTokenValidator) updateLastUsed(ctx context.Context, tokenID uuid.UUID) {
 	query := `UPDATE sdk_tokens SET last_used_at = NOW() WHERE id = $1`
@@ -105,17 +127,7 @@ func (v *SDKTokenValidator) updateLastUsed(ctx context.Context, tokenID uuid.UUI
 
 // GenerateToken generates a new SDK token
 func GenerateToken() (string, string, error) {
-	// Generate 32 random bytes
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", "", err
-	}
-
-	// Encode as hex with prefix
-	token := "sdk_" + hex.EncodeToString(bytes)
-	hash := hashToken(token)
-
-	return token, hash, nil
+	return generateToken("sdk_")
 }
 
 // hashToken creates a SHA-256 hash of a token
@@ -124,54 +136,6 @@ func hashToken(token string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// isOriginAllowed checks if the origin is in the allowed list
-func isOriginAllowed(allowedOriginsJSON []byte, origin string) bool {
-	// Parse JSON array
-	var allowedOrigins []string
-	// Simple JSON parsing - in production use encoding/json
-	str := string(allowedOriginsJSON)
-	str = strings.TrimPrefix(str, "[")
-	str = strings.TrimSuffix(str, "]")
-	if str == "" {
-		return true // Empty list means all origins allowed
-	}
-
-	parts := strings.Split(str, ",")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		part = strings.Trim(part, "\"")
-		allowedOrigins = append(allowedOrigins, part)
-	}
-
-	// Check if origin matches any allowed origin
-	for _, allowed := range allowedOrigins {
-		if allowed == "*" {
-			return true
-		}
-		if matchOrigin(allowed, origin) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// matchOrigin checks if an origin matches a pattern
-// Supports wildcards like "*.example.com"
-func matchOrigin(pattern, origin string) bool {
-	if pattern == origin {
-		return true
-	}
-
-	// Handle wildcard subdomains
-	if strings.HasPrefix(pattern, "*.") {
-		suffix := pattern[1:] // Remove the "*"
-		return strings.HasSuffix(origin, suffix)
-	}
-
-	return false
-}
-
 // ExtractSDKTokenFromHeader extracts the SDK token from the request header
 func ExtractSDKTokenFromHeader(r *http.Request) (string, error) {
 	token := r.Header.Get("X-SDK-Token")
@@ -2,15 +2,10 @@ package auth
 
 import (
 	"context"
-	"crypto/rsa"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"math/big"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -24,14 +19,26 @@ var (
 	ErrInvalidSignature = errors.New("invalid token signature")
 )
 
-// SupabaseValidator validates Supabase JWT tokens using JWKS
+// SupabaseConfig configures how Supabase JWTs are verified
+type SupabaseConfig struct {
+	// URL is the Supabase project URL; it determines the issuer and JWKS endpoint
+	URL string
+	// JWTSecret verifies HS256 tokens (self-hosted and legacy projects)
+	JWTSecret string
+	// JWKSFile, when set, loads signing keys from disk instead of the JWKS endpoint
+	JWKSFile string
+	// KeyTTL controls how often keys are refreshed from the JWKS endpoint
+	KeyTTL time.Duration
+}
+
+// SupabaseValidator validates Supabase JWT tokens signed with the shared
+// secret (HS256) or an asymmetric key published in the project's JWKS
+// (RS256, ES256)
 type SupabaseValidator struct {
-	jwksURL   string
-	issuer    string
-	audience  string
-	keys      map[string]*rsa.PublicKey
-	keysMutex sync.RWMutex
-	client    *http.Client
+	issuer   string
+	audience string
+	secret   []byte
+	keys     *keySet
 }
 
 // SupabaseClaims represents the claims in a Supabase JWT
@@ -53,68 +60,81 @@ type AMREntry struct {
 	Timestamp int64  `json:"timestamp"`
 }
 
-// JWKS represents a JSON Web Key Set
-type JWKS struct {
-	Keys []JWK `json:"keys"`
-}
-
-// JWK represents a JSON Web Key
-type JWK struct {
-	Kty string `json:"kty"`
-	Kid string `json:"kid"`
-	Use string `json:"use"`
-	Alg string `json:"alg"`
-	N   string `json:"n"`
-	E   string `json:"e"`
-}
-
 // NewSupabaseValidator creates a new Supabase JWT validator
-func NewSupabaseValidator(supabaseURL string) *SupabaseValidator {
-	return &SupabaseValidator{
-		jwksURL:  fmt.Sprintf("%s/auth/v1/.well-known/jwks.json", strings.TrimSuffix(supabaseURL, "/")),
-		issuer:   fmt.Sprintf("%s/auth/v1", strings.TrimSuffix(supabaseURL, "/")),
+func NewSupabaseValidator(cfg SupabaseConfig) (*SupabaseValidator, error) {
+	baseURL := strings.TrimSuffix(cfg.URL, "/")
+	v := &SupabaseValidator{
+		issuer:   fmt.Sprintf("%s/auth/v1", baseURL),
 		audience: "authenticated",
-		keys:     make(map[string]*rsa.PublicKey),
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
 	}
+	if cfg.JWTSecret != "" {
+		v.secret = []byte(cfg.JWTSecret)
+	}
+
+	if cfg.JWKSFile != "" {
+		keys, err := loadStaticKeySet(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+	} else {
+		v.keys = newRemoteKeySet(
+			fmt.Sprintf("%s/auth/v1/.well-known/jwks.json", baseURL),
+			cfg.KeyTTL,
+			&http.Client{Timeout: 10 * time.Second},
+		)
+	}
+
+	return v, nil
 }
 
-// ValidateToken validates a Supabase JWT and returns the user ID
-func (v *SupabaseValidator) ValidateToken(ctx context.Context, tokenString string) (uuid.UUID, *SupabaseClaims, error) {
-	// Parse the token without verification first to get the kid
-	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &SupabaseClaims{})
+// Run keeps the JWKS fresh until ctx is cancelled
+func (v *SupabaseValidator) Run(ctx context.Context) {
+	v.keys.Run(ctx)
+}
+
+// Authenticate implements IdentityProvider
+func (v *SupabaseValidator) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	userID, claims, err := v.ValidateToken(ctx, token)
 	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		return nil, err
 	}
 
-	// Get the key ID from the token header
-	kid, ok := token.Header["kid"].(string)
-	if !ok {
-		return uuid.Nil, nil, fmt.Errorf("%w: missing kid in token header", ErrInvalidToken)
+	identity := &Identity{
+		UserID:  userID,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+		Method:  AuthMethodSupabase,
 	}
-
-	// Get the public key for this kid
-	publicKey, err := v.getPublicKey(ctx, kid)
-	if err != nil {
-		return uuid.Nil, nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	for _, key := range []string{"full_name", "name"} {
+		if name, ok := claims.UserMetadata[key].(string); ok && name != "" {
+			identity.Name = name
+			break
+		}
 	}
+	return identity, nil
+}
 
-	// Parse and validate the token with the public key
+// ValidateToken validates a Supabase JWT and returns the user ID
+func (v *SupabaseValidator) ValidateToken(ctx context.Context, tokenString string) (uuid.UUID, *SupabaseClaims, error) {
 	claims := &SupabaseClaims{}
-	token, err = jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// The shared secret is only ever returned for HMAC tokens so a
+		// public key can't be abused as one
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			if v.secret == nil {
+				return nil, errors.New("HS256 tokens are not accepted: no JWT secret configured")
+			}
+			return v.secret, nil
 		}
-		return publicKey, nil
-	}, jwt.WithIssuer(v.issuer), jwt.WithAudience(v.audience))
-
+		return v.keys.verificationKey(ctx, token)
+	},
+		jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.audience),
+	)
 	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return uuid.Nil, nil, ErrTokenExpired
-		}
-		return uuid.Nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		return uuid.Nil, nil, tokenError(err)
 	}
 
 	if !token.Valid {
@@ -130,94 +150,15 @@ func (v *SupabaseValidator) ValidateToken(ctx context.Context, tokenString strin
 	return userID, claims, nil
 }
 
-// getPublicKey retrieves the public key for the given kid
-func (v *SupabaseValidator) getPublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
-	// Check cache first
-	v.keysMutex.RLock()
-	key, ok := v.keys[kid]
-	v.keysMutex.RUnlock()
-	if ok {
-		return key, nil
+// tokenError maps jwt parse errors onto the package's sentinel errors
+func tokenError(err error) error {
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return ErrTokenExpired
 	}
-
-	// Fetch JWKS
-	if err := v.fetchJWKS(ctx); err != nil {
-		return nil, err
+	if errors.Is(err, jwt.ErrTokenUnverifiable) || errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
 	}
-
-	// Check cache again after fetch
-	v.keysMutex.RLock()
-	key, ok = v.keys[kid]
-	v.keysMutex.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("key with kid %s not found", kid)
-	}
-
-	return key, nil
-}
-
-// fetchJWKS fetches the JWKS from Supabase
-func (v *SupabaseValidator) fetchJWKS(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := v.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
-	}
-
-	var jwks JWKS
-	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
-		return err
-	}
-
-	// Parse and cache the keys
-	v.keysMutex.Lock()
-	defer v.keysMutex.Unlock()
-
-	for _, jwk := range jwks.Keys {
-		if jwk.Kty != "RSA" || jwk.Use != "sig" {
-			continue
-		}
-
-		key, err := parseRSAPublicKey(jwk)
-		if err != nil {
-			continue
-		}
-
-		v.keys[jwk.Kid] = key
-	}
-
-	return nil
-}
-
-// parseRSAPublicKey parses an RSA public key from a JWK
-func parseRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
-	// Decode N (modulus)
-	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
-	if err != nil {
-		return nil, err
-	}
-	n := new(big.Int).SetBytes(nBytes)
-
-	// Decode E (exponent)
-	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
-	if err != nil {
-		return nil, err
-	}
-	e := 0
-	for _, b := range eBytes {
-		e = e<<8 + int(b)
-	}
-
-	return &rsa.PublicKey{N: n, E: e}, nil
+	return fmt.Errorf("%w: %v", ErrInvalidToken, err)
 }
 
 // ExtractTokenFromHeader extracts the JWT token from the Authorization header
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSupabaseURL = "http://supabase.test"
+
+func testClaims(userID uuid.UUID) SupabaseClaims {
+	return SupabaseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			Issuer:    testSupabaseURL + "/auth/v1",
+			Audience:  jwt.ClaimStrings{"authenticated"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Email: "user@example.com",
+	}
+}
+
+func mintToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) JWK {
+	return JWK{
+		Kty: "EC",
+		Kid: kid,
+		Use: "sig",
+		Alg: "ES256",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func writeJWKSFile(t *testing.T, keys ...JWK) string {
+	t.Helper()
+	data, err := json.Marshal(JWKS{Keys: keys})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestSupabaseValidator_ValidateToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	secret := "super-secret-jwt-token-with-at-least-32-characters"
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	v, err := NewSupabaseValidator(SupabaseConfig{
+		URL:       testSupabaseURL,
+		JWTSecret: secret,
+		JWKSFile:  writeJWKSFile(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey)),
+	})
+	require.NoError(t, err)
+
+	t.Run("accepts HS256, RS256 and ES256 tokens", func(t *testing.T) {
+		tokens := map[string]string{
+			"HS256": mintToken(t, jwt.SigningMethodHS256, "", []byte(secret), testClaims(userID)),
+			"RS256": mintToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, testClaims(userID)),
+			"ES256": mintToken(t, jwt.SigningMethodES256, "ec-1", ecKey, testClaims(userID)),
+		}
+		for alg, token := range tokens {
+			gotID, claims, err := v.ValidateToken(ctx, token)
+			require.NoError(t, err, alg)
+			assert.Equal(t, userID, gotID, alg)
+			assert.Equal(t, "user@example.com", claims.Email, alg)
+		}
+	})
+
+	t.Run("rejects a token signed with the wrong secret", func(t *testing.T) {
+		token := mintToken(t, jwt.SigningMethodHS256, "", []byte("another-secret"), testClaims(userID))
+		_, _, err := v.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects a key that doesn't match the algorithm", func(t *testing.T) {
+		token := mintToken(t, jwt.SigningMethodES256, "rsa-1", ecKey, testClaims(userID))
+		_, _, err := v.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects unknown kids without fetching", func(t *testing.T) {
+		token := mintToken(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, testClaims(userID))
+		_, _, err := v.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects expired tokens", func(t *testing.T) {
+		claims := testClaims(userID)
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		token := mintToken(t, jwt.SigningMethodES256, "ec-1", ecKey, claims)
+		_, _, err := v.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("rejects the wrong audience", func(t *testing.T) {
+		claims := testClaims(userID)
+		claims.Audience = jwt.ClaimStrings{"anon"}
+		token := mintToken(t, jwt.SigningMethodHS256, "", []byte(secret), claims)
+		_, _, err := v.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("rejects HS256 when no secret is configured", func(t *testing.T) {
+		noSecret, err := NewSupabaseValidator(SupabaseConfig{
+			URL:      testSupabaseURL,
+			JWKSFile: writeJWKSFile(t, rsaJWK("rsa-1", &rsaKey.PublicKey)),
+		})
+		require.NoError(t, err)
+
+		token := mintToken(t, jwt.SigningMethodHS256, "", []byte(""), testClaims(userID))
+		_, _, err = noSecret.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestSupabaseValidator_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/v1/.well-known/jwks.json", r.URL.Path)
+		fetches.Add(1)
+		jwks := JWKS{Keys: []JWK{ecJWK("old", &oldKey.PublicKey)}}
+		if rotated.Load() {
+			jwks = JWKS{Keys: []JWK{ecJWK("new", &newKey.PublicKey)}}
+		}
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	defer srv.Close()
+
+	claims := testClaims(userID)
+	claims.Issuer = srv.URL + "/auth/v1"
+
+	v, err := NewSupabaseValidator(SupabaseConfig{URL: srv.URL, KeyTTL: time.Hour})
+	require.NoError(t, err)
+
+	_, _, err = v.ValidateToken(ctx, mintToken(t, jwt.SigningMethodES256, "old", oldKey, claims))
+	require.NoError(t, err)
+	_, _, err = v.ValidateToken(ctx, mintToken(t, jwt.SigningMethodES256, "old", oldKey, claims))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load(), "cached keys are reused")
+
+	rotated.Store(true)
+	require.NoError(t, v.keys.refresh(ctx, 0))
+
+	_, _, err = v.ValidateToken(ctx, mintToken(t, jwt.SigningMethodES256, "new", newKey, claims))
+	require.NoError(t, err)
+	_, _, err = v.ValidateToken(ctx, mintToken(t, jwt.SigningMethodES256, "old", oldKey, claims))
+	assert.ErrorIs(t, err, ErrInvalidSignature, "rotated-out keys stop validating")
+}
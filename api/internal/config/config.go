@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
@@ -17,11 +18,25 @@ type Config struct {
 	// CORS
 	AllowedOrigins []string `env:"ALLOWED_ORIGINS,default=http://localhost:5173"`
 
-	// Supabase
-	SupabaseURL       string `env:"SUPABASE_URL,required"`
-	SupabaseAnonKey   string `env:"SUPABASE_ANON_KEY,required"`
-	SupabaseServiceKey string `env:"SUPABASE_SERVICE_KEY,required"`
-	SupabaseJWTSecret string `env:"SUPABASE_JWT_SECRET,required"`
+	// Identity provider used to authenticate users: "supabase" or "oidc"
+	AuthProvider string `env:"AUTH_PROVIDER,default=supabase"`
+
+	// Supabase (required when AUTH_PROVIDER=supabase)
+	SupabaseURL       string `env:"SUPABASE_URL"`
+	SupabaseAnonKey   string `env:"SUPABASE_ANON_KEY"`
+	SupabaseServiceKey string `env:"SUPABASE_SERVICE_KEY"`
+	SupabaseJWTSecret string `env:"SUPABASE_JWT_SECRET"`
+	// Static JWKS file used instead of the Supabase endpoint (offline/local testing)
+	SupabaseJWKSFile   string        `env:"SUPABASE_JWKS_FILE"`
+	SupabaseJWKSRefresh time.Duration `env:"SUPABASE_JWKS_REFRESH,default=10m"`
+
+	// Generic OpenID Connect provider (Auth0, Keycloak, Dex, ...)
+	OIDCIssuer       string        `env:"OIDC_ISSUER"`
+	OIDCAudience     string        `env:"OIDC_AUDIENCE"`
+	OIDCSubjectClaim string        `env:"OIDC_SUBJECT_CLAIM,default=sub"`
+	OIDCEmailClaim   string        `env:"OIDC_EMAIL_CLAIM,default=email"`
+	OIDCNameClaim    string        `env:"OIDC_NAME_CLAIM,default=name"`
+	OIDCJWKSRefresh  time.Duration `env:"OIDC_JWKS_REFRESH,default=10m"`
 
 	// Database
 	DatabaseURL        string        `env:"DATABASE_URL,required"`
@@ -35,6 +50,15 @@ type Config struct {
 	GCSUploadURLExpiry  time.Duration `env:"GCS_UPLOAD_URL_EXPIRY,default=15m"`
 	GCSDownloadURLExpiry time.Duration `env:"GCS_DOWNLOAD_URL_EXPIRY,default=1h"`
 
+	// Object storage backend: "gcs" or "local". The local backend stores files
+	// under LocalStorageDir and serves them from the API via signed URLs.
+	StorageBackend  string `env:"STORAGE_BACKEND,default=gcs"`
+	LocalStorageDir string `env:"LOCAL_STORAGE_DIR,default=./data/storage"`
+	// Key for signing upload and download URLs served by the API; defaults to SDK_TOKEN_SECRET
+	StorageSigningKey string `env:"STORAGE_SIGNING_KEY"`
+	// Public base URL of this API, used to build signed URLs
+	PublicAPIURL string `env:"PUBLIC_API_URL,default=http://localhost:8080"`
+
 	// Rate Limiting
 	RateLimitEnabled     bool   `env:"RATE_LIMIT_ENABLED,default=true"`
 	RedisURL             string `env:"REDIS_URL"`
@@ -44,6 +68,13 @@ type Config struct {
 	RateLimitComment     int    `env:"RATE_LIMIT_COMMENT,default=10"`
 	RateLimitGeneral     int    `env:"RATE_LIMIT_GENERAL,default=300"`
 
+	// Deleted projects can be restored for this long before they are purged
+	ProjectDeletionGrace time.Duration `env:"PROJECT_DELETION_GRACE,default=720h"`
+
+	// Limits given to new organizations; 0 means unlimited
+	OrgDefaultMaxProjects int `env:"ORG_DEFAULT_MAX_PROJECTS,default=0"`
+	OrgDefaultMaxMembers  int `env:"ORG_DEFAULT_MAX_MEMBERS,default=0"`
+
 	// SDK Token
 	SDKTokenSecret string        `env:"SDK_TOKEN_SECRET,required"`
 	SDKTokenExpiry time.Duration `env:"SDK_TOKEN_EXPIRY,default=24h"`
@@ -55,6 +86,29 @@ func Load(ctx context.Context) (*Config, error) {
 	if err := envconfig.Process(ctx, &cfg); err != nil {
 		return nil, err
 	}
+
+	switch cfg.AuthProvider {
+	case "supabase":
+		if cfg.SupabaseURL == "" {
+			return nil, fmt.Errorf("SUPABASE_URL is required when AUTH_PROVIDER=supabase")
+		}
+	case "oidc":
+		if cfg.OIDCIssuer == "" || cfg.OIDCAudience == "" {
+			return nil, fmt.Errorf("OIDC_ISSUER and OIDC_AUDIENCE are required when AUTH_PROVIDER=oidc")
+		}
+	default:
+		return nil, fmt.Errorf("unknown AUTH_PROVIDER %q", cfg.AuthProvider)
+	}
+
+	switch cfg.StorageBackend {
+	case "gcs", "local":
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", cfg.StorageBackend)
+	}
+	if cfg.StorageSigningKey == "" {
+		cfg.StorageSigningKey = cfg.SDKTokenSecret
+	}
+
 	return &cfg, nil
 }
 
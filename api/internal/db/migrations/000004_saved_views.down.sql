@@ -0,0 +1,6 @@
+-- Rollback: Saved Views
+
+DROP TABLE IF EXISTS saved_view_visits;
+
+DROP TRIGGER IF EXISTS trg_saved_views_updated_at ON saved_views;
+DROP TABLE IF EXISTS saved_views;
@@ -0,0 +1,38 @@
+-- Migration: Saved Views
+-- Named feedback list filters for the creator console, either private to
+-- their owner or shared with the whole project team.
+
+CREATE TABLE saved_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL,  -- References Supabase auth.users.id
+    name VARCHAR(100) NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT false,
+
+    -- Serialized feedback filter (type, status, tag_ids, assigned_to, search, sort)
+    filter JSONB NOT NULL DEFAULT '{}',
+
+    -- Sidebar ordering
+    position INTEGER NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_saved_views_project_owner ON saved_views(project_id, owner_id);
+CREATE INDEX idx_saved_views_project_shared ON saved_views(project_id) WHERE shared = true;
+
+CREATE TRIGGER trg_saved_views_updated_at
+    BEFORE UPDATE ON saved_views
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at();
+
+-- Tracks when each user last opened a view, used for "new since last visit" badges
+CREATE TABLE saved_view_visits (
+    view_id UUID NOT NULL REFERENCES saved_views(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References Supabase auth.users.id
+    last_viewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (view_id, user_id)
+);
+
+CREATE INDEX idx_saved_view_visits_user ON saved_view_visits(user_id);
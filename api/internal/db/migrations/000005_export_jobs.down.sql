@@ -0,0 +1,4 @@
+-- Rollback: Export Jobs
+
+DROP TABLE IF EXISTS export_jobs;
+DROP TYPE IF EXISTS export_job_status;
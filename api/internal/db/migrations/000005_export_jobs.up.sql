@@ -0,0 +1,27 @@
+-- Migration: Export Jobs
+-- Background feedback exports that are too large to stream in a single
+-- request. The finished file is written to object storage.
+
+CREATE TYPE export_job_status AS ENUM ('pending', 'running', 'completed', 'failed');
+
+CREATE TABLE export_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL,  -- References Supabase auth.users.id
+
+    format VARCHAR(10) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+
+    status export_job_status NOT NULL DEFAULT 'pending',
+    row_count INTEGER NOT NULL DEFAULT 0,
+    storage_path TEXT,
+    error TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_export_jobs_format CHECK (format IN ('csv', 'ndjson'))
+);
+
+CREATE INDEX idx_export_jobs_project ON export_jobs(project_id, created_at DESC);
+CREATE INDEX idx_export_jobs_unfinished ON export_jobs(status) WHERE status IN ('pending', 'running');
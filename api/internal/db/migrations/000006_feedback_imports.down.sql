@@ -0,0 +1,11 @@
+-- Rollback: Feedback Imports
+
+DROP INDEX IF EXISTS idx_comments_import_source;
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS external_author_name,
+    DROP COLUMN IF EXISTS import_source_id;
+
+DROP INDEX IF EXISTS idx_feedback_import_source;
+ALTER TABLE feedback
+    DROP COLUMN IF EXISTS import_source_id,
+    DROP COLUMN IF EXISTS import_source;
@@ -0,0 +1,25 @@
+-- Migration: Feedback Imports
+-- Tracks where imported records came from so that re-running an import
+-- from another tool skips rows that were already brought in.
+
+-- ============================================
+-- Source tracking on imported records
+-- ============================================
+
+ALTER TABLE feedback
+    ADD COLUMN import_source VARCHAR(50),
+    ADD COLUMN import_source_id VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_feedback_import_source
+    ON feedback(project_id, import_source, import_source_id)
+    WHERE import_source_id IS NOT NULL;
+
+-- Imported comments are attributed to the importing team member; the
+-- original author's display name is kept alongside.
+ALTER TABLE comments
+    ADD COLUMN import_source_id VARCHAR(255),
+    ADD COLUMN external_author_name VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_comments_import_source
+    ON comments(feedback_id, import_source_id)
+    WHERE import_source_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Rollback: Customer Segments
+
+DROP INDEX IF EXISTS idx_sdk_users_traits;
+
+DROP TRIGGER IF EXISTS trg_segments_updated_at ON segments;
+DROP TABLE IF EXISTS segments;
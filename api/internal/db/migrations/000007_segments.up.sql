@@ -0,0 +1,32 @@
+-- Migration: Customer Segments
+-- Segments are saved rule sets over sdk_users.traits, used to filter
+-- feedback and voters and to scope revenue-weighted priority scores.
+
+CREATE TABLE segments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+
+    -- 'all' requires every rule to match, 'any' requires at least one
+    match VARCHAR(3) NOT NULL DEFAULT 'all',
+    -- [{"trait": "plan", "op": "eq", "value": "enterprise"}, ...]
+    rules JSONB NOT NULL DEFAULT '[]',
+
+    created_by UUID NOT NULL,  -- References Supabase auth.users.id
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_segments_match CHECK (match IN ('all', 'any')),
+    CONSTRAINT uq_segments_project_name UNIQUE (project_id, name)
+);
+
+CREATE INDEX idx_segments_project ON segments(project_id);
+
+CREATE TRIGGER trg_segments_updated_at
+    BEFORE UPDATE ON segments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at();
+
+-- Trait rules are evaluated with jsonb operators
+CREATE INDEX idx_sdk_users_traits ON sdk_users USING GIN (traits);
@@ -0,0 +1,7 @@
+-- Rollback: Portal Submissions
+
+DROP TRIGGER IF EXISTS trg_feedback_status_history ON feedback;
+DROP FUNCTION IF EXISTS log_feedback_status_change();
+
+DROP INDEX IF EXISTS idx_comments_sdk_user;
+ALTER TABLE comments DROP COLUMN IF EXISTS sdk_user_id;
@@ -0,0 +1,29 @@
+-- Migration: Portal Submissions
+-- Ties portal comments to the commenter's linked SDK user and records
+-- status changes so portal users can see an item's history.
+
+ALTER TABLE comments
+    ADD COLUMN sdk_user_id UUID REFERENCES sdk_users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_comments_sdk_user ON comments(sdk_user_id) WHERE sdk_user_id IS NOT NULL;
+
+-- Log every status change, whichever code path made it
+CREATE OR REPLACE FUNCTION log_feedback_status_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO activity_log (project_id, feedback_id, action, changes)
+    VALUES (
+        NEW.project_id,
+        NEW.id,
+        'status_changed',
+        jsonb_build_object('from', OLD.status, 'to', NEW.status)
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_feedback_status_history
+    AFTER UPDATE OF status ON feedback
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION log_feedback_status_change();
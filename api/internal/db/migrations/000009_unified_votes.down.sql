@@ -0,0 +1,28 @@
+-- Rollback: Unified Votes
+-- Portal votes stay in votes; the split cannot be recovered. SDK and
+-- anonymous votes have no place in the old schema and are removed.
+
+CREATE TABLE portal_votes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    feedback_id UUID NOT NULL REFERENCES feedback(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References Supabase auth.users.id
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_portal_votes UNIQUE (feedback_id, user_id)
+);
+
+CREATE INDEX idx_portal_votes_feedback ON portal_votes(feedback_id);
+CREATE INDEX idx_portal_votes_user ON portal_votes(user_id);
+CREATE INDEX idx_portal_votes_project ON portal_votes(project_id);
+
+DELETE FROM votes WHERE user_id IS NULL;
+
+DROP INDEX IF EXISTS idx_votes_sdk_user;
+DROP INDEX IF EXISTS uq_votes_feedback_fingerprint;
+DROP INDEX IF EXISTS uq_votes_feedback_sdk_user;
+
+ALTER TABLE votes
+    DROP CONSTRAINT IF EXISTS chk_votes_voter,
+    DROP COLUMN IF EXISTS anonymous_fingerprint,
+    DROP COLUMN IF EXISTS sdk_user_id,
+    ALTER COLUMN user_id SET NOT NULL;
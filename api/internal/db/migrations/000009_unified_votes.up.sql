@@ -0,0 +1,39 @@
+-- Migration: Unified Votes
+-- Merges portal_votes into votes so every surface reads one store and the
+-- trigger-maintained vote_count. A voter is exactly one of a Supabase user,
+-- an SDK end user, or an anonymous browser fingerprint.
+
+ALTER TABLE votes
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD COLUMN sdk_user_id UUID REFERENCES sdk_users(id) ON DELETE CASCADE,
+    ADD COLUMN anonymous_fingerprint VARCHAR(128),
+    ADD CONSTRAINT chk_votes_voter CHECK (num_nonnulls(user_id, sdk_user_id, anonymous_fingerprint) = 1);
+
+-- uq_votes_feedback_user still covers Supabase users (NULLs never collide)
+CREATE UNIQUE INDEX uq_votes_feedback_sdk_user ON votes(feedback_id, sdk_user_id)
+    WHERE sdk_user_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_votes_feedback_fingerprint ON votes(feedback_id, anonymous_fingerprint)
+    WHERE anonymous_fingerprint IS NOT NULL;
+CREATE INDEX idx_votes_sdk_user ON votes(sdk_user_id) WHERE sdk_user_id IS NOT NULL;
+
+-- Users who voted on both surfaces keep their earliest vote
+INSERT INTO votes (id, feedback_id, user_id, created_at)
+SELECT id, feedback_id, user_id, created_at
+FROM portal_votes
+ON CONFLICT (feedback_id, user_id) DO UPDATE
+    SET created_at = LEAST(votes.created_at, EXCLUDED.created_at);
+
+-- Portal votes were already counted by hand, and the insert trigger counted
+-- each merged row again. Take the portal votes back out so users who voted on
+-- both surfaces count once. Only merged feedback is touched, which keeps the
+-- tallies of imported items that have no vote rows.
+UPDATE feedback f
+SET vote_count = f.vote_count - pv.merged
+FROM (
+    SELECT feedback_id, COUNT(*) AS merged
+    FROM portal_votes
+    GROUP BY feedback_id
+) pv
+WHERE f.id = pv.feedback_id;
+
+DROP TABLE portal_votes;
@@ -0,0 +1,34 @@
+-- Rollback: Vote Details
+
+DROP TRIGGER IF EXISTS trg_votes_count ON votes;
+
+CREATE OR REPLACE FUNCTION update_feedback_vote_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE feedback SET vote_count = vote_count + 1, updated_at = NOW()
+        WHERE id = NEW.feedback_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE feedback SET vote_count = vote_count - 1, updated_at = NOW()
+        WHERE id = OLD.feedback_id;
+        RETURN OLD;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_votes_count
+AFTER INSERT OR DELETE ON votes
+FOR EACH ROW EXECUTE FUNCTION update_feedback_vote_count();
+
+DROP FUNCTION IF EXISTS vote_importance_weight(VARCHAR);
+
+DROP INDEX IF EXISTS idx_feedback_vote_score;
+ALTER TABLE feedback DROP COLUMN IF EXISTS vote_score;
+
+ALTER TABLE votes
+    DROP CONSTRAINT IF EXISTS chk_votes_note_length,
+    DROP CONSTRAINT IF EXISTS chk_votes_importance,
+    DROP COLUMN IF EXISTS recorded_by,
+    DROP COLUMN IF EXISTS note,
+    DROP COLUMN IF EXISTS importance;
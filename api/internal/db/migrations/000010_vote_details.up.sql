@@ -0,0 +1,72 @@
+-- Migration: Vote Details
+-- Voters may mark how much they need an item and leave a private note for
+-- the team. Team members may record votes on behalf of customers. Each
+-- vote's importance weight is summed into feedback.vote_score.
+
+ALTER TABLE votes
+    ADD COLUMN importance VARCHAR(20) NOT NULL DEFAULT 'nice_to_have',
+    ADD COLUMN note TEXT,
+    ADD COLUMN recorded_by UUID,  -- Team member (Supabase auth.users.id) who recorded the vote
+    ADD CONSTRAINT chk_votes_importance CHECK (importance IN ('nice_to_have', 'important', 'critical')),
+    ADD CONSTRAINT chk_votes_note_length CHECK (char_length(note) <= 1000);
+
+ALTER TABLE feedback ADD COLUMN vote_score INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_feedback_vote_score ON feedback(project_id, vote_score DESC);
+
+-- Keep in sync with domain.VoteImportance.Weight
+CREATE OR REPLACE FUNCTION vote_importance_weight(importance VARCHAR)
+RETURNS INTEGER AS $$
+    SELECT CASE importance
+        WHEN 'critical' THEN 3
+        WHEN 'important' THEN 2
+        ELSE 1
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION update_feedback_vote_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE feedback SET
+            vote_count = vote_count + 1,
+            vote_score = vote_score + vote_importance_weight(NEW.importance),
+            updated_at = NOW()
+        WHERE id = NEW.feedback_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE feedback SET
+            vote_count = vote_count - 1,
+            vote_score = vote_score - vote_importance_weight(OLD.importance),
+            updated_at = NOW()
+        WHERE id = OLD.feedback_id;
+        RETURN OLD;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF NEW.feedback_id <> OLD.feedback_id THEN
+            -- Votes move between items when feedback is merged
+            UPDATE feedback SET
+                vote_count = vote_count - 1,
+                vote_score = vote_score - vote_importance_weight(OLD.importance)
+            WHERE id = OLD.feedback_id;
+            UPDATE feedback SET
+                vote_count = vote_count + 1,
+                vote_score = vote_score + vote_importance_weight(NEW.importance)
+            WHERE id = NEW.feedback_id;
+        ELSIF NEW.importance <> OLD.importance THEN
+            UPDATE feedback SET
+                vote_score = vote_score - vote_importance_weight(OLD.importance)
+                    + vote_importance_weight(NEW.importance)
+            WHERE id = NEW.feedback_id;
+        END IF;
+        RETURN NEW;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_votes_count ON votes;
+CREATE TRIGGER trg_votes_count
+AFTER INSERT OR DELETE OR UPDATE OF feedback_id, importance ON votes
+FOR EACH ROW EXECUTE FUNCTION update_feedback_vote_count();
+
+-- Existing votes are all nice-to-have
+UPDATE feedback SET vote_score = vote_count;
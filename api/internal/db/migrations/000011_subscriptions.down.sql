@@ -0,0 +1,23 @@
+-- Rollback: Subscriptions
+
+DELETE FROM notification_queue WHERE user_id IS NULL;
+
+ALTER TABLE notification_queue
+    DROP CONSTRAINT IF EXISTS chk_notification_recipient,
+    DROP COLUMN IF EXISTS event_id,
+    DROP COLUMN IF EXISTS sdk_user_id,
+    ALTER COLUMN user_id SET NOT NULL;
+
+DROP TRIGGER IF EXISTS trg_feedback_status_notify ON feedback;
+DROP FUNCTION IF EXISTS record_status_event();
+DROP TRIGGER IF EXISTS trg_comments_notify ON comments;
+DROP FUNCTION IF EXISTS record_comment_event();
+DROP TABLE IF EXISTS notification_events;
+
+DROP TRIGGER IF EXISTS trg_votes_subscribe_voter ON votes;
+DROP FUNCTION IF EXISTS subscribe_voter();
+DROP TRIGGER IF EXISTS trg_feedback_subscribe_submitter ON feedback;
+DROP FUNCTION IF EXISTS subscribe_submitter();
+DROP FUNCTION IF EXISTS auto_subscribe(UUID, UUID, UUID, VARCHAR);
+
+DROP TABLE IF EXISTS feedback_subscriptions;
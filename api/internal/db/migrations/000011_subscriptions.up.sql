@@ -0,0 +1,165 @@
+-- Migration: Subscriptions
+-- Lets portal, SDK-identified and team users follow feedback items, records
+-- the events that notify followers, and lets the notification queue address
+-- SDK users who have no Supabase account.
+
+CREATE TABLE feedback_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    feedback_id UUID NOT NULL REFERENCES feedback(id) ON DELETE CASCADE,
+    user_id UUID,  -- References Supabase auth.users.id
+    sdk_user_id UUID REFERENCES sdk_users(id) ON DELETE CASCADE,
+    reason VARCHAR(20) NOT NULL DEFAULT 'manual',
+    -- Unsubscribing mutes rather than deletes, so later votes and comments
+    -- don't subscribe the user again
+    muted BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_subscription_reason CHECK (reason IN ('manual', 'submitted', 'commented', 'voted')),
+    CONSTRAINT chk_subscription_follower CHECK (num_nonnulls(user_id, sdk_user_id) = 1)
+);
+
+CREATE UNIQUE INDEX uq_subscriptions_user ON feedback_subscriptions(feedback_id, user_id)
+    WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_subscriptions_sdk_user ON feedback_subscriptions(feedback_id, sdk_user_id)
+    WHERE sdk_user_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_user ON feedback_subscriptions(user_id) WHERE user_id IS NOT NULL;
+
+CREATE TRIGGER trg_feedback_subscriptions_updated_at
+    BEFORE UPDATE ON feedback_subscriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at();
+
+-- Auto-subscribe submitters, commenters and voters, whichever code path
+-- created the row. Existing subscriptions (including muted ones) are kept.
+CREATE OR REPLACE FUNCTION auto_subscribe(p_feedback_id UUID, p_user_id UUID, p_sdk_user_id UUID, p_reason VARCHAR)
+RETURNS VOID AS $$
+BEGIN
+    IF p_user_id IS NOT NULL THEN
+        INSERT INTO feedback_subscriptions (feedback_id, user_id, reason)
+        VALUES (p_feedback_id, p_user_id, p_reason)
+        ON CONFLICT (feedback_id, user_id) WHERE user_id IS NOT NULL DO NOTHING;
+    ELSIF p_sdk_user_id IS NOT NULL THEN
+        INSERT INTO feedback_subscriptions (feedback_id, sdk_user_id, reason)
+        VALUES (p_feedback_id, p_sdk_user_id, p_reason)
+        ON CONFLICT (feedback_id, sdk_user_id) WHERE sdk_user_id IS NOT NULL DO NOTHING;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION subscribe_submitter()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM auto_subscribe(NEW.id, NEW.author_id, NEW.sdk_user_id, 'submitted');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_feedback_subscribe_submitter
+    AFTER INSERT ON feedback
+    FOR EACH ROW
+    EXECUTE FUNCTION subscribe_submitter();
+
+CREATE OR REPLACE FUNCTION subscribe_voter()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Votes a team member recorded for a customer don't subscribe them
+    IF NEW.recorded_by IS NULL THEN
+        PERFORM auto_subscribe(NEW.feedback_id, NEW.user_id, NEW.sdk_user_id, 'voted');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_votes_subscribe_voter
+    AFTER INSERT ON votes
+    FOR EACH ROW
+    EXECUTE FUNCTION subscribe_voter();
+
+-- Notification events: changes the notification worker fans out to an
+-- item's subscribers
+CREATE TABLE notification_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    feedback_id UUID NOT NULL REFERENCES feedback(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    actor_user_id UUID,
+    -- Visibility of the change itself, e.g. a TEAM_ONLY comment
+    visibility visibility_level NOT NULL DEFAULT 'COMMUNITY',
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    processed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_events_pending ON notification_events(created_at)
+    WHERE processed_at IS NULL;
+
+CREATE OR REPLACE FUNCTION record_comment_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM auto_subscribe(NEW.feedback_id, NEW.author_id, NULL, 'commented');
+
+    INSERT INTO notification_events (project_id, feedback_id, event_type, actor_user_id, visibility, payload)
+    SELECT f.project_id, NEW.feedback_id, 'new_comment', NEW.author_id, NEW.visibility,
+        jsonb_build_object('comment_id', NEW.id)
+    FROM feedback f
+    WHERE f.id = NEW.feedback_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Imported history is not news: it neither notifies nor subscribes anyone
+CREATE TRIGGER trg_comments_notify
+    AFTER INSERT ON comments
+    FOR EACH ROW
+    WHEN (NEW.import_source_id IS NULL)
+    EXECUTE FUNCTION record_comment_event();
+
+CREATE OR REPLACE FUNCTION record_status_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO notification_events (project_id, feedback_id, event_type, payload)
+    VALUES (
+        NEW.project_id,
+        NEW.id,
+        'status_changed',
+        jsonb_build_object('from', OLD.status, 'to', NEW.status)
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_feedback_status_notify
+    AFTER UPDATE OF status ON feedback
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION record_status_event();
+
+-- Notifications for SDK users without a Supabase account go to their email
+ALTER TABLE notification_queue
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD COLUMN sdk_user_id UUID REFERENCES sdk_users(id) ON DELETE CASCADE,
+    ADD COLUMN event_id UUID REFERENCES notification_events(id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_notification_recipient CHECK (num_nonnulls(user_id, sdk_user_id) = 1);
+
+-- Existing submitters, commenters and voters follow their items
+INSERT INTO feedback_subscriptions (feedback_id, user_id, reason)
+SELECT id, author_id, 'submitted' FROM feedback WHERE author_id IS NOT NULL
+ON CONFLICT (feedback_id, user_id) WHERE user_id IS NOT NULL DO NOTHING;
+
+INSERT INTO feedback_subscriptions (feedback_id, sdk_user_id, reason)
+SELECT id, sdk_user_id, 'submitted' FROM feedback WHERE author_id IS NULL AND sdk_user_id IS NOT NULL
+ON CONFLICT (feedback_id, sdk_user_id) WHERE sdk_user_id IS NOT NULL DO NOTHING;
+
+INSERT INTO feedback_subscriptions (feedback_id, user_id, reason)
+SELECT DISTINCT feedback_id, author_id, 'commented' FROM comments WHERE deleted_at IS NULL
+ON CONFLICT (feedback_id, user_id) WHERE user_id IS NOT NULL DO NOTHING;
+
+INSERT INTO feedback_subscriptions (feedback_id, user_id, reason)
+SELECT DISTINCT feedback_id, user_id, 'voted' FROM votes WHERE user_id IS NOT NULL AND recorded_by IS NULL
+ON CONFLICT (feedback_id, user_id) WHERE user_id IS NOT NULL DO NOTHING;
+
+INSERT INTO feedback_subscriptions (feedback_id, sdk_user_id, reason)
+SELECT DISTINCT feedback_id, sdk_user_id, 'voted' FROM votes WHERE sdk_user_id IS NOT NULL AND recorded_by IS NULL
+ON CONFLICT (feedback_id, sdk_user_id) WHERE sdk_user_id IS NOT NULL DO NOTHING;
@@ -0,0 +1,10 @@
+-- Rollback: Mentions
+
+DROP TRIGGER IF EXISTS trg_comment_mentions_notify ON comment_mentions;
+DROP FUNCTION IF EXISTS record_mention_event();
+
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE memberships DROP COLUMN IF EXISTS notification_preferences;
+
+DROP TABLE IF EXISTS comment_mentions;
@@ -0,0 +1,49 @@
+-- Migration: Mentions
+-- Records the team members @mentioned in comments, turns each mention into
+-- a notification event, and adds the in-app inbox mentions are delivered to.
+
+CREATE TABLE comment_mentions (
+    comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References Supabase auth.users.id
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX idx_comment_mentions_user ON comment_mentions(user_id);
+
+-- Per-member notification preferences; NULL follows the project settings
+ALTER TABLE memberships ADD COLUMN notification_preferences JSONB;
+
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,  -- References Supabase auth.users.id
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    feedback_id UUID REFERENCES feedback(id) ON DELETE CASCADE,
+    event_id UUID REFERENCES notification_events(id) ON DELETE SET NULL,
+    notification_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_inbox ON notifications(user_id, project_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications(user_id, project_id)
+    WHERE read_at IS NULL;
+
+CREATE OR REPLACE FUNCTION record_mention_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO notification_events (project_id, feedback_id, event_type, actor_user_id, visibility, payload)
+    SELECT f.project_id, c.feedback_id, 'mention', c.author_id, c.visibility,
+        jsonb_build_object('comment_id', c.id, 'mentioned_user_id', NEW.user_id)
+    FROM comments c
+    JOIN feedback f ON f.id = c.feedback_id
+    WHERE c.id = NEW.comment_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_comment_mentions_notify
+    AFTER INSERT ON comment_mentions
+    FOR EACH ROW
+    EXECUTE FUNCTION record_mention_event();
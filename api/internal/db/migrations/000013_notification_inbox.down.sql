@@ -0,0 +1,9 @@
+-- Rollback: Notification Inbox
+
+DROP TRIGGER IF EXISTS trg_feedback_created_notify ON feedback;
+DROP FUNCTION IF EXISTS record_new_feedback_event();
+DROP TRIGGER IF EXISTS trg_feedback_assignment_notify ON feedback;
+DROP FUNCTION IF EXISTS record_assignment_event();
+
+DROP INDEX IF EXISTS idx_saved_views_notify;
+ALTER TABLE saved_views DROP COLUMN IF EXISTS notify;
@@ -0,0 +1,45 @@
+-- Migration: Notification Inbox
+-- Records assignment and new feedback events so the notification worker can
+-- fill the in-app inbox, and lets saved view owners opt in to hearing about
+-- new feedback matching their views.
+
+ALTER TABLE saved_views ADD COLUMN notify BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_saved_views_notify ON saved_views(project_id) WHERE notify = true;
+
+CREATE OR REPLACE FUNCTION record_assignment_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO notification_events (project_id, feedback_id, event_type, visibility, payload)
+    VALUES (
+        NEW.project_id,
+        NEW.id,
+        'assigned',
+        'TEAM_ONLY',
+        jsonb_build_object('assignee_id', NEW.assigned_to, 'previous_assignee_id', OLD.assigned_to)
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_feedback_assignment_notify
+    AFTER UPDATE OF assigned_to ON feedback
+    FOR EACH ROW
+    WHEN (NEW.assigned_to IS NOT NULL AND OLD.assigned_to IS DISTINCT FROM NEW.assigned_to)
+    EXECUTE FUNCTION record_assignment_event();
+
+CREATE OR REPLACE FUNCTION record_new_feedback_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO notification_events (project_id, feedback_id, event_type, actor_user_id, visibility)
+    VALUES (NEW.project_id, NEW.id, 'new_feedback', NEW.author_id, NEW.visibility);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Imported items are history, not new feedback
+CREATE TRIGGER trg_feedback_created_notify
+    AFTER INSERT ON feedback
+    FOR EACH ROW
+    WHEN (NEW.import_source IS NULL)
+    EXECUTE FUNCTION record_new_feedback_event();
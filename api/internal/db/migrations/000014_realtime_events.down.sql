@@ -0,0 +1,12 @@
+-- Rollback: Realtime Events
+
+DROP TRIGGER IF EXISTS trg_comments_realtime ON comments;
+DROP FUNCTION IF EXISTS publish_comment_realtime_event();
+DROP TRIGGER IF EXISTS trg_feedback_realtime_updated ON feedback;
+DROP TRIGGER IF EXISTS trg_feedback_realtime_created ON feedback;
+DROP FUNCTION IF EXISTS publish_feedback_realtime_event();
+DROP FUNCTION IF EXISTS publish_realtime_event(UUID, UUID, VARCHAR, visibility_level, JSONB);
+
+DROP TABLE IF EXISTS realtime_events;
+DROP FUNCTION IF EXISTS announce_realtime_event();
+DROP SEQUENCE IF EXISTS realtime_events_id_seq;
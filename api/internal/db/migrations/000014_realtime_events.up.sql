@@ -0,0 +1,125 @@
+-- Migration: Realtime Events
+-- Records live changes to feedback items and announces them with
+-- NOTIFY realtime_events so that every API instance can stream them to its
+-- SSE clients. Rows are kept briefly so reconnecting clients can resume
+-- from their Last-Event-ID.
+
+-- Event ids follow commit order, not insert order: a client that has seen
+-- an id must never miss an event that commits after it with a lower one
+CREATE SEQUENCE realtime_events_id_seq;
+
+CREATE TABLE realtime_events (
+    row_id BIGSERIAL PRIMARY KEY,
+    id BIGINT UNIQUE, -- Assigned as the transaction commits
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    feedback_id UUID REFERENCES feedback(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    -- TEAM_ONLY if the change or the item it is on is team-only
+    visibility visibility_level NOT NULL DEFAULT 'COMMUNITY',
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_realtime_events_project ON realtime_events(project_id, id);
+CREATE INDEX idx_realtime_events_created ON realtime_events(created_at);
+
+CREATE OR REPLACE FUNCTION publish_realtime_event(
+    p_project_id UUID,
+    p_feedback_id UUID,
+    p_event_type VARCHAR,
+    p_visibility visibility_level,
+    p_payload JSONB
+) RETURNS VOID AS $$
+BEGIN
+    INSERT INTO realtime_events (project_id, feedback_id, event_type, visibility, payload)
+    VALUES (p_project_id, p_feedback_id, p_event_type, p_visibility, p_payload);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Runs as the transaction commits. The lock is held until the commit
+-- completes, so ids are handed out in commit order, and NOTIFY, delivered
+-- on commit, announces them in ascending order. Listeners never see rolled
+-- back changes.
+CREATE OR REPLACE FUNCTION announce_realtime_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    e realtime_events;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('realtime_events'));
+
+    UPDATE realtime_events SET id = nextval('realtime_events_id_seq')
+    WHERE row_id = NEW.row_id
+    RETURNING * INTO e;
+
+    -- The row is gone if its item was deleted in the same transaction
+    IF FOUND THEN
+        PERFORM pg_notify('realtime_events', row_to_json(e)::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_realtime_events_announce
+    AFTER INSERT ON realtime_events
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+    EXECUTE FUNCTION announce_realtime_event();
+
+CREATE OR REPLACE FUNCTION publish_feedback_realtime_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM publish_realtime_event(NEW.project_id, NEW.id, 'feedback_created', NEW.visibility,
+            jsonb_build_object('title', NEW.title, 'type', NEW.type, 'status', NEW.status));
+        RETURN NEW;
+    END IF;
+
+    IF OLD.status IS DISTINCT FROM NEW.status THEN
+        PERFORM publish_realtime_event(NEW.project_id, NEW.id, 'status_changed', NEW.visibility,
+            jsonb_build_object('from', OLD.status, 'to', NEW.status));
+    END IF;
+
+    IF OLD.vote_count IS DISTINCT FROM NEW.vote_count OR OLD.vote_score IS DISTINCT FROM NEW.vote_score THEN
+        PERFORM publish_realtime_event(NEW.project_id, NEW.id, 'votes_changed', NEW.visibility,
+            jsonb_build_object('vote_count', NEW.vote_count, 'vote_score', NEW.vote_score));
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Imported items are history, not live changes
+CREATE TRIGGER trg_feedback_realtime_created
+    AFTER INSERT ON feedback
+    FOR EACH ROW
+    WHEN (NEW.import_source IS NULL)
+    EXECUTE FUNCTION publish_feedback_realtime_event();
+
+CREATE TRIGGER trg_feedback_realtime_updated
+    AFTER UPDATE OF status, vote_count, vote_score ON feedback
+    FOR EACH ROW
+    EXECUTE FUNCTION publish_feedback_realtime_event();
+
+CREATE OR REPLACE FUNCTION publish_comment_realtime_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM publish_realtime_event(
+        f.project_id,
+        NEW.feedback_id,
+        'comment_created',
+        CASE WHEN NEW.visibility = 'TEAM_ONLY' OR f.visibility = 'TEAM_ONLY'
+            THEN 'TEAM_ONLY'::visibility_level ELSE 'COMMUNITY'::visibility_level END,
+        jsonb_build_object('comment_id', NEW.id, 'parent_id', NEW.parent_id)
+    )
+    FROM feedback f
+    WHERE f.id = NEW.feedback_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Imported comments are history too
+CREATE TRIGGER trg_comments_realtime
+    AFTER INSERT ON comments
+    FOR EACH ROW
+    WHEN (NEW.import_source_id IS NULL)
+    EXECUTE FUNCTION publish_comment_realtime_event();
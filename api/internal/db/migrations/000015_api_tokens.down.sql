@@ -0,0 +1,4 @@
+-- Rollback: API tokens
+
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS service_accounts;
@@ -0,0 +1,39 @@
+-- Migration: API tokens
+-- Personal access tokens let team members script the creator API with their
+-- own permissions; service accounts are project-scoped robot identities with
+-- a fixed role. Both authenticate with hashed bearer tokens, like sdk_tokens.
+
+CREATE TABLE service_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_by UUID NOT NULL,  -- References Supabase auth.users.id
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    disabled_at TIMESTAMPTZ,
+    -- Service accounts can't own or delete projects
+    CONSTRAINT chk_service_account_role CHECK (role IN ('viewer', 'member', 'admin'))
+);
+
+CREATE INDEX idx_service_accounts_project ON service_accounts(project_id) WHERE disabled_at IS NULL;
+
+CREATE TABLE api_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- Exactly one owner: the user for personal tokens, or the service account
+    user_id UUID,  -- References Supabase auth.users.id
+    service_account_id UUID REFERENCES service_accounts(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    token_prefix VARCHAR(12) NOT NULL,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_by UUID NOT NULL,  -- References Supabase auth.users.id
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_api_token_owner CHECK (num_nonnulls(user_id, service_account_id) = 1)
+);
+
+CREATE UNIQUE INDEX uq_api_tokens_hash ON api_tokens(token_hash);
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id) WHERE user_id IS NOT NULL AND revoked_at IS NULL;
+CREATE INDEX idx_api_tokens_service_account ON api_tokens(service_account_id)
+    WHERE service_account_id IS NOT NULL AND revoked_at IS NULL;
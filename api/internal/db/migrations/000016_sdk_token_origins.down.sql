@@ -0,0 +1,12 @@
+-- Rollback: SDK token origins
+
+ALTER TABLE sdk_tokens
+    ALTER COLUMN allowed_origins DROP NOT NULL,
+    ALTER COLUMN allowed_origins DROP DEFAULT;
+
+-- An empty list meant "any origin" before the flag existed
+UPDATE sdk_tokens SET allowed_origins = '{}' WHERE allow_any_origin;
+
+ALTER TABLE sdk_tokens
+    DROP COLUMN IF EXISTS allowed_bundle_ids,
+    DROP COLUMN IF EXISTS allow_any_origin;
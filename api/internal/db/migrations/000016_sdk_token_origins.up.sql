@@ -0,0 +1,22 @@
+-- Migration: SDK token origins
+-- Origin checks used to treat an empty allow-list as "any origin". Allowing
+-- any origin is now an explicit flag, and native apps are allow-listed by
+-- bundle ID since they send no Origin header.
+
+ALTER TABLE sdk_tokens
+    ADD COLUMN allow_any_origin BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN allowed_bundle_ids TEXT[] NOT NULL DEFAULT '{}';
+
+-- Keep existing tokens that relied on an empty list or "*" working
+UPDATE sdk_tokens
+SET allow_any_origin = true
+WHERE allowed_origins IS NULL
+   OR cardinality(allowed_origins) = 0
+   OR '*' = ANY(allowed_origins);
+
+UPDATE sdk_tokens
+SET allowed_origins = array_remove(COALESCE(allowed_origins, '{}'), '*');
+
+ALTER TABLE sdk_tokens
+    ALTER COLUMN allowed_origins SET DEFAULT '{}',
+    ALTER COLUMN allowed_origins SET NOT NULL;
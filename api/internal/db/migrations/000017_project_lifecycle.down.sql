@@ -0,0 +1,5 @@
+-- Rollback: Project lifecycle
+
+DROP TABLE IF EXISTS project_transfers;
+DROP INDEX IF EXISTS idx_projects_deletion_scheduled;
+ALTER TABLE projects DROP COLUMN IF EXISTS deletion_scheduled_at;
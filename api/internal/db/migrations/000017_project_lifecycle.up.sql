@@ -0,0 +1,26 @@
+-- Migration: Project lifecycle
+-- Archived projects are read-only. Deleting a project archives it and
+-- schedules a purge after a grace period, during which it can be restored.
+-- Ownership transfers wait for the new owner to accept.
+
+ALTER TABLE projects ADD COLUMN deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX idx_projects_deletion_scheduled ON projects(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
+
+CREATE TABLE project_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_project_transfers_users CHECK (from_user_id <> to_user_id)
+);
+
+-- At most one open transfer per project
+CREATE UNIQUE INDEX idx_project_transfers_pending ON project_transfers(project_id)
+    WHERE accepted_at IS NULL AND cancelled_at IS NULL;
@@ -0,0 +1,3 @@
+-- Rollback: Custom domains
+
+DROP TABLE IF EXISTS project_domains;
@@ -0,0 +1,21 @@
+-- Migration: Custom domains
+-- A project can serve its public portal from its own hostname. Ownership is
+-- proven with a DNS TXT record holding the verification token; until then
+-- the hostname resolves to nothing. Several projects may claim a hostname,
+-- but only one can verify it.
+
+CREATE TABLE project_domains (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    hostname TEXT NOT NULL,
+    verification_token TEXT NOT NULL,
+    verified_at TIMESTAMPTZ,
+    last_checked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_project_domains_project UNIQUE (project_id),
+    CONSTRAINT chk_project_domains_hostname CHECK (hostname = LOWER(hostname))
+);
+
+CREATE UNIQUE INDEX idx_project_domains_verified ON project_domains(hostname)
+    WHERE verified_at IS NOT NULL;
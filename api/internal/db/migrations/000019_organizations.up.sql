@@ -0,0 +1,64 @@
+-- Migration: Organizations
+-- An organization owns projects and has members whose role applies to all
+-- of its projects. A project membership overrides the inherited role for
+-- that project. Limits cap the organization's projects and members; NULL
+-- means unlimited.
+
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    max_projects INTEGER CHECK (max_projects >= 0),
+    max_members INTEGER CHECK (max_members >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_organizations_updated_at
+BEFORE UPDATE ON organizations
+FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE organization_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References auth.users (Supabase)
+    role membership_role NOT NULL DEFAULT 'member',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_organization_members UNIQUE (organization_id, user_id),
+    CONSTRAINT chk_organization_members_role CHECK (role <> 'community')
+);
+
+CREATE INDEX idx_organization_members_user ON organization_members(user_id);
+
+ALTER TABLE projects ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_projects_organization ON projects(organization_id);
+
+-- Every existing project owner gets a workspace holding the projects they own
+CREATE TEMP TABLE owner_workspaces AS
+SELECT o.user_id, uuid_generate_v4() AS organization_id
+FROM (SELECT DISTINCT user_id FROM memberships WHERE role = 'owner') o;
+
+INSERT INTO organizations (id, name, slug)
+SELECT organization_id, 'Workspace', 'workspace-' || substr(replace(user_id::text, '-', ''), 1, 12)
+FROM owner_workspaces;
+
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT organization_id, user_id, 'owner'
+FROM owner_workspaces;
+
+-- A project with several owners goes to its longest-standing owner
+UPDATE projects p
+SET organization_id = w.organization_id
+FROM (
+    SELECT DISTINCT ON (project_id) project_id, user_id
+    FROM memberships
+    WHERE role = 'owner'
+    ORDER BY project_id, created_at, user_id
+) m
+JOIN owner_workspaces w ON w.user_id = m.user_id
+WHERE m.project_id = p.id;
+
+DROP TABLE owner_workspaces;
@@ -0,0 +1,5 @@
+-- Rollback: Custom project roles
+
+DROP INDEX IF EXISTS idx_memberships_custom_role;
+ALTER TABLE memberships DROP COLUMN IF EXISTS custom_role_id;
+DROP TABLE IF EXISTS project_roles;
@@ -0,0 +1,28 @@
+-- Migration: Custom project roles
+-- Built-in roles map to default permission sets in code. Admins can define
+-- custom roles with their own permission set; a membership assigned one
+-- gets its permissions instead of its built-in role's defaults. Roles in
+-- use can't be deleted, so members never silently fall back to a broader
+-- built-in role. The foreign key is NO ACTION rather than RESTRICT so a
+-- project purge can cascade to its roles and memberships together.
+
+CREATE TABLE project_roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    description TEXT,
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_project_roles_name UNIQUE (project_id, name)
+);
+
+CREATE TRIGGER trg_project_roles_updated_at
+BEFORE UPDATE ON project_roles
+FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+ALTER TABLE memberships
+    ADD COLUMN custom_role_id UUID REFERENCES project_roles(id);
+
+CREATE INDEX idx_memberships_custom_role ON memberships(custom_role_id) WHERE custom_role_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Rollback: Effective memberships
+
+DROP VIEW IF EXISTS effective_memberships;
+DROP TABLE IF EXISTS inherited_member_preferences;
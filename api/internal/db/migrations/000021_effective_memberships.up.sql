@@ -0,0 +1,39 @@
+-- Migration: Effective memberships
+-- A user's effective role in a project is their team membership there, else
+-- the role inherited from the organization that owns the project. Community
+-- memberships don't override an organization role. This mirrors the
+-- membership resolver so queries can join members without it.
+
+-- Notification preferences of members who only inherit their role; members
+-- with a memberships row keep theirs on that row
+CREATE TABLE inherited_member_preferences (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References auth.users (Supabase)
+    notification_preferences JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE TRIGGER trg_inherited_member_preferences_updated_at
+BEFORE UPDATE ON inherited_member_preferences
+FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE VIEW effective_memberships AS
+SELECT m.project_id, m.user_id, m.role, m.notification_preferences
+FROM memberships m
+WHERE m.role <> 'community'
+    OR NOT EXISTS (
+        SELECT 1
+        FROM organization_members om
+        JOIN projects p ON p.organization_id = om.organization_id
+        WHERE p.id = m.project_id AND om.user_id = m.user_id
+    )
+UNION ALL
+SELECT p.id, om.user_id, om.role, COALESCE(m.notification_preferences, imp.notification_preferences)
+FROM organization_members om
+JOIN projects p ON p.organization_id = om.organization_id
+LEFT JOIN memberships m ON m.project_id = p.id AND m.user_id = om.user_id
+LEFT JOIN inherited_member_preferences imp ON imp.project_id = p.id AND imp.user_id = om.user_id
+WHERE m.id IS NULL OR m.role = 'community';
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsInterval is the bucket size of a time series
+type AnalyticsInterval string
+
+const (
+	IntervalDay  AnalyticsInterval = "day"
+	IntervalWeek AnalyticsInterval = "week"
+)
+
+// IsValid checks if the interval is valid
+func (i AnalyticsInterval) IsValid() bool {
+	return i == IntervalDay || i == IntervalWeek
+}
+
+// MaxAnalyticsRange bounds the date range of a single analytics query
+const MaxAnalyticsRange = 366 * 24 * time.Hour
+
+// AnalyticsFilter scopes an analytics query to a date range and tags.
+// From is inclusive, To is exclusive.
+type AnalyticsFilter struct {
+	From     time.Time
+	To       time.Time
+	Interval AnalyticsInterval
+	TagIDs   []uuid.UUID
+	Limit    int
+}
+
+// Validate validates the filter
+func (f *AnalyticsFilter) Validate() error {
+	if !f.To.After(f.From) {
+		return fmt.Errorf("to must be after from")
+	}
+	if f.To.Sub(f.From) > MaxAnalyticsRange {
+		return fmt.Errorf("date range must be one year or less")
+	}
+	if !f.Interval.IsValid() {
+		return fmt.Errorf("interval must be day or week")
+	}
+	return nil
+}
+
+// Buckets returns the start of every interval bucket in the range, in UTC.
+// Weeks start on Monday to match Postgres date_trunc.
+func (f *AnalyticsFilter) Buckets() []time.Time {
+	start := TruncateToInterval(f.From, f.Interval)
+	step := 24 * time.Hour
+	if f.Interval == IntervalWeek {
+		step = 7 * 24 * time.Hour
+	}
+
+	var buckets []time.Time
+	for t := start; t.Before(f.To); t = t.Add(step) {
+		buckets = append(buckets, t)
+	}
+	return buckets
+}
+
+// TruncateToInterval returns the start of the bucket containing t, in UTC
+func TruncateToInterval(t time.Time, interval AnalyticsInterval) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == IntervalWeek {
+		offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// SeriesPoint is one bucket of a chart series
+type SeriesPoint struct {
+	Bucket time.Time `json:"bucket"`
+	Value  float64   `json:"value"`
+}
+
+// Series is a named, zero-filled chart series
+type Series struct {
+	Name   string        `json:"name"`
+	Points []SeriesPoint `json:"points"`
+}
+
+// VolumeReport is feedback created per bucket, split by type and by source
+type VolumeReport struct {
+	Interval AnalyticsInterval `json:"interval"`
+	Total    int               `json:"total"`
+	ByType   []Series          `json:"by_type"`
+	BySource []Series          `json:"by_source"`
+}
+
+// ResolutionStats summarizes time from creation to resolution, in hours
+type ResolutionStats struct {
+	Name        string   `json:"name"` // "all" or a feedback type
+	Resolved    int      `json:"resolved"`
+	MedianHours *float64 `json:"median_hours"`
+	P90Hours    *float64 `json:"p90_hours"`
+}
+
+// ResolutionReport holds resolution stats overall and per feedback type
+type ResolutionReport struct {
+	Overall ResolutionStats   `json:"overall"`
+	ByType  []ResolutionStats `json:"by_type"`
+}
+
+// FunnelStage is one status in the feedback funnel. Count is items currently
+// in the status; Reached also includes items that have moved past it.
+type FunnelStage struct {
+	Status  FeedbackStatus `json:"status"`
+	Count   int            `json:"count"`
+	Reached int            `json:"reached"`
+}
+
+// FunnelReport is the status funnel for feedback created in the range
+type FunnelReport struct {
+	Total  int           `json:"total"`
+	Stages []FunnelStage `json:"stages"`
+	// Closed without progressing through the pipeline
+	Declined  int `json:"declined"`
+	Duplicate int `json:"duplicate"`
+}
+
+// FunnelPipeline is the order open feedback moves through towards completion
+var FunnelPipeline = []FeedbackStatus{
+	StatusNew,
+	StatusUnderReview,
+	StatusPlanned,
+	StatusInProgress,
+	StatusCompleted,
+}
+
+// TopRequest is an open item ranked by votes received in the range
+type TopRequest struct {
+	FeedbackID    uuid.UUID      `json:"feedback_id"`
+	Title         string         `json:"title"`
+	Type          FeedbackType   `json:"type"`
+	Status        FeedbackStatus `json:"status"`
+	VoteCount     int            `json:"vote_count"`
+	VotesInWindow int            `json:"votes_in_window"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// VoteVelocityReport is votes cast per bucket
+type VoteVelocityReport struct {
+	Interval      AnalyticsInterval `json:"interval"`
+	Total         int               `json:"total"`
+	AveragePerDay float64           `json:"average_per_day"`
+	Series        Series            `json:"series"`
+}
+
+// ActiveSDKUser is an identified SDK user ranked by feedback submitted
+type ActiveSDKUser struct {
+	ID            uuid.UUID `json:"id"`
+	ExternalID    string    `json:"external_id"`
+	Email         *string   `json:"email,omitempty"`
+	Name          *string   `json:"name,omitempty"`
+	FeedbackCount int       `json:"feedback_count"`
+	LastSubmitted time.Time `json:"last_submitted_at"`
+}
+
+// BuildSeries creates a zero-filled series over buckets from values keyed by
+// bucket start
+func BuildSeries(name string, buckets []time.Time, values map[time.Time]float64) Series {
+	points := make([]SeriesPoint, len(buckets))
+	for i, b := range buckets {
+		points[i] = SeriesPoint{Bucket: b, Value: values[b]}
+	}
+	return Series{Name: name, Points: points}
+}
@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Branding limits
+const (
+	MaxBrandingTitleLength   = 100
+	MaxWelcomeMessageLength  = 2000
+	MaxBrandingLinks         = 5
+	MaxBrandingLinkLabelSize = 50
+	MaxLogoSize              = 2 * 1024 * 1024
+)
+
+// LogoContentTypes are the image types accepted for project logos. SVG is
+// excluded because it can carry script.
+var LogoContentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ProjectBranding customizes the public portal and SDK widgets. The primary
+// color lives on the project itself.
+type ProjectBranding struct {
+	Title          string         `json:"title,omitempty"`
+	WelcomeMessage string         `json:"welcome_message,omitempty"`
+	AccentColor    string         `json:"accent_color,omitempty"`
+	Links          []BrandingLink `json:"links"`
+	// AcceptedTypes are the feedback types the public portal accepts
+	AcceptedTypes []FeedbackType `json:"accepted_types"`
+	// LogoPath is the storage path of an uploaded logo
+	LogoPath string `json:"logo_path,omitempty"`
+}
+
+// BrandingLink is a link shown in the portal header
+type BrandingLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// DefaultProjectBranding returns the branding of a new project
+func DefaultProjectBranding() ProjectBranding {
+	return ProjectBranding{
+		Links:         []BrandingLink{},
+		AcceptedTypes: []FeedbackType{FeedbackTypeBug, FeedbackTypeFeature, FeedbackTypeGeneral},
+	}
+}
+
+// Accepts returns true if the public portal accepts the feedback type
+func (b ProjectBranding) Accepts(t FeedbackType) bool {
+	for _, accepted := range b.AcceptedTypes {
+		if accepted == t {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultType returns the type used for portal submissions that don't pick
+// one: feature if accepted, otherwise the first accepted type
+func (b ProjectBranding) DefaultType() FeedbackType {
+	if b.Accepts(FeedbackTypeFeature) || len(b.AcceptedTypes) == 0 {
+		return FeedbackTypeFeature
+	}
+	return b.AcceptedTypes[0]
+}
+
+// LogoPathPrefix is where a project's uploaded logos are stored
+func LogoPathPrefix(projectID uuid.UUID) string {
+	return fmt.Sprintf("projects/%s/branding/", projectID)
+}
+
+// UpdateBrandingRequest updates a project's branding; nil fields are left
+// unchanged
+type UpdateBrandingRequest struct {
+	Title          *string         `json:"title,omitempty"`
+	WelcomeMessage *string         `json:"welcome_message,omitempty"`
+	PrimaryColor   *string         `json:"primary_color,omitempty"`
+	AccentColor    *string         `json:"accent_color,omitempty"`
+	Links          *[]BrandingLink `json:"links,omitempty"`
+	AcceptedTypes  *[]FeedbackType `json:"accepted_types,omitempty"`
+}
+
+// Validate checks the request, trimming text fields in place
+func (r *UpdateBrandingRequest) Validate() error {
+	if r.Title != nil {
+		title := strings.TrimSpace(*r.Title)
+		r.Title = &title
+		if len(title) > MaxBrandingTitleLength {
+			return fmt.Errorf("title must be %d characters or less", MaxBrandingTitleLength)
+		}
+	}
+	if r.WelcomeMessage != nil {
+		message := strings.TrimSpace(*r.WelcomeMessage)
+		r.WelcomeMessage = &message
+		if len(message) > MaxWelcomeMessageLength {
+			return fmt.Errorf("welcome_message must be %d characters or less", MaxWelcomeMessageLength)
+		}
+	}
+	if r.PrimaryColor != nil && !isValidHexColor(*r.PrimaryColor) {
+		return fmt.Errorf("invalid primary_color: must be a hex color (e.g., #6B7280)")
+	}
+	if r.AccentColor != nil && *r.AccentColor != "" && !isValidHexColor(*r.AccentColor) {
+		return fmt.Errorf("invalid accent_color: must be a hex color (e.g., #6B7280)")
+	}
+	if r.Links != nil {
+		links := *r.Links
+		if len(links) > MaxBrandingLinks {
+			return fmt.Errorf("at most %d links are allowed", MaxBrandingLinks)
+		}
+		for i := range links {
+			links[i].Label = strings.TrimSpace(links[i].Label)
+			links[i].URL = strings.TrimSpace(links[i].URL)
+			if links[i].Label == "" {
+				return fmt.Errorf("link label is required")
+			}
+			if len(links[i].Label) > MaxBrandingLinkLabelSize {
+				return fmt.Errorf("link label must be %d characters or less", MaxBrandingLinkLabelSize)
+			}
+			if !isValidLinkURL(links[i].URL) {
+				return fmt.Errorf("invalid link url: %s", links[i].URL)
+			}
+		}
+	}
+	if r.AcceptedTypes != nil {
+		types := *r.AcceptedTypes
+		if len(types) == 0 {
+			return fmt.Errorf("accepted_types must include at least one type")
+		}
+		seen := make(map[FeedbackType]bool, len(types))
+		for _, t := range types {
+			if !t.IsValid() {
+				return fmt.Errorf("invalid feedback type: %s", t)
+			}
+			if seen[t] {
+				return fmt.Errorf("duplicate feedback type: %s", t)
+			}
+			seen[t] = true
+		}
+	}
+	return nil
+}
+
+// Apply copies the set fields onto the project
+func (r *UpdateBrandingRequest) Apply(p *Project) {
+	b := &p.Settings.Branding
+	if r.Title != nil {
+		b.Title = *r.Title
+	}
+	if r.WelcomeMessage != nil {
+		b.WelcomeMessage = *r.WelcomeMessage
+	}
+	if r.PrimaryColor != nil {
+		p.PrimaryColor = *r.PrimaryColor
+	}
+	if r.AccentColor != nil {
+		b.AccentColor = *r.AccentColor
+	}
+	if r.Links != nil {
+		b.Links = *r.Links
+	}
+	if r.AcceptedTypes != nil {
+		b.AcceptedTypes = *r.AcceptedTypes
+	}
+}
+
+// LogoUploadRequest starts a logo upload
+type LogoUploadRequest struct {
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+
+// Validate checks the logo's type and size
+func (r *LogoUploadRequest) Validate() error {
+	if _, ok := LogoContentTypes[r.ContentType]; !ok {
+		return fmt.Errorf("logo must be a PNG, JPEG, GIF or WebP image")
+	}
+	if r.SizeBytes <= 0 {
+		return fmt.Errorf("size_bytes must be positive")
+	}
+	if r.SizeBytes > MaxLogoSize {
+		return fmt.Errorf("logo must be %d bytes or less", MaxLogoSize)
+	}
+	return nil
+}
+
+// PublicPortalConfig is the branding and portal settings served to the
+// portal frontend and SDK widgets without authentication
+type PublicPortalConfig struct {
+	ProjectID                uuid.UUID      `json:"project_id"`
+	Name                     string         `json:"name"`
+	Slug                     string         `json:"slug"`
+	Title                    string         `json:"title"`
+	WelcomeMessage           string         `json:"welcome_message,omitempty"`
+	LogoURL                  *string        `json:"logo_url,omitempty"`
+	PrimaryColor             string         `json:"primary_color"`
+	AccentColor              string         `json:"accent_color,omitempty"`
+	Links                    []BrandingLink `json:"links"`
+	AcceptedTypes            []FeedbackType `json:"accepted_types"`
+	AllowAnonymousFeedback   bool           `json:"allow_anonymous_feedback"`
+	RequireEmailForAnonymous bool           `json:"require_email_for_anonymous"`
+	VotingEnabled            bool           `json:"voting_enabled"`
+	CommunityCommentsEnabled bool           `json:"community_comments_enabled"`
+}
+
+// NewPublicPortalConfig builds the public config of a project. logoURL is
+// the resolved URL of the logo, if any.
+func NewPublicPortalConfig(p *Project, logoURL *string) *PublicPortalConfig {
+	b := p.Settings.Branding
+	title := b.Title
+	if title == "" {
+		title = p.Name
+	}
+	links := b.Links
+	if links == nil {
+		links = []BrandingLink{}
+	}
+	return &PublicPortalConfig{
+		ProjectID:                p.ID,
+		Name:                     p.Name,
+		Slug:                     p.Slug,
+		Title:                    title,
+		WelcomeMessage:           b.WelcomeMessage,
+		LogoURL:                  logoURL,
+		PrimaryColor:             p.PrimaryColor,
+		AccentColor:              b.AccentColor,
+		Links:                    links,
+		AcceptedTypes:            b.AcceptedTypes,
+		AllowAnonymousFeedback:   p.Settings.AllowAnonymousFeedback,
+		RequireEmailForAnonymous: p.Settings.RequireEmailForAnonymous,
+		VotingEnabled:            p.Settings.VotingEnabled,
+		CommunityCommentsEnabled: p.Settings.CommunityCommentsEnabled,
+	}
+}
+
+func isValidLinkURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "https" || u.Scheme == "http"
+}
@@ -2,7 +2,9 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
@@ -12,6 +14,7 @@ type Comment struct {
 	ID         uuid.UUID  `json:"id"`
 	FeedbackID uuid.UUID  `json:"feedback_id"`
 	AuthorID   uuid.UUID  `json:"author_id"`
+	SDKUserID  *uuid.UUID `json:"sdk_user_id,omitempty"` // Set for portal comments
 	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
 	Body       string     `json:"body"`
 	Visibility Visibility `json:"visibility"`
@@ -21,8 +24,9 @@ type Comment struct {
 	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
 
 	// Relationships (populated by service layer)
-	Author   *User      `json:"author,omitempty"`
-	Replies  []Comment  `json:"replies,omitempty"`
+	Author   *User       `json:"author,omitempty"`
+	Replies  []Comment   `json:"replies,omitempty"`
+	Mentions []uuid.UUID `json:"mentions,omitempty"` // Members notified of the comment
 }
 
 // IsDeleted returns true if the comment has been soft-deleted
@@ -56,3 +60,52 @@ func (c *Comment) Validate() error {
 	}
 	return nil
 }
+
+// ParseMentions returns the normalized handles @mentioned in a comment body,
+// in order of first appearance. An @ inside a word, such as in an email
+// address, is not a mention.
+func ParseMentions(body string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+
+	runes := []rune(body)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isMentionRune(runes[i-1])) {
+			continue
+		}
+
+		j := i + 1
+		for j < len(runes) && isMentionRune(runes[j]) {
+			j++
+		}
+		// Trailing punctuation ends the sentence, not the handle
+		raw := strings.TrimRight(string(runes[i+1:j]), "._-")
+		i = j - 1
+
+		handle := NormalizeMentionHandle(raw)
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+
+	return handles
+}
+
+// NormalizeMentionHandle lowercases a handle or display name and drops
+// everything but letters and digits, so "@jane.doe" and "@JaneDoe" both
+// match the display name "Jane Doe"
+func NormalizeMentionHandle(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+func isMentionRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-' || r == '@'
+}
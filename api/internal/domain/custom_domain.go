@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Custom domain verification records. The TXT record is published at
+// CustomDomainTXTPrefix + hostname.
+const (
+	CustomDomainTXTPrefix      = "_fulldisclosure."
+	CustomDomainTXTValuePrefix = "fulldisclosure-verification="
+)
+
+// CustomDomain is a hostname a project serves its public portal from. It
+// is only used once verified.
+type CustomDomain struct {
+	ID                uuid.UUID  `json:"id"`
+	ProjectID         uuid.UUID  `json:"project_id"`
+	Hostname          string     `json:"hostname"`
+	VerificationToken string     `json:"-"`
+	VerifiedAt        *time.Time `json:"verified_at,omitempty"`
+	LastCheckedAt     *time.Time `json:"last_checked_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// IsVerified returns true once DNS ownership has been proven
+func (d *CustomDomain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+// TXTRecordName is the DNS name the verification record is published at
+func (d *CustomDomain) TXTRecordName() string {
+	return CustomDomainTXTPrefix + d.Hostname
+}
+
+// TXTRecordValue is the content of the verification record
+func (d *CustomDomain) TXTRecordValue() string {
+	return CustomDomainTXTValuePrefix + d.VerificationToken
+}
+
+// CustomDomainResponse is a custom domain with its DNS instructions
+type CustomDomainResponse struct {
+	*CustomDomain
+	Verified  bool      `json:"verified"`
+	TXTRecord TXTRecord `json:"txt_record"`
+}
+
+// TXTRecord is a DNS record the customer publishes
+type TXTRecord struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewCustomDomainResponse builds the response for a custom domain
+func NewCustomDomainResponse(d *CustomDomain) *CustomDomainResponse {
+	return &CustomDomainResponse{
+		CustomDomain: d,
+		Verified:     d.IsVerified(),
+		TXTRecord:    TXTRecord{Name: d.TXTRecordName(), Value: d.TXTRecordValue()},
+	}
+}
+
+// RegisterDomainRequest registers a custom domain for a project
+type RegisterDomainRequest struct {
+	Hostname string `json:"hostname"`
+}
+
+// Validate normalizes and checks the hostname
+func (r *RegisterDomainRequest) Validate() error {
+	r.Hostname = NormalizeHostname(r.Hostname)
+	if r.Hostname == "" {
+		return fmt.Errorf("hostname is required")
+	}
+	if !isValidHostname(r.Hostname) {
+		return fmt.Errorf("invalid hostname: must be a domain name such as feedback.example.com")
+	}
+	return nil
+}
+
+// NormalizeHostname lowercases a hostname and strips any port and trailing dot
+func NormalizeHostname(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+var hostnameLabelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func isValidHostname(host string) bool {
+	if len(host) > 253 || net.ParseIP(host) != nil {
+		return false
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !hostnameLabelRegex.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
@@ -23,6 +23,17 @@ var (
 	ErrInviteNotFound   = NewDomainError("invite_not_found", "invite not found", http.StatusNotFound)
 	ErrMemberNotFound   = NewDomainError("member_not_found", "member not found", http.StatusNotFound)
 	ErrAttachmentNotFound = NewDomainError("attachment_not_found", "attachment not found", http.StatusNotFound)
+	ErrSavedViewNotFound  = NewDomainError("saved_view_not_found", "saved view not found", http.StatusNotFound)
+	ErrExportJobNotFound  = NewDomainError("export_job_not_found", "export job not found", http.StatusNotFound)
+	ErrSegmentNotFound    = NewDomainError("segment_not_found", "segment not found", http.StatusNotFound)
+	ErrSDKUserNotFound    = NewDomainError("sdk_user_not_found", "SDK user not found; call identify first", http.StatusNotFound)
+	ErrNotificationNotFound = NewDomainError("notification_not_found", "notification not found", http.StatusNotFound)
+	ErrAPITokenNotFound     = NewDomainError("api_token_not_found", "API token not found", http.StatusNotFound)
+	ErrServiceAccountNotFound = NewDomainError("service_account_not_found", "service account not found", http.StatusNotFound)
+	ErrTransferNotFound       = NewDomainError("transfer_not_found", "no pending ownership transfer", http.StatusNotFound)
+	ErrDomainNotFound         = NewDomainError("domain_not_found", "no custom domain registered", http.StatusNotFound)
+	ErrOrganizationNotFound   = NewDomainError("organization_not_found", "organization not found", http.StatusNotFound)
+	ErrProjectRoleNotFound    = NewDomainError("project_role_not_found", "role not found", http.StatusNotFound)
 
 	// Conflict errors
 	ErrConflict            = NewDomainError("conflict", "resource already exists", http.StatusConflict)
@@ -30,11 +41,16 @@ var (
 	ErrAlreadyVoted        = NewDomainError("already_voted", "user has already voted", http.StatusConflict)
 	ErrSlugTaken           = NewDomainError("slug_taken", "slug is already in use", http.StatusConflict)
 	ErrPendingInviteExists = NewDomainError("pending_invite_exists", "a pending invite already exists for this email", http.StatusConflict)
+	ErrSegmentNameTaken    = NewDomainError("segment_name_taken", "a segment with this name already exists", http.StatusConflict)
+	ErrDomainTaken         = NewDomainError("domain_taken", "this domain is already verified by another project", http.StatusConflict)
+	ErrRoleNameTaken       = NewDomainError("role_name_taken", "a role with this name already exists", http.StatusConflict)
 
 	// Validation errors
 	ErrValidation       = NewDomainError("validation_error", "validation failed", http.StatusBadRequest)
 	ErrInvalidInput     = NewDomainError("invalid_input", "invalid input data", http.StatusBadRequest)
 	ErrMissingField     = NewDomainError("missing_field", "required field is missing", http.StatusBadRequest)
+	ErrInvalidVoter     = NewDomainError("invalid_voter", "a vote needs exactly one voter identity", http.StatusBadRequest)
+	ErrInvalidFollower  = NewDomainError("invalid_follower", "a subscription needs exactly one follower identity", http.StatusBadRequest)
 
 	// Business logic errors
 	ErrInviteExpired    = NewDomainError("invite_expired", "invite has expired", http.StatusGone)
@@ -43,6 +59,14 @@ var (
 	ErrCannotRemoveOwner = NewDomainError("cannot_remove_owner", "cannot remove the project owner", http.StatusForbidden)
 	ErrCannotChangeOwnerRole = NewDomainError("cannot_change_owner_role", "cannot change the owner's role", http.StatusForbidden)
 	ErrNoMembership     = NewDomainError("no_membership", "user is not a member of this project", http.StatusForbidden)
+	ErrVotingDisabled      = NewDomainError("voting_disabled", "voting is disabled for this project", http.StatusForbidden)
+	ErrVoteBudgetExhausted = NewDomainError("vote_budget_exhausted", "no votes left; remove a vote or wait for an item to be resolved", http.StatusConflict)
+	ErrProjectArchived     = NewDomainError("project_archived", "project is archived and read-only", http.StatusConflict)
+	ErrTransferExpired     = NewDomainError("transfer_expired", "ownership transfer has expired", http.StatusGone)
+	ErrOrganizationLimit   = NewDomainError("organization_limit_reached", "the organization has reached its limit", http.StatusConflict)
+	ErrLastOrganizationOwner = NewDomainError("last_organization_owner", "an organization needs at least one owner", http.StatusConflict)
+	ErrDomainNotVerified   = NewDomainError("domain_not_verified", "the verification TXT record was not found", http.StatusUnprocessableEntity)
+	ErrRoleInUse           = NewDomainError("role_in_use", "the role is assigned to members; reassign them first", http.StatusConflict)
 
 	// Rate limiting
 	ErrRateLimited      = NewDomainError("rate_limited", "too many requests", http.StatusTooManyRequests)
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportFormat represents the output format of a feedback export
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// IsValid checks if the export format is valid
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatNDJSON
+}
+
+// ContentType returns the MIME type for the export format
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// ExportJobStatus represents the state of a background export
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// ExportSyncRowLimit is the largest export streamed directly in the request.
+// Anything bigger runs as a background job.
+const ExportSyncRowLimit = 10000
+
+// ExportJob tracks a background feedback export
+type ExportJob struct {
+	ID          uuid.UUID       `json:"id"`
+	ProjectID   uuid.UUID       `json:"project_id"`
+	RequestedBy uuid.UUID       `json:"requested_by"`
+	Format      ExportFormat    `json:"format"`
+	Filter      SavedViewFilter `json:"filter"`
+	Status      ExportJobStatus `json:"status"`
+	RowCount    int             `json:"row_count"`
+	StoragePath *string         `json:"-"`
+	Error       *string         `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+
+	// Computed fields (populated by service layer)
+	DownloadURL *string `json:"download_url,omitempty"`
+}
+
+// IsFinished checks if the job has stopped running
+func (j *ExportJob) IsFinished() bool {
+	return j.Status == ExportJobCompleted || j.Status == ExportJobFailed
+}
+
+// ExportRow is a single flattened feedback record in an export
+type ExportRow struct {
+	ID                  uuid.UUID              `json:"id"`
+	Title               string                 `json:"title"`
+	Description         string                 `json:"description"`
+	Type                FeedbackType           `json:"type"`
+	Status              FeedbackStatus         `json:"status"`
+	Severity            *Severity              `json:"severity,omitempty"`
+	Visibility          Visibility             `json:"visibility"`
+	Tags                []string               `json:"tags"`
+	VoteCount           int                    `json:"vote_count"`
+	CommentCount        int                    `json:"comment_count"`
+	AssignedTo          *uuid.UUID             `json:"assigned_to,omitempty"`
+	Source              *string                `json:"source,omitempty"`
+	SubmitterEmail      *string                `json:"submitter_email,omitempty"`
+	SubmitterName       *string                `json:"submitter_name,omitempty"`
+	SubmitterIdentifier *string                `json:"submitter_identifier,omitempty"`
+	SDKUserID           *uuid.UUID             `json:"sdk_user_id,omitempty"`
+	SDKUserExternalID   *string                `json:"sdk_user_external_id,omitempty"`
+	SDKUserEmail        *string                `json:"sdk_user_email,omitempty"`
+	SDKUserName         *string                `json:"sdk_user_name,omitempty"`
+	SDKUserTraits       map[string]interface{} `json:"sdk_user_traits,omitempty"`
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt           time.Time              `json:"created_at"`
+	UpdatedAt           time.Time              `json:"updated_at"`
+	ResolvedAt          *time.Time             `json:"resolved_at,omitempty"`
+}
@@ -107,6 +107,7 @@ type Feedback struct {
 	Visibility  Visibility     `json:"visibility"`
 
 	VoteCount    int `json:"vote_count"`
+	VoteScore    int `json:"vote_score"` // Votes weighted by importance
 	CommentCount int `json:"comment_count"`
 
 	// Anonymous submitter info
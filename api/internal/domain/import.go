@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxImportItems is the largest number of rows accepted in a single import
+const MaxImportItems = 10000
+
+// ImportRequest is the JSON import document.
+//
+//	{
+//	  "source": "canny",
+//	  "items": [{
+//	    "source_id": "abc123",
+//	    "title": "Dark mode",
+//	    "description": "Please add a dark theme",
+//	    "type": "feature",
+//	    "status": "planned",
+//	    "visibility": "COMMUNITY",
+//	    "tags": ["ui", "themes"],
+//	    "vote_count": 42,
+//	    "submitter": {"external_id": "user-1", "email": "a@example.com", "name": "Ada"},
+//	    "comments": [{"source_id": "c1", "body": "+1", "author_name": "Bob"}],
+//	    "metadata": {"url": "https://old.tool/p/abc123"},
+//	    "created_at": "2024-01-31T12:00:00Z"
+//	  }]
+//	}
+//
+// Items are matched on (source, source_id), so re-running the same import
+// skips rows that were already created.
+type ImportRequest struct {
+	Source string       `json:"source"`
+	Items  []ImportItem `json:"items"`
+}
+
+// ImportItem is a single feedback record to import
+type ImportItem struct {
+	SourceID    string                 `json:"source_id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Type        FeedbackType           `json:"type,omitempty"`
+	Status      FeedbackStatus         `json:"status,omitempty"`
+	Visibility  Visibility             `json:"visibility,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	VoteCount   int                    `json:"vote_count,omitempty"`
+	Submitter   *ImportSubmitter       `json:"submitter,omitempty"`
+	Comments    []ImportComment        `json:"comments,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt   *time.Time             `json:"created_at,omitempty"`
+}
+
+// ImportSubmitter identifies who originally submitted an item. Submitters
+// are deduplicated into sdk_users by external ID, then by email.
+type ImportSubmitter struct {
+	ExternalID string `json:"external_id,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+// ImportComment is a comment attached to an imported item
+type ImportComment struct {
+	SourceID   string     `json:"source_id,omitempty"`
+	Body       string     `json:"body"`
+	AuthorName string     `json:"author_name,omitempty"`
+	Visibility Visibility `json:"visibility,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+// ImportColumnMapping maps import fields to CSV column headers
+type ImportColumnMapping struct {
+	SourceID            string `json:"source_id"`
+	Title               string `json:"title"`
+	Description         string `json:"description"`
+	Type                string `json:"type,omitempty"`
+	Status              string `json:"status,omitempty"`
+	Visibility          string `json:"visibility,omitempty"`
+	Tags                string `json:"tags,omitempty"`
+	VoteCount           string `json:"vote_count,omitempty"`
+	SubmitterExternalID string `json:"submitter_external_id,omitempty"`
+	SubmitterEmail      string `json:"submitter_email,omitempty"`
+	SubmitterName       string `json:"submitter_name,omitempty"`
+	CreatedAt           string `json:"created_at,omitempty"`
+
+	// TagSeparator splits the tags column; defaults to a comma
+	TagSeparator string `json:"tag_separator,omitempty"`
+}
+
+// Validate checks that the required columns are mapped
+func (m *ImportColumnMapping) Validate() error {
+	if m.SourceID == "" {
+		return fmt.Errorf("source_id column mapping is required")
+	}
+	if m.Title == "" {
+		return fmt.Errorf("title column mapping is required")
+	}
+	if m.Description == "" {
+		return fmt.Errorf("description column mapping is required")
+	}
+	return nil
+}
+
+// ImportRowStatus is the outcome of importing a single row
+type ImportRowStatus string
+
+const (
+	ImportRowValid   ImportRowStatus = "valid"   // Dry run: would be created
+	ImportRowCreated ImportRowStatus = "created" // Feedback was created
+	ImportRowSkipped ImportRowStatus = "skipped" // Already imported from this source
+	ImportRowInvalid ImportRowStatus = "invalid" // Failed validation
+	ImportRowFailed  ImportRowStatus = "failed"  // Failed while writing
+)
+
+// ImportRowResult reports what happened to one row
+type ImportRowResult struct {
+	Row        int             `json:"row"` // 1-based position in the input
+	SourceID   string          `json:"source_id,omitempty"`
+	Status     ImportRowStatus `json:"status"`
+	FeedbackID *uuid.UUID      `json:"feedback_id,omitempty"`
+	Errors     []string        `json:"errors,omitempty"`
+}
+
+// ImportResult summarizes an import run
+type ImportResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Invalid int               `json:"invalid"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// Add records a row result and updates the totals
+func (r *ImportResult) Add(row ImportRowResult) {
+	r.Total++
+	switch row.Status {
+	case ImportRowCreated:
+		r.Created++
+	case ImportRowSkipped:
+		r.Skipped++
+	case ImportRowInvalid:
+		r.Invalid++
+	case ImportRowFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+var importSourceRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// ValidateImportSource checks the import source name
+func ValidateImportSource(source string) error {
+	if !importSourceRegex.MatchString(source) {
+		return fmt.Errorf("source must be 1-50 lowercase letters, digits, hyphens or underscores")
+	}
+	return nil
+}
+
+// Normalize trims values and fills defaults that don't depend on the project
+func (i *ImportItem) Normalize() {
+	i.SourceID = strings.TrimSpace(i.SourceID)
+	i.Title = strings.TrimSpace(i.Title)
+	i.Description = strings.TrimSpace(i.Description)
+	if i.Type == "" {
+		i.Type = FeedbackTypeFeature
+	}
+	if i.Status == "" {
+		i.Status = StatusNew
+	}
+
+	tags := i.Tags[:0]
+	for _, t := range i.Tags {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	i.Tags = tags
+
+	if i.Submitter != nil {
+		i.Submitter.ExternalID = strings.TrimSpace(i.Submitter.ExternalID)
+		i.Submitter.Email = strings.ToLower(strings.TrimSpace(i.Submitter.Email))
+		i.Submitter.Name = strings.TrimSpace(i.Submitter.Name)
+		if i.Submitter.ExternalID == "" && i.Submitter.Email == "" {
+			i.Submitter = nil
+		}
+	}
+}
+
+// Validate returns every problem with the item rather than stopping at the first
+func (i *ImportItem) Validate() []string {
+	var errs []string
+	if i.SourceID == "" {
+		errs = append(errs, "source_id is required")
+	} else if len(i.SourceID) > 255 {
+		errs = append(errs, "source_id must be 255 characters or less")
+	}
+	if i.Title == "" {
+		errs = append(errs, "title is required")
+	} else if len(i.Title) > 200 {
+		errs = append(errs, "title must be 200 characters or less")
+	}
+	if i.Description == "" {
+		errs = append(errs, "description is required")
+	}
+	if !i.Type.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid feedback type: %s", i.Type))
+	}
+	if !i.Status.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid feedback status: %s", i.Status))
+	}
+	if i.Visibility != "" && !i.Visibility.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid visibility: %s", i.Visibility))
+	}
+	if i.VoteCount < 0 {
+		errs = append(errs, "vote_count cannot be negative")
+	}
+	for _, t := range i.Tags {
+		if len(t) > 50 {
+			errs = append(errs, fmt.Sprintf("tag %q must be 50 characters or less", t))
+		} else if GenerateSlug(t) == "" {
+			errs = append(errs, fmt.Sprintf("tag %q has no usable characters", t))
+		}
+	}
+	for n, c := range i.Comments {
+		if strings.TrimSpace(c.Body) == "" {
+			errs = append(errs, fmt.Sprintf("comment %d: body is required", n+1))
+		}
+		if c.Visibility != "" && !c.Visibility.IsValid() {
+			errs = append(errs, fmt.Sprintf("comment %d: invalid visibility: %s", n+1, c.Visibility))
+		}
+	}
+	return errs
+}
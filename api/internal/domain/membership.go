@@ -15,6 +15,13 @@ type Membership struct {
 	DisplayName *string    `json:"display_name,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+	// Inherited is set when the role comes from an organization membership
+	// rather than a project membership
+	Inherited bool `json:"inherited,omitempty"`
+	// CustomRoleID is set when the member has a custom role, whose
+	// permissions replace the built-in role's defaults
+	CustomRoleID *uuid.UUID   `json:"custom_role_id,omitempty"`
+	CustomRole   *ProjectRole `json:"custom_role,omitempty"`
 
 	// Relationships (populated by service layer)
 	Project *Project `json:"project,omitempty"`
@@ -26,24 +33,51 @@ func (m *Membership) CanViewTeamOnlyContent() bool {
 	return m.Role.IsTeamRole()
 }
 
+// MentionHandle returns the normalized handle the member is @mentioned by,
+// or "" if they have no display name
+func (m *Membership) MentionHandle() string {
+	if m.DisplayName == nil {
+		return ""
+	}
+	return NormalizeMentionHandle(*m.DisplayName)
+}
+
+// Permissions returns the member's effective permissions: the custom
+// role's if one is assigned, otherwise the built-in role's defaults.
+// Owners always have every permission.
+func (m *Membership) Permissions() []Permission {
+	if m.CustomRole != nil && !m.Role.IsOwner() {
+		return m.CustomRole.Permissions
+	}
+	return m.Role.Permissions()
+}
+
+// HasPermission checks if the member's effective permissions include p
+func (m *Membership) HasPermission(p Permission) bool {
+	if m.CustomRole != nil && !m.Role.IsOwner() {
+		return m.CustomRole.HasPermission(p)
+	}
+	return m.Role.HasPermission(p)
+}
+
 // CanModifyFeedback returns true if the member can modify feedback
 func (m *Membership) CanModifyFeedback() bool {
-	return m.Role.CanModify()
+	return m.HasPermission(PermissionFeedbackTriage)
 }
 
 // CanManageMembers returns true if the member can manage other members
 func (m *Membership) CanManageMembers() bool {
-	return m.Role.IsAdminOrOwner()
+	return m.HasPermission(PermissionMembersManage)
 }
 
 // CanManageSettings returns true if the member can manage project settings
 func (m *Membership) CanManageSettings() bool {
-	return m.Role.IsAdminOrOwner()
+	return m.HasPermission(PermissionSettingsEdit)
 }
 
 // CanDeleteProject returns true if the member can delete the project
 func (m *Membership) CanDeleteProject() bool {
-	return m.Role.IsOwner()
+	return m.HasPermission(PermissionProjectManage)
 }
 
 // HasRoleAtLeast checks if the member has at least the given role level
@@ -61,6 +95,43 @@ func (m *Membership) CanManageRole(targetRole Role) bool {
 	return m.Role.Level() > targetRole.Level()
 }
 
+// CanAssignRole checks if the member can give target a custom role, or
+// clear it when role is nil. Owners can't be given custom roles, members
+// can only be managed by someone with a higher built-in role, and a custom
+// role can't grant permissions the assigner lacks.
+func (m *Membership) CanAssignRole(target *Membership, role *ProjectRole) bool {
+	if !m.HasPermission(PermissionMembersManage) {
+		return false
+	}
+	if !target.Role.IsTeamRole() || target.Role.IsOwner() {
+		return false
+	}
+	if !m.Role.IsOwner() && target.Role.Level() >= m.Role.Level() {
+		return false
+	}
+	return role == nil || m.HasPermissions(role.Permissions)
+}
+
+// CanGrantRole checks if the member can give a new principal, such as a
+// service account, the built-in role. Like CanAssignRole, non-owners can
+// only grant roles below their own and never permissions they lack.
+func (m *Membership) CanGrantRole(role Role) bool {
+	if !m.Role.IsOwner() && role.Level() >= m.Role.Level() {
+		return false
+	}
+	return m.HasPermissions(role.Permissions())
+}
+
+// HasPermissions checks if the member has every permission in ps
+func (m *Membership) HasPermissions(ps []Permission) bool {
+	for _, p := range ps {
+		if !m.HasPermission(p) {
+			return false
+		}
+	}
+	return true
+}
+
 // MembershipWithUser includes user details for display
 type MembershipWithUser struct {
 	Membership
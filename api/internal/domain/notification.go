@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is an in-app notification in a user's inbox for a project
+type Notification struct {
+	ID               uuid.UUID              `json:"id"`
+	UserID           uuid.UUID              `json:"user_id"`
+	ProjectID        uuid.UUID              `json:"project_id"`
+	FeedbackID       *uuid.UUID             `json:"feedback_id,omitempty"`
+	EventID          *uuid.UUID             `json:"event_id,omitempty"`
+	NotificationType string                 `json:"notification_type"`
+	Payload          map[string]interface{} `json:"payload"`
+	ReadAt           *time.Time             `json:"read_at,omitempty"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// MemberRecipient is a project member an event is addressed to directly,
+// such as the member mentioned or assigned, resolved when the event is
+// fanned out
+type MemberRecipient struct {
+	UserID      uuid.UUID
+	Role        *Role // Nil if they are no longer a member of the project
+	Preferences NotificationPreferences
+}
+
+// CanSee returns true if the recipient may still see the change and the
+// item it is on
+func (m MemberRecipient) CanSee(e *NotificationEvent) bool {
+	if m.Role == nil {
+		return false
+	}
+	return e.Visibility.IsVisibleTo(*m.Role) && e.FeedbackVisibility.IsVisibleTo(*m.Role)
+}
+
+// ViewWatcher is the owner of a saved view with notifications on that new
+// feedback matched
+type ViewWatcher struct {
+	MemberRecipient
+	ViewID   uuid.UUID
+	ViewName string
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is a workspace that owns projects. Its members' roles apply
+// to every project it owns unless a project membership overrides them.
+type Organization struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+	// Limits; nil means unlimited
+	MaxProjects *int      `json:"max_projects,omitempty"`
+	MaxMembers  *int      `json:"max_members,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OrganizationWithRole is an organization together with the caller's role
+type OrganizationWithRole struct {
+	Organization
+	Role Role `json:"role"`
+}
+
+// OrganizationUsage counts what the organization's limits apply to
+type OrganizationUsage struct {
+	Projects int `json:"projects"`
+	Members  int `json:"members"`
+}
+
+// OrganizationDetail is an organization with its current usage
+type OrganizationDetail struct {
+	Organization
+	Usage OrganizationUsage `json:"usage"`
+}
+
+// OrganizationMember is a user's membership in an organization. Roles are
+// team roles; community members belong to single projects.
+type OrganizationMember struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Role           Role      `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ProjectMembership returns the membership the organization role grants in
+// one of the organization's projects
+func (m *OrganizationMember) ProjectMembership(projectID uuid.UUID) *Membership {
+	return &Membership{
+		ProjectID: projectID,
+		UserID:    m.UserID,
+		Role:      m.Role,
+		Inherited: true,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// CanManageRole checks if the member can grant, change or revoke the given
+// role. Owners manage every role; admins manage roles below their own.
+func (m *OrganizationMember) CanManageRole(targetRole Role) bool {
+	if m.Role.IsOwner() {
+		return true
+	}
+	return m.Role.IsAdminOrOwner() && m.Role.Level() > targetRole.Level()
+}
+
+// CreateOrganizationRequest creates an organization owned by the caller
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// Validate validates the request
+func (r *CreateOrganizationRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return validateOrganizationName(r.Name)
+}
+
+// UpdateOrganizationRequest renames an organization
+type UpdateOrganizationRequest struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Validate validates the request
+func (r *UpdateOrganizationRequest) Validate() error {
+	if r.Name == nil {
+		return nil
+	}
+	name := strings.TrimSpace(*r.Name)
+	r.Name = &name
+	return validateOrganizationName(name)
+}
+
+// OrganizationMemberRequest adds a user to an organization or changes their role
+type OrganizationMemberRequest struct {
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	Role   Role      `json:"role"`
+}
+
+// Validate validates the request; the user ID is only required when adding
+func (r *OrganizationMemberRequest) Validate(adding bool) error {
+	if adding && r.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	if !r.Role.IsTeamRole() {
+		return fmt.Errorf("role must be one of viewer, member, admin or owner")
+	}
+	return nil
+}
+
+// OrganizationSearchResult is a feedback item found by cross-project search
+type OrganizationSearchResult struct {
+	FeedbackID  uuid.UUID      `json:"feedback_id"`
+	ProjectID   uuid.UUID      `json:"project_id"`
+	ProjectName string         `json:"project_name"`
+	Title       string         `json:"title"`
+	Type        FeedbackType   `json:"type"`
+	Status      FeedbackStatus `json:"status"`
+	VoteCount   int            `json:"vote_count"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+func validateOrganizationName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > 100 {
+		return fmt.Errorf("name must be 100 characters or less")
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permission is a single capability within a project. Routes and handlers
+// check permissions rather than role levels.
+type Permission string
+
+const (
+	// Read feedback, analytics, members and settings
+	PermissionFeedbackView Permission = "feedback.view"
+	// Submit feedback, team notes and votes on behalf of users, and import
+	PermissionFeedbackCreate Permission = "feedback.create"
+	// Change status, merge and tag feedback; manage segments and saved views
+	PermissionFeedbackTriage Permission = "feedback.triage"
+	// Delete any feedback; authors may always delete their own
+	PermissionFeedbackDelete Permission = "feedback.delete"
+	// Delete any comment; authors may always delete their own
+	PermissionCommentsModerate Permission = "comments.moderate"
+	// Download exports
+	PermissionExport Permission = "export"
+	// Edit settings, branding and the custom domain
+	PermissionSettingsEdit Permission = "settings.edit"
+	// Add and remove members and manage custom roles
+	PermissionMembersManage Permission = "members.manage"
+	// Manage SDK tokens and service accounts
+	PermissionTokensManage Permission = "tokens.manage"
+	// Archive, restore, delete and transfer the project; owners only
+	PermissionProjectManage Permission = "project.manage"
+)
+
+// RolePermissions are the default permission sets of the built-in roles.
+// Community members have no team permissions; the community routes only
+// require a membership.
+var RolePermissions = map[Role][]Permission{
+	RoleCommunity: {},
+	RoleViewer: {
+		PermissionFeedbackView,
+		PermissionExport,
+	},
+	RoleMember: {
+		PermissionFeedbackView,
+		PermissionExport,
+		PermissionFeedbackCreate,
+		PermissionFeedbackTriage,
+	},
+	RoleAdmin: {
+		PermissionFeedbackView,
+		PermissionExport,
+		PermissionFeedbackCreate,
+		PermissionFeedbackTriage,
+		PermissionFeedbackDelete,
+		PermissionCommentsModerate,
+		PermissionSettingsEdit,
+		PermissionMembersManage,
+		PermissionTokensManage,
+	},
+	RoleOwner: AllPermissions(),
+}
+
+// AllPermissions returns every permission
+func AllPermissions() []Permission {
+	return []Permission{
+		PermissionFeedbackView,
+		PermissionFeedbackCreate,
+		PermissionFeedbackTriage,
+		PermissionFeedbackDelete,
+		PermissionCommentsModerate,
+		PermissionExport,
+		PermissionSettingsEdit,
+		PermissionMembersManage,
+		PermissionTokensManage,
+		PermissionProjectManage,
+	}
+}
+
+// IsValid checks if the permission is known
+func (p Permission) IsValid() bool {
+	return slices.Contains(AllPermissions(), p)
+}
+
+// IsAssignable checks if the permission may be granted by a custom role.
+// Managing the project itself stays with its owner.
+func (p Permission) IsAssignable() bool {
+	return p.IsValid() && p != PermissionProjectManage
+}
+
+// Permissions returns the role's default permissions
+func (r Role) Permissions() []Permission {
+	return RolePermissions[r]
+}
+
+// HasPermission checks if the role's default permissions include p
+func (r Role) HasPermission(p Permission) bool {
+	return slices.Contains(RolePermissions[r], p)
+}
+
+// Custom role limits
+const (
+	MaxProjectRoleNameLength        = 50
+	MaxProjectRoleDescriptionLength = 500
+)
+
+// ProjectRole is a custom role defined by a project's admins. Members
+// assigned a custom role get its permissions instead of their built-in
+// role's defaults.
+type ProjectRole struct {
+	ID          uuid.UUID    `json:"id"`
+	ProjectID   uuid.UUID    `json:"project_id"`
+	Name        string       `json:"name"`
+	Description *string      `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// HasPermission checks if the custom role grants p
+func (r *ProjectRole) HasPermission(p Permission) bool {
+	return slices.Contains(r.Permissions, p)
+}
+
+// BuiltInRolePermissions describes a built-in role's default permissions
+type BuiltInRolePermissions struct {
+	Role        Role         `json:"role"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// ProjectRolesResponse lists the built-in and custom roles of a project
+type ProjectRolesResponse struct {
+	BuiltIn []BuiltInRolePermissions `json:"built_in"`
+	Custom  []ProjectRole            `json:"custom"`
+}
+
+// NewProjectRolesResponse builds the response for a project's custom roles
+func NewProjectRolesResponse(custom []ProjectRole) ProjectRolesResponse {
+	resp := ProjectRolesResponse{Custom: custom}
+	for _, role := range TeamRoles() {
+		resp.BuiltIn = append(resp.BuiltIn, BuiltInRolePermissions{Role: role, Permissions: role.Permissions()})
+	}
+	return resp
+}
+
+// ProjectRoleRequest creates or updates a custom role
+type ProjectRoleRequest struct {
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// Validate validates the request; name and permissions are required when
+// creating. Every custom role can view feedback, so that permission is
+// added if missing.
+func (r *ProjectRoleRequest) Validate(creating bool) error {
+	if r.Name != nil {
+		name := strings.TrimSpace(*r.Name)
+		r.Name = &name
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+		if len(name) > MaxProjectRoleNameLength {
+			return fmt.Errorf("name must be %d characters or less", MaxProjectRoleNameLength)
+		}
+		if Role(strings.ToLower(name)).IsValid() {
+			return fmt.Errorf("name %q is reserved for a built-in role", name)
+		}
+	} else if creating {
+		return fmt.Errorf("name is required")
+	}
+
+	if r.Description != nil && len(*r.Description) > MaxProjectRoleDescriptionLength {
+		return fmt.Errorf("description must be %d characters or less", MaxProjectRoleDescriptionLength)
+	}
+
+	if r.Permissions == nil {
+		if creating {
+			return fmt.Errorf("permissions are required")
+		}
+		return nil
+	}
+	seen := make(map[Permission]bool)
+	permissions := []Permission{PermissionFeedbackView}
+	seen[PermissionFeedbackView] = true
+	for _, p := range r.Permissions {
+		if !p.IsAssignable() {
+			return fmt.Errorf("permission %q cannot be granted by a custom role", p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+	r.Permissions = permissions
+	return nil
+}
+
+// Apply applies the request to the role
+func (r *ProjectRoleRequest) Apply(role *ProjectRole) {
+	if r.Name != nil {
+		role.Name = *r.Name
+	}
+	if r.Description != nil {
+		role.Description = r.Description
+	}
+	if r.Permissions != nil {
+		role.Permissions = r.Permissions
+	}
+}
+
+// AssignProjectRoleRequest assigns a custom role to a member, or clears it
+// so the member's built-in role applies again
+type AssignProjectRoleRequest struct {
+	CustomRoleID *uuid.UUID `json:"custom_role_id"`
+}
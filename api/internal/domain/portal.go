@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,7 +18,10 @@ type PortalUserProfile struct {
 	UpdatedAt               time.Time                     `json:"updated_at"`
 }
 
-// PortalNotificationPreferences defines portal user notification settings
+// PortalNotificationPreferences defines portal user notification settings.
+// They apply to items the user follows; NewCommentsOnMyFeedback covers items
+// they submitted and NewCommentsOnVotedFeedback items they follow because
+// they voted.
 type PortalNotificationPreferences struct {
 	StatusChanges              bool `json:"status_changes"`
 	NewCommentsOnMyFeedback    bool `json:"new_comments_on_my_feedback"`
@@ -41,11 +46,14 @@ type PortalVote struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
-// NotificationQueueEntry represents a pending notification
+// NotificationQueueEntry represents a pending notification. It goes to a
+// Supabase user or, for SDK users without an account, to an SDK user.
 type NotificationQueueEntry struct {
 	ID               uuid.UUID              `json:"id"`
-	UserID           uuid.UUID              `json:"user_id"`
+	UserID           *uuid.UUID             `json:"user_id,omitempty"`
+	SDKUserID        *uuid.UUID             `json:"sdk_user_id,omitempty"`
 	ProjectID        uuid.UUID              `json:"project_id"`
+	EventID          *uuid.UUID             `json:"event_id,omitempty"`
 	NotificationType string                 `json:"notification_type"`
 	Payload          map[string]interface{} `json:"payload"`
 	SentAt           *time.Time             `json:"sent_at,omitempty"`
@@ -61,6 +69,9 @@ const (
 	NotificationTypeNewComment       = "new_comment"
 	NotificationTypeFeedbackResolved = "feedback_resolved"
 	NotificationTypeWeeklyDigest     = "weekly_digest"
+	NotificationTypeMention          = "mention"
+	NotificationTypeAssigned         = "assigned"
+	NotificationTypeSavedViewMatch   = "saved_view_match"
 )
 
 // PortalFeedbackSummary is a lightweight feedback representation for portal users
@@ -76,3 +87,101 @@ type PortalFeedbackSummary struct {
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
+
+// MaxPortalCommentLength bounds the body of a portal comment
+const MaxPortalCommentLength = 10000
+
+// PortalSubmission is feedback submitted by a portal user. Email and Name
+// are only used for anonymous submissions.
+type PortalSubmission struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Type        FeedbackType `json:"type"`
+	Email       *string      `json:"email,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+}
+
+// Validate checks the submission against the project's settings
+func (s *PortalSubmission) Validate(settings ProjectSettings, anonymous bool) error {
+	s.Title = strings.TrimSpace(s.Title)
+	s.Description = strings.TrimSpace(s.Description)
+	if s.Type == "" {
+		s.Type = settings.Branding.DefaultType()
+	}
+
+	if s.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(s.Title) > 200 {
+		return fmt.Errorf("title must be 200 characters or less")
+	}
+	if s.Description == "" {
+		return fmt.Errorf("description is required")
+	}
+	if !s.Type.IsValid() {
+		return fmt.Errorf("invalid feedback type: %s", s.Type)
+	}
+	if !settings.Branding.Accepts(s.Type) {
+		return fmt.Errorf("this portal does not accept %s feedback", s.Type)
+	}
+
+	if anonymous {
+		if s.Email != nil {
+			email := strings.TrimSpace(*s.Email)
+			s.Email = &email
+		}
+		if settings.RequireEmailForAnonymous && (s.Email == nil || *s.Email == "") {
+			return fmt.Errorf("email is required")
+		}
+		if s.Email != nil && *s.Email != "" && !strings.Contains(*s.Email, "@") {
+			return fmt.Errorf("email is invalid")
+		}
+	}
+	return nil
+}
+
+// PortalFeedbackDetail is a single item as shown to portal users
+type PortalFeedbackDetail struct {
+	PortalFeedbackSummary
+	Visibility    Visibility         `json:"visibility"`
+	IsMine        bool               `json:"is_mine"`
+	MergedInto    *uuid.UUID         `json:"merged_into,omitempty"`
+	Comments      []PortalComment    `json:"comments"`
+	Attachments   []PortalAttachment `json:"attachments"`
+	StatusHistory []StatusChange     `json:"status_history"`
+}
+
+// IsVisibleToPortal returns true if portal users may open the item. Team-only
+// items are visible only to the user who submitted them.
+func (d *PortalFeedbackDetail) IsVisibleToPortal() bool {
+	return d.Visibility == VisibilityCommunity || d.IsMine
+}
+
+// PortalComment is a COMMUNITY comment as shown to portal users
+type PortalComment struct {
+	ID         uuid.UUID `json:"id"`
+	Body       string    `json:"body"`
+	AuthorName *string   `json:"author_name,omitempty"`
+	IsTeam     bool      `json:"is_team"`
+	IsMine     bool      `json:"is_mine"`
+	IsEdited   bool      `json:"is_edited"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PortalAttachment is an uploaded file on an item
+type PortalAttachment struct {
+	ID          uuid.UUID `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	StoragePath string    `json:"-"`
+	DownloadURL string    `json:"download_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// StatusChange is one entry of an item's status history
+type StatusChange struct {
+	From      *FeedbackStatus `json:"from,omitempty"`
+	To        FeedbackStatus  `json:"to"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
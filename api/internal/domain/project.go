@@ -21,6 +21,10 @@ type Project struct {
 	CreatedAt  time.Time       `json:"created_at"`
 	UpdatedAt  time.Time       `json:"updated_at"`
 	ArchivedAt *time.Time      `json:"archived_at,omitempty"`
+	// Set when the project is deleted; it is purged at this time unless restored
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	// The organization that owns the project
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
 }
 
 // ProjectSettings holds configurable settings for a project
@@ -32,6 +36,10 @@ type ProjectSettings struct {
 	CommunityCommentsEnabled bool                     `json:"community_comments_enabled"`
 	AutoCloseDuplicates     bool                      `json:"auto_close_duplicates"`
 	NotificationPreferences NotificationPreferences   `json:"notification_preferences"`
+	Prioritization          PrioritizationSettings    `json:"prioritization"`
+	// VoteBudget caps each voter's active votes; 0 means unlimited
+	VoteBudget int             `json:"vote_budget"`
+	Branding   ProjectBranding `json:"branding"`
 }
 
 // DefaultVisibilitySettings defines default visibility per feedback type
@@ -41,11 +49,76 @@ type DefaultVisibilitySettings struct {
 	General Visibility `json:"general"`
 }
 
-// NotificationPreferences defines notification settings
+// NotificationPreferences defines team notification settings. Project
+// settings hold the defaults; a team member may override them for
+// themselves. The top-level switches choose what is emailed, Inbox what
+// reaches the in-app inbox.
 type NotificationPreferences struct {
-	NewFeedback   bool `json:"new_feedback"`
-	StatusChanges bool `json:"status_changes"`
-	NewComments   bool `json:"new_comments"`
+	NewFeedback      bool             `json:"new_feedback"`
+	StatusChanges    bool             `json:"status_changes"`
+	NewComments      bool             `json:"new_comments"`
+	Mentions         bool             `json:"mentions"`
+	Assignments      bool             `json:"assignments"`
+	SavedViewMatches bool             `json:"saved_view_matches"`
+	Inbox            InboxPreferences `json:"inbox"`
+}
+
+// InboxPreferences chooses which notification types reach the in-app inbox
+type InboxPreferences struct {
+	Mentions         bool `json:"mentions"`
+	Assignments      bool `json:"assignments"`
+	StatusChanges    bool `json:"status_changes"`
+	SavedViewMatches bool `json:"saved_view_matches"`
+}
+
+// DefaultNotificationPreferences returns the default team notification preferences
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		NewFeedback:      true,
+		StatusChanges:    true,
+		NewComments:      true,
+		Mentions:         true,
+		Assignments:      true,
+		SavedViewMatches: false,
+		Inbox: InboxPreferences{
+			Mentions:         true,
+			Assignments:      true,
+			StatusChanges:    true,
+			SavedViewMatches: true,
+		},
+	}
+}
+
+// EmailsFor returns true if notifications of the given type are emailed
+func (p NotificationPreferences) EmailsFor(notificationType string) bool {
+	switch notificationType {
+	case NotificationTypeStatusChanged, NotificationTypeFeedbackResolved:
+		return p.StatusChanges
+	case NotificationTypeNewComment:
+		return p.NewComments
+	case NotificationTypeMention:
+		return p.Mentions
+	case NotificationTypeAssigned:
+		return p.Assignments
+	case NotificationTypeSavedViewMatch:
+		return p.SavedViewMatches
+	}
+	return false
+}
+
+// InboxFor returns true if notifications of the given type reach the inbox
+func (p NotificationPreferences) InboxFor(notificationType string) bool {
+	switch notificationType {
+	case NotificationTypeStatusChanged, NotificationTypeFeedbackResolved:
+		return p.Inbox.StatusChanges
+	case NotificationTypeMention:
+		return p.Inbox.Mentions
+	case NotificationTypeAssigned:
+		return p.Inbox.Assignments
+	case NotificationTypeSavedViewMatch:
+		return p.Inbox.SavedViewMatches
+	}
+	return false
 }
 
 // DefaultProjectSettings returns the default settings for a new project
@@ -61,11 +134,11 @@ func DefaultProjectSettings() ProjectSettings {
 		VotingEnabled:            true,
 		CommunityCommentsEnabled: true,
 		AutoCloseDuplicates:      true,
-		NotificationPreferences: NotificationPreferences{
-			NewFeedback:   true,
-			StatusChanges: true,
-			NewComments:   true,
+		NotificationPreferences:  DefaultNotificationPreferences(),
+		Prioritization: PrioritizationSettings{
+			DefaultWeight: 1,
 		},
+		Branding: DefaultProjectBranding(),
 	}
 }
 
@@ -89,6 +162,8 @@ func (s *ProjectSettings) Scan(src interface{}) error {
 		*s = DefaultProjectSettings()
 		return nil
 	}
+	// Settings stored before a field existed keep its default
+	*s = DefaultProjectSettings()
 	switch v := src.(type) {
 	case []byte:
 		return json.Unmarshal(v, s)
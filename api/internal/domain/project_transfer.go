@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectTransferExpiry is how long the new owner has to accept a transfer
+const ProjectTransferExpiry = 7 * 24 * time.Hour
+
+// DefaultProjectDeletionGrace is how long a deleted project can still be
+// restored before it is purged
+const DefaultProjectDeletionGrace = 30 * 24 * time.Hour
+
+// ProjectTransfer is a pending or completed handover of project ownership.
+// The current owner proposes it; it takes effect when the new owner accepts.
+type ProjectTransfer struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	FromUserID  uuid.UUID  `json:"from_user_id"`
+	ToUserID    uuid.UUID  `json:"to_user_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// IsExpired reports whether the transfer can no longer be accepted
+func (t *ProjectTransfer) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// TransferProjectRequest names the team member who should become owner
+type TransferProjectRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// Validate validates the transfer request
+func (r *TransferProjectRequest) Validate() error {
+	if r.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
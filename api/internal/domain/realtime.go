@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Realtime event types streamed to SSE clients
+const (
+	RealtimeFeedbackCreated = "feedback_created"
+	RealtimeStatusChanged   = "status_changed"
+	RealtimeVotesChanged    = "votes_changed"
+	RealtimeCommentCreated  = "comment_created"
+
+	// RealtimeReset tells a resuming client that events were missed and it
+	// should reload instead
+	RealtimeReset = "reset"
+)
+
+// RealtimeEvent is a live change on a project, recorded by database
+// triggers and announced to every API instance with NOTIFY
+type RealtimeEvent struct {
+	ID         int64                  `json:"id"`
+	ProjectID  uuid.UUID              `json:"project_id"`
+	FeedbackID *uuid.UUID             `json:"feedback_id,omitempty"`
+	EventType  string                 `json:"event_type"`
+	Visibility Visibility             `json:"visibility"` // TEAM_ONLY if the change or its item is
+	Payload    map[string]interface{} `json:"payload"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// IsVisibleTo returns true if the event may be streamed to the audience:
+// team members see everything, everyone else COMMUNITY changes only
+func (e *RealtimeEvent) IsVisibleTo(isTeam bool) bool {
+	return isTeam || e.Visibility == VisibilityCommunity
+}
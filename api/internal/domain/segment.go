@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SegmentMatch controls how a segment's rules combine
+type SegmentMatch string
+
+const (
+	SegmentMatchAll SegmentMatch = "all"
+	SegmentMatchAny SegmentMatch = "any"
+)
+
+// SegmentOperator compares an SDK user trait against a rule value
+type SegmentOperator string
+
+const (
+	OpEquals      SegmentOperator = "eq"
+	OpNotEquals   SegmentOperator = "neq"
+	OpGreaterThan SegmentOperator = "gt"
+	OpGreaterOrEq SegmentOperator = "gte"
+	OpLessThan    SegmentOperator = "lt"
+	OpLessOrEq    SegmentOperator = "lte"
+	OpContains    SegmentOperator = "contains"
+	OpIn          SegmentOperator = "in"
+	OpExists      SegmentOperator = "exists"
+)
+
+// IsNumeric returns true if the operator compares numbers
+func (o SegmentOperator) IsNumeric() bool {
+	switch o {
+	case OpGreaterThan, OpGreaterOrEq, OpLessThan, OpLessOrEq:
+		return true
+	}
+	return false
+}
+
+// Segment is a saved group of SDK users defined by rules over their traits
+type Segment struct {
+	ID          uuid.UUID     `json:"id"`
+	ProjectID   uuid.UUID     `json:"project_id"`
+	Name        string        `json:"name"`
+	Description *string       `json:"description,omitempty"`
+	Match       SegmentMatch  `json:"match"`
+	Rules       []SegmentRule `json:"rules"`
+	CreatedBy   uuid.UUID     `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+
+	// Computed fields (populated by service layer)
+	UserCount *int `json:"user_count,omitempty"`
+}
+
+// SegmentRule matches a single trait, e.g. {"trait": "mrr", "op": "gt", "value": 500}
+type SegmentRule struct {
+	Trait string          `json:"trait"`
+	Op    SegmentOperator `json:"op"`
+	Value interface{}     `json:"value,omitempty"`
+}
+
+// Validate validates the segment data
+func (s *Segment) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(s.Name) > 100 {
+		return fmt.Errorf("name must be 100 characters or less")
+	}
+	if s.Match == "" {
+		s.Match = SegmentMatchAll
+	}
+	if s.Match != SegmentMatchAll && s.Match != SegmentMatchAny {
+		return fmt.Errorf("match must be all or any")
+	}
+	if len(s.Rules) == 0 {
+		return fmt.Errorf("at least one rule is required")
+	}
+	if len(s.Rules) > 20 {
+		return fmt.Errorf("segments are limited to 20 rules")
+	}
+	for i := range s.Rules {
+		if err := s.Rules[i].Validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the rule's operator and value types
+func (r *SegmentRule) Validate() error {
+	r.Trait = strings.TrimSpace(r.Trait)
+	if r.Trait == "" {
+		return fmt.Errorf("trait is required")
+	}
+	if len(r.Trait) > 100 {
+		return fmt.Errorf("trait must be 100 characters or less")
+	}
+
+	switch r.Op {
+	case OpExists:
+		return nil
+	case OpEquals, OpNotEquals:
+		switch r.Value.(type) {
+		case string, float64, bool:
+			return nil
+		}
+		return fmt.Errorf("%s requires a string, number or boolean value", r.Op)
+	case OpGreaterThan, OpGreaterOrEq, OpLessThan, OpLessOrEq:
+		if _, ok := r.Value.(float64); !ok {
+			return fmt.Errorf("%s requires a numeric value", r.Op)
+		}
+		return nil
+	case OpContains:
+		if s, ok := r.Value.(string); !ok || s == "" {
+			return fmt.Errorf("contains requires a non-empty string value")
+		}
+		return nil
+	case OpIn:
+		values, ok := r.Value.([]interface{})
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("in requires a non-empty list of values")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown operator: %s", r.Op)
+	}
+}
+
+// PrioritizationSettings configures the revenue-weighted priority score.
+// Each voter contributes the numeric value of WeightTrait from their SDK
+// user traits, or DefaultWeight when the voter has no such trait.
+type PrioritizationSettings struct {
+	WeightTrait   string  `json:"weight_trait,omitempty"`
+	DefaultWeight float64 `json:"default_weight"`
+}
+
+// UpdatePrioritizationRequest updates a project's prioritization settings;
+// nil fields are left unchanged and an empty weight trait counts every
+// voter at the default weight
+type UpdatePrioritizationRequest struct {
+	WeightTrait   *string  `json:"weight_trait,omitempty"`
+	DefaultWeight *float64 `json:"default_weight,omitempty"`
+}
+
+// Validate checks the request, trimming the trait name in place
+func (r *UpdatePrioritizationRequest) Validate() error {
+	if r.WeightTrait != nil {
+		trait := strings.TrimSpace(*r.WeightTrait)
+		if len(trait) > 100 {
+			return fmt.Errorf("weight_trait must be 100 characters or less")
+		}
+		r.WeightTrait = &trait
+	}
+	if r.DefaultWeight != nil && (*r.DefaultWeight < 0 || math.IsInf(*r.DefaultWeight, 0) || math.IsNaN(*r.DefaultWeight)) {
+		return fmt.Errorf("default_weight must be zero or more")
+	}
+	return nil
+}
+
+// Apply copies the request's set fields onto settings
+func (r *UpdatePrioritizationRequest) Apply(settings *PrioritizationSettings) {
+	if r.WeightTrait != nil {
+		settings.WeightTrait = *r.WeightTrait
+	}
+	if r.DefaultWeight != nil {
+		settings.DefaultWeight = *r.DefaultWeight
+	}
+}
+
+// PriorityItem is an open item ranked by its weighted score
+type PriorityItem struct {
+	FeedbackID    uuid.UUID      `json:"feedback_id"`
+	Title         string         `json:"title"`
+	Type          FeedbackType   `json:"type"`
+	Status        FeedbackStatus `json:"status"`
+	VoteCount     int            `json:"vote_count"`
+	SegmentVoters int            `json:"segment_voters"` // Voters that contributed to the score
+	Score         float64        `json:"score"`
+}
+
+// SegmentVoter is a voter on an item with their resolved SDK identity
+type SegmentVoter struct {
+	UserID     *uuid.UUID             `json:"user_id,omitempty"`
+	SDKUserID  *uuid.UUID             `json:"sdk_user_id,omitempty"`
+	ExternalID *string                `json:"external_id,omitempty"`
+	Email      *string                `json:"email,omitempty"`
+	Name       *string                `json:"name,omitempty"`
+	Traits     map[string]interface{} `json:"traits,omitempty"`
+	Weight     float64                `json:"weight"`
+	Importance VoteImportance         `json:"importance"`
+	Note       *string                `json:"note,omitempty"`        // Private "why I need this" note
+	RecordedBy *uuid.UUID             `json:"recorded_by,omitempty"` // Team member who recorded the vote
+	VotedAt    time.Time              `json:"voted_at"`
+}
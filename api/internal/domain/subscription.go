@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionReason records why a user follows a feedback item
+type SubscriptionReason string
+
+const (
+	SubscriptionManual    SubscriptionReason = "manual"
+	SubscriptionSubmitted SubscriptionReason = "submitted"
+	SubscriptionCommented SubscriptionReason = "commented"
+	SubscriptionVoted     SubscriptionReason = "voted"
+)
+
+// Follower identifies who follows an item: a Supabase user (portal user or
+// team member) or an SDK-identified user. Exactly one ID is set.
+type Follower struct {
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	SDKUserID *uuid.UUID `json:"sdk_user_id,omitempty"`
+}
+
+// UserFollower returns the follower for a Supabase user
+func UserFollower(userID uuid.UUID) Follower {
+	return Follower{UserID: &userID}
+}
+
+// SDKUserFollower returns the follower for an SDK-identified user
+func SDKUserFollower(sdkUserID uuid.UUID) Follower {
+	return Follower{SDKUserID: &sdkUserID}
+}
+
+// Validate checks that exactly one identity is set
+func (f Follower) Validate() error {
+	if (f.UserID == nil) == (f.SDKUserID == nil) {
+		return ErrInvalidFollower
+	}
+	return nil
+}
+
+// SubscriptionStatus reports whether the caller follows an item. Muted
+// subscriptions are kept so that voting or commenting later doesn't
+// subscribe the follower again, and are reported as not subscribed.
+type SubscriptionStatus struct {
+	FeedbackID uuid.UUID           `json:"feedback_id"`
+	Subscribed bool                `json:"subscribed"`
+	Reason     *SubscriptionReason `json:"reason,omitempty"`
+}
+
+// Notification event types recorded by database triggers
+const (
+	EventNewComment    = "new_comment"
+	EventStatusChanged = "status_changed"
+	EventMention       = "mention"  // Payload names the mentioned_user_id
+	EventAssigned      = "assigned" // Payload names the assignee_id
+	EventNewFeedback   = "new_feedback"
+)
+
+// NotificationEvent is a change on a feedback item that the notification
+// worker fans out to the item's subscribers
+type NotificationEvent struct {
+	ID                 uuid.UUID              `json:"id"`
+	ProjectID          uuid.UUID              `json:"project_id"`
+	FeedbackID         uuid.UUID              `json:"feedback_id"`
+	FeedbackTitle      string                 `json:"feedback_title"`
+	FeedbackVisibility Visibility             `json:"feedback_visibility"`
+	EventType          string                 `json:"event_type"`
+	ActorUserID        *uuid.UUID             `json:"actor_user_id,omitempty"`
+	Visibility         Visibility             `json:"visibility"` // Visibility of the change itself
+	Payload            map[string]interface{} `json:"payload"`
+	Attempts           int                    `json:"attempts"`
+	CreatedAt          time.Time              `json:"created_at"`
+}
+
+// Subscriber is an active subscription resolved for notification fan-out.
+// SDK users linked to a Supabase account are resolved to that account.
+// Team members are notified according to TeamPreferences, everyone else
+// according to Preferences.
+type Subscriber struct {
+	UserID          *uuid.UUID
+	SDKUserID       *uuid.UUID // Set only for SDK users without a linked account
+	Reason          SubscriptionReason
+	Muted           bool
+	IsTeam          bool // Has a team role in the project
+	IsSubmitter     bool
+	Preferences     PortalNotificationPreferences
+	TeamPreferences NotificationPreferences
+}
+
+// CanSee returns true if the subscriber may be told about the event: team
+// members see everything, portal users only COMMUNITY changes on items
+// they can open.
+func (s Subscriber) CanSee(e *NotificationEvent) bool {
+	if s.IsTeam {
+		return true
+	}
+	if e.Visibility != VisibilityCommunity {
+		return false
+	}
+	return e.FeedbackVisibility == VisibilityCommunity || s.IsSubmitter
+}
+
+// Wants returns true if the subscriber's preferences allow emailing
+// notifications of the given type. Explicit follows always get comment
+// notifications.
+func (s Subscriber) Wants(notificationType string) bool {
+	if s.IsTeam {
+		if notificationType == NotificationTypeNewComment && s.Reason == SubscriptionManual {
+			return true
+		}
+		return s.TeamPreferences.EmailsFor(notificationType)
+	}
+
+	switch notificationType {
+	case NotificationTypeStatusChanged, NotificationTypeFeedbackResolved:
+		return s.Preferences.StatusChanges
+	case NotificationTypeNewComment:
+		switch s.Reason {
+		case SubscriptionSubmitted:
+			return s.Preferences.NewCommentsOnMyFeedback
+		case SubscriptionVoted:
+			return s.Preferences.NewCommentsOnVotedFeedback
+		}
+		return true
+	}
+	return true
+}
+
+// WantsInbox returns true if notifications of the given type should reach
+// the subscriber's in-app inbox. Portal users see status changes on the
+// items they follow; SDK users without an account have no inbox.
+func (s Subscriber) WantsInbox(notificationType string) bool {
+	if s.UserID == nil {
+		return false
+	}
+	if s.IsTeam {
+		return s.TeamPreferences.InboxFor(notificationType)
+	}
+	return notificationType == NotificationTypeStatusChanged || notificationType == NotificationTypeFeedbackResolved
+}
@@ -1,22 +1,180 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// Vote represents a user's vote on a feedback item
+// Voter identifies who cast a vote. Exactly one field is set: a Supabase
+// user, an SDK end user, or an anonymous browser fingerprint.
+type Voter struct {
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	SDKUserID   *uuid.UUID `json:"sdk_user_id,omitempty"`
+	Fingerprint *string    `json:"-"`
+}
+
+// UserVoter returns the voter identity of a Supabase user
+func UserVoter(userID uuid.UUID) Voter {
+	return Voter{UserID: &userID}
+}
+
+// SDKUserVoter returns the voter identity of an SDK end user
+func SDKUserVoter(sdkUserID uuid.UUID) Voter {
+	return Voter{SDKUserID: &sdkUserID}
+}
+
+// AnonymousVoter returns the voter identity of a signed-out browser
+func AnonymousVoter(fingerprint string) Voter {
+	return Voter{Fingerprint: &fingerprint}
+}
+
+// Validate checks that exactly one identity is set
+func (v Voter) Validate() error {
+	set := 0
+	if v.UserID != nil {
+		set++
+	}
+	if v.SDKUserID != nil {
+		set++
+	}
+	if v.Fingerprint != nil {
+		if *v.Fingerprint == "" {
+			return ErrInvalidVoter
+		}
+		set++
+	}
+	if set != 1 {
+		return ErrInvalidVoter
+	}
+	return nil
+}
+
+// VoteImportance is how much a voter needs an item
+type VoteImportance string
+
+const (
+	ImportanceNiceToHave VoteImportance = "nice_to_have"
+	ImportanceImportant  VoteImportance = "important"
+	ImportanceCritical   VoteImportance = "critical"
+)
+
+// IsValid checks if the importance is valid
+func (i VoteImportance) IsValid() bool {
+	switch i {
+	case ImportanceNiceToHave, ImportanceImportant, ImportanceCritical:
+		return true
+	}
+	return false
+}
+
+// Weight is the vote's contribution to an item's vote_score. Keep in sync
+// with the vote_importance_weight SQL function.
+func (i VoteImportance) Weight() int {
+	switch i {
+	case ImportanceCritical:
+		return 3
+	case ImportanceImportant:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// MaxVoteNoteLength bounds the private note left with a vote
+const MaxVoteNoteLength = 1000
+
+// VoteDetails are the optional importance and private note sent with a
+// vote. The note is only shown to the project team. Unset fields leave a
+// repeat vote's stored values unchanged; importance defaults to
+// nice-to-have.
+type VoteDetails struct {
+	Importance VoteImportance `json:"importance,omitempty"`
+	Note       *string        `json:"note,omitempty"`
+}
+
+// TrimNote trims whitespace from the note. An empty note clears a stored one.
+func (d *VoteDetails) TrimNote() {
+	if d.Note != nil {
+		note := strings.TrimSpace(*d.Note)
+		d.Note = &note
+	}
+}
+
+// Validate checks the importance and note length
+func (d VoteDetails) Validate() error {
+	if d.Importance != "" && !d.Importance.IsValid() {
+		return fmt.Errorf("importance must be nice_to_have, important or critical")
+	}
+	if d.Note != nil && len([]rune(*d.Note)) > MaxVoteNoteLength {
+		return fmt.Errorf("note must be at most %d characters", MaxVoteNoteLength)
+	}
+	return nil
+}
+
+// Vote represents a voter's vote on a feedback item
 type Vote struct {
 	ID         uuid.UUID `json:"id"`
 	FeedbackID uuid.UUID `json:"feedback_id"`
-	UserID     uuid.UUID `json:"user_id"`
-	CreatedAt  time.Time `json:"created_at"`
+	Voter
+	VoteDetails
+	// RecordedBy is the team member who recorded the vote for a customer
+	RecordedBy *uuid.UUID `json:"recorded_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// VoteCustomer names the customer a team member records a vote for: an
+// existing SDK user, or one found or created by external ID or email
+type VoteCustomer struct {
+	SDKUserID  *uuid.UUID `json:"sdk_user_id,omitempty"`
+	ExternalID *string    `json:"external_id,omitempty"`
+	Email      *string    `json:"email,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+}
+
+// Validate checks that the customer can be resolved
+func (c VoteCustomer) Validate() error {
+	if c.SDKUserID == nil && isBlank(c.ExternalID) && isBlank(c.Email) {
+		return fmt.Errorf("customer needs sdk_user_id, external_id or email")
+	}
+	return nil
+}
+
+func isBlank(s *string) bool {
+	return s == nil || strings.TrimSpace(*s) == ""
+}
+
+// VoteBudgetUsage reports a voter's active votes against the project's vote
+// budget. Votes on resolved items and votes the team recorded for a
+// customer are not active, so resolving an item refunds its votes.
+type VoteBudgetUsage struct {
+	Budget    *int `json:"budget"`    // nil when votes are unlimited
+	Used      int  `json:"used"`      // Active votes
+	Remaining *int `json:"remaining"` // nil when votes are unlimited
+}
+
+// NewVoteBudgetUsage builds the usage for a project budget (0 = unlimited)
+func NewVoteBudgetUsage(budget, used int) *VoteBudgetUsage {
+	usage := &VoteBudgetUsage{Used: used}
+	if budget > 0 {
+		remaining := max(budget-used, 0)
+		usage.Budget = &budget
+		usage.Remaining = &remaining
+	}
+	return usage
+}
+
+// Exhausted returns true if the voter may not cast another active vote
+func (u *VoteBudgetUsage) Exhausted() bool {
+	return u.Remaining != nil && *u.Remaining == 0
 }
 
 // VoteResult represents the result of a vote operation
 type VoteResult struct {
 	FeedbackID uuid.UUID `json:"feedback_id"`
 	VoteCount  int       `json:"vote_count"`
+	VoteScore  int       `json:"vote_score"`
 	HasVoted   bool      `json:"has_voted"`
 }
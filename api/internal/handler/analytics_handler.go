@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// AnalyticsHandlers contains the project analytics HTTP handlers. Every
+// endpoint accepts from, to, interval (day|week) and repeated tag filters.
+type AnalyticsHandlers struct {
+	repo   repository.AnalyticsRepository
+	logger zerolog.Logger
+	now    func() time.Time
+}
+
+// NewAnalyticsHandlers creates a new AnalyticsHandlers instance
+func NewAnalyticsHandlers(repo repository.AnalyticsRepository, logger zerolog.Logger) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Volume returns feedback created per bucket by type and by source
+func (h *AnalyticsHandlers) Volume(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (interface{}, error) {
+		return h.repo.Volume(ctx, projectID, filter)
+	})
+}
+
+// Resolution returns median and p90 time to resolution
+func (h *AnalyticsHandlers) Resolution(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (interface{}, error) {
+		return h.repo.Resolution(ctx, projectID, filter)
+	})
+}
+
+// Funnel returns the status funnel for feedback created in the range
+func (h *AnalyticsHandlers) Funnel(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (interface{}, error) {
+		return h.repo.Funnel(ctx, projectID, filter)
+	})
+}
+
+// TopRequests returns the open items with the most votes in the range
+func (h *AnalyticsHandlers) TopRequests(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (interface{}, error) {
+		return h.repo.TopRequests(ctx, projectID, filter)
+	})
+}
+
+// VoteVelocity returns votes cast per bucket
+func (h *AnalyticsHandlers) VoteVelocity(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (interface{}, error) {
+		return h.repo.VoteVelocity(ctx, projectID, filter)
+	})
+}
+
+// ActiveUsers returns the SDK users who submitted the most feedback
+func (h *AnalyticsHandlers) ActiveUsers(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (interface{}, error) {
+		return h.repo.ActiveSDKUsers(ctx, projectID, filter)
+	})
+}
+
+// serve parses the shared parameters and writes the report
+func (h *AnalyticsHandlers) serve(w http.ResponseWriter, r *http.Request, report func(context.Context, uuid.UUID, domain.AnalyticsFilter) (interface{}, error)) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	filter, fields := parseAnalyticsFilter(r, h.now())
+	if fields != nil {
+		ValidationError(w, fields)
+		return
+	}
+
+	result, err := report(r.Context(), projectID, filter)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestParseAnalyticsFilter(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("defaults to the last 30 days by day", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/analytics/volume", nil)
+
+		filter, fields := parseAnalyticsFilter(req, now)
+
+		assert.Nil(t, fields)
+		assert.Equal(t, now.AddDate(0, 0, -30), filter.From)
+		assert.Equal(t, now, filter.To)
+		assert.Equal(t, domain.IntervalDay, filter.Interval)
+		assert.Equal(t, 10, filter.Limit)
+	})
+
+	t.Run("date-only to includes the whole day", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/analytics/volume?from=2024-01-01&to=2024-01-31&interval=week", nil)
+
+		filter, fields := parseAnalyticsFilter(req, now)
+
+		assert.Nil(t, fields)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), filter.To)
+		assert.Equal(t, domain.IntervalWeek, filter.Interval)
+
+		// 2024-01-01 is a Monday, so the range spans five week buckets
+		buckets := filter.Buckets()
+		assert.Len(t, buckets, 5)
+		assert.Equal(t, time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC), buckets[4])
+	})
+
+	t.Run("rejects inverted ranges and bad intervals", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/analytics/volume?from=2024-02-01&to=2024-01-01", nil)
+		_, fields := parseAnalyticsFilter(req, now)
+		assert.Contains(t, fields, "filter")
+
+		req = httptest.NewRequest("GET", "/analytics/volume?interval=month", nil)
+		_, fields = parseAnalyticsFilter(req, now)
+		assert.Contains(t, fields, "filter")
+	})
+}
+
+func TestAnalyticsHandlers_InvalidFilter(t *testing.T) {
+	h := NewAnalyticsHandlers(nil, zerolog.Nop())
+
+	projectID := uuid.New()
+	req := httptest.NewRequest("GET", "/analytics/volume?limit=1000", nil)
+	req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+
+	rr := httptest.NewRecorder()
+	h.TopRequests(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
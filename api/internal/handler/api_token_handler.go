@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// APITokenHandlers contains the HTTP handlers for personal access tokens
+// and project service accounts. Tokens can only be managed from a signed-in
+// session, so a leaked token can't be used to mint more.
+type APITokenHandlers struct {
+	repo   repository.APITokenRepository
+	logger zerolog.Logger
+}
+
+// NewAPITokenHandlers creates a new APITokenHandlers instance
+func NewAPITokenHandlers(repo repository.APITokenRepository, logger zerolog.Logger) *APITokenHandlers {
+	return &APITokenHandlers{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreatedAPITokenResponse is a newly created token; the plaintext token is
+// only ever returned here
+type CreatedAPITokenResponse struct {
+	domain.APIToken
+	Token string `json:"token"`
+}
+
+// ListPersonal handles GET /creator/tokens
+func (h *APITokenHandlers) ListPersonal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.repo.ListUserTokens(r.Context(), userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if tokens == nil {
+		tokens = []domain.APIToken{}
+	}
+
+	JSON(w, http.StatusOK, tokens)
+}
+
+// CreatePersonal handles POST /creator/tokens
+func (h *APITokenHandlers) CreatePersonal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateAPITokenRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	token := &domain.APIToken{UserID: &userID}
+	h.createToken(w, r, userID, auth.PersonalTokenPrefix, token, req)
+}
+
+// RevokePersonal handles DELETE /creator/tokens/{tokenId}
+func (h *APITokenHandlers) RevokePersonal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tokenId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_TOKEN_ID", "Invalid token ID")
+		return
+	}
+
+	if err := h.repo.RevokeUserToken(r.Context(), userID, tokenID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// ListServiceAccounts handles GET /creator/projects/{projectId}/service-accounts
+func (h *APITokenHandlers) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireSession(w, r); !ok {
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	accounts, err := h.repo.ListServiceAccounts(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if accounts == nil {
+		accounts = []domain.ServiceAccount{}
+	}
+
+	JSON(w, http.StatusOK, accounts)
+}
+
+// CreateServiceAccount handles POST /creator/projects/{projectId}/service-accounts
+func (h *APITokenHandlers) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	var req domain.CreateServiceAccountRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+	if !auth.MustMembershipFromContext(r.Context()).CanGrantRole(req.Role) {
+		Error(w, http.StatusForbidden, "FORBIDDEN", "You cannot create a service account with this role")
+		return
+	}
+
+	account := &domain.ServiceAccount{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Name:      req.Name,
+		Role:      req.Role,
+		CreatedBy: userID,
+	}
+	if err := h.repo.CreateServiceAccount(r.Context(), account); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, account)
+}
+
+// DisableServiceAccount handles DELETE /creator/projects/{projectId}/service-accounts/{accountId}
+// and revokes all of the account's tokens
+func (h *APITokenHandlers) DisableServiceAccount(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireSession(w, r); !ok {
+		return
+	}
+
+	projectID, accountID, ok := parseServiceAccountRoute(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DisableServiceAccount(r.Context(), projectID, accountID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// ListServiceAccountTokens handles GET /creator/projects/{projectId}/service-accounts/{accountId}/tokens
+func (h *APITokenHandlers) ListServiceAccountTokens(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireSession(w, r); !ok {
+		return
+	}
+
+	account, ok := h.loadServiceAccount(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.repo.ListServiceAccountTokens(r.Context(), account.ID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if tokens == nil {
+		tokens = []domain.APIToken{}
+	}
+
+	JSON(w, http.StatusOK, tokens)
+}
+
+// CreateServiceAccountToken handles POST /creator/projects/{projectId}/service-accounts/{accountId}/tokens
+func (h *APITokenHandlers) CreateServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireSession(w, r)
+	if !ok {
+		return
+	}
+
+	account, ok := h.loadServiceAccount(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateAPITokenRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	token := &domain.APIToken{ServiceAccountID: &account.ID}
+	h.createToken(w, r, userID, auth.ServiceAccountTokenPrefix, token, req)
+}
+
+// RevokeServiceAccountToken handles DELETE /creator/projects/{projectId}/service-accounts/{accountId}/tokens/{tokenId}
+func (h *APITokenHandlers) RevokeServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireSession(w, r); !ok {
+		return
+	}
+
+	account, ok := h.loadServiceAccount(w, r)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tokenId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_TOKEN_ID", "Invalid token ID")
+		return
+	}
+
+	if err := h.repo.RevokeServiceAccountToken(r.Context(), account.ID, tokenID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// createToken validates the request, generates the token and stores its hash
+func (h *APITokenHandlers) createToken(w http.ResponseWriter, r *http.Request, userID uuid.UUID, prefix string, token *domain.APIToken, req domain.CreateAPITokenRequest) {
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	plaintext, tokenHash, err := auth.GenerateAPIToken(prefix)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "TOKEN_GENERATION_ERROR", "Failed to generate token")
+		return
+	}
+
+	token.ID = uuid.New()
+	token.Name = req.Name
+	token.TokenPrefix = plaintext[:12]
+	token.ExpiresAt = req.ExpiresAt(time.Now())
+	token.CreatedBy = userID
+
+	if err := h.repo.CreateToken(r.Context(), token, tokenHash); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, CreatedAPITokenResponse{APIToken: *token, Token: plaintext})
+}
+
+// loadServiceAccount fetches the enabled service account named in the URL
+func (h *APITokenHandlers) loadServiceAccount(w http.ResponseWriter, r *http.Request) (*domain.ServiceAccount, bool) {
+	projectID, accountID, ok := parseServiceAccountRoute(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	account, err := h.repo.GetServiceAccount(r.Context(), projectID, accountID)
+	if err != nil {
+		HandleError(w, err)
+		return nil, false
+	}
+
+	return account, true
+}
+
+// requireSession returns the signed-in user, rejecting requests
+// authenticated with an API token
+func requireSession(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	return requireSessionFor(w, r, "API tokens can only be managed from a signed-in session")
+}
+
+// requireSessionFor returns the signed-in user, rejecting API tokens with
+// the given message
+func requireSessionFor(w http.ResponseWriter, r *http.Request, message string) (uuid.UUID, bool) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return uuid.Nil, false
+	}
+
+	if !auth.IsSessionAuth(r.Context()) {
+		Error(w, http.StatusForbidden, "SESSION_REQUIRED", message)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+func parseServiceAccountRoute(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "accountId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_ACCOUNT_ID", "Invalid service account ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, accountID, true
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func withAuthMethod(r *http.Request, userID uuid.UUID, method auth.AuthMethod) *http.Request {
+	ctx := auth.ContextWithUserID(r.Context(), userID)
+	ctx = auth.ContextWithAuthMethod(ctx, method)
+	return r.WithContext(ctx)
+}
+
+func TestAPITokenHandlers_CreatePersonal(t *testing.T) {
+	logger := zerolog.Nop()
+	userID := uuid.New()
+
+	t.Run("success - returns the token once and stores its hash", func(t *testing.T) {
+		mockRepo := repository.NewMockAPITokenRepository()
+		h := NewAPITokenHandlers(mockRepo, logger)
+
+		var storedHash string
+		mockRepo.On("CreateToken", mock.Anything, mock.MatchedBy(func(tok *domain.APIToken) bool {
+			return tok.UserID != nil && *tok.UserID == userID && tok.ServiceAccountID == nil &&
+				tok.Name == "CI triage" && tok.ExpiresAt != nil
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			storedHash = args.String(2)
+		}).Return(nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"name": " CI triage ", "expires_in_days": 30})
+		req := httptest.NewRequest("POST", "/creator/tokens", bytes.NewReader(body))
+		req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+		rr := httptest.NewRecorder()
+		h.CreatePersonal(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var resp CreatedAPITokenResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.True(t, strings.HasPrefix(resp.Token, auth.PersonalTokenPrefix))
+		assert.Equal(t, resp.Token[:12], resp.TokenPrefix)
+		assert.Len(t, storedHash, 64)
+		assert.NotContains(t, storedHash, resp.Token)
+	})
+
+	t.Run("forbidden - API tokens can't mint tokens", func(t *testing.T) {
+		h := NewAPITokenHandlers(repository.NewMockAPITokenRepository(), logger)
+
+		req := httptest.NewRequest("POST", "/creator/tokens", strings.NewReader(`{"name":"x"}`))
+		req = withAuthMethod(req, userID, auth.AuthMethodPersonalToken)
+		rr := httptest.NewRecorder()
+		h.CreatePersonal(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("bad request - lifetime out of range", func(t *testing.T) {
+		h := NewAPITokenHandlers(repository.NewMockAPITokenRepository(), logger)
+
+		req := httptest.NewRequest("POST", "/creator/tokens", strings.NewReader(`{"name":"x","expires_in_days":1000}`))
+		req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+		rr := httptest.NewRecorder()
+		h.CreatePersonal(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestAPITokenHandlers_ServiceAccounts(t *testing.T) {
+	logger := zerolog.Nop()
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	t.Run("bad request - owner role", func(t *testing.T) {
+		h := NewAPITokenHandlers(repository.NewMockAPITokenRepository(), logger)
+
+		req := httptest.NewRequest("POST", "/service-accounts", strings.NewReader(`{"name":"CI","role":"owner"}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+		rr := httptest.NewRecorder()
+		h.CreateServiceAccount(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("forbidden - role above the creator's", func(t *testing.T) {
+		mockRepo := repository.NewMockAPITokenRepository()
+		h := NewAPITokenHandlers(mockRepo, logger)
+		tokenKeeper := &domain.Membership{ProjectID: projectID, Role: domain.RoleMember, CustomRole: &domain.ProjectRole{
+			Permissions: []domain.Permission{domain.PermissionFeedbackView, domain.PermissionTokensManage},
+		}}
+
+		for _, role := range []string{"admin", "member"} {
+			req := httptest.NewRequest("POST", "/service-accounts", strings.NewReader(`{"name":"CI","role":"`+role+`"}`))
+			req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+			req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+			req = req.WithContext(auth.ContextWithMembership(req.Context(), tokenKeeper))
+			rr := httptest.NewRecorder()
+			h.CreateServiceAccount(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code, role)
+		}
+		mockRepo.AssertNotCalled(t, "CreateServiceAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - admin creates a member account", func(t *testing.T) {
+		mockRepo := repository.NewMockAPITokenRepository()
+		h := NewAPITokenHandlers(mockRepo, logger)
+		mockRepo.On("CreateServiceAccount", mock.Anything, mock.MatchedBy(func(a *domain.ServiceAccount) bool {
+			return a.ProjectID == projectID && a.Role == domain.RoleMember && a.CreatedBy == userID
+		})).Return(nil)
+
+		req := httptest.NewRequest("POST", "/service-accounts", strings.NewReader(`{"name":"CI","role":"member"}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+		req = req.WithContext(auth.ContextWithMembership(req.Context(), &domain.Membership{ProjectID: projectID, Role: domain.RoleAdmin}))
+		rr := httptest.NewRecorder()
+		h.CreateServiceAccount(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - creates a token for the account", func(t *testing.T) {
+		mockRepo := repository.NewMockAPITokenRepository()
+		h := NewAPITokenHandlers(mockRepo, logger)
+		account := &domain.ServiceAccount{ID: uuid.New(), ProjectID: projectID, Name: "CI", Role: domain.RoleMember}
+
+		mockRepo.On("GetServiceAccount", mock.Anything, projectID, account.ID).Return(account, nil)
+		mockRepo.On("CreateToken", mock.Anything, mock.MatchedBy(func(tok *domain.APIToken) bool {
+			return tok.ServiceAccountID != nil && *tok.ServiceAccountID == account.ID && tok.UserID == nil &&
+				tok.CreatedBy == userID && tok.ExpiresAt == nil
+		}), mock.Anything).Return(nil)
+
+		req := httptest.NewRequest("POST", "/tokens", strings.NewReader(`{"name":"deploy"}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "accountId": account.ID.String()})
+		req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+		rr := httptest.NewRecorder()
+		h.CreateServiceAccountToken(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var resp CreatedAPITokenResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.True(t, strings.HasPrefix(resp.Token, auth.ServiceAccountTokenPrefix))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found - disabled account", func(t *testing.T) {
+		mockRepo := repository.NewMockAPITokenRepository()
+		h := NewAPITokenHandlers(mockRepo, logger)
+		accountID := uuid.New()
+
+		mockRepo.On("GetServiceAccount", mock.Anything, projectID, accountID).Return(nil, domain.ErrServiceAccountNotFound)
+
+		req := httptest.NewRequest("POST", "/tokens", strings.NewReader(`{"name":"deploy"}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "accountId": accountID.String()})
+		req = withAuthMethod(req, userID, auth.AuthMethodSupabase)
+		rr := httptest.NewRecorder()
+		h.CreateServiceAccountToken(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// BrandingHandlers contains the HTTP handlers for project branding and the
+// public portal config
+type BrandingHandlers struct {
+	svc    service.BrandingService
+	logger zerolog.Logger
+}
+
+// NewBrandingHandlers creates a new BrandingHandlers instance
+func NewBrandingHandlers(svc service.BrandingService, logger zerolog.Logger) *BrandingHandlers {
+	return &BrandingHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Get handles GET /creator/projects/{projectId}/branding
+func (h *BrandingHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	config, err := h.svc.Get(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, config)
+}
+
+// Update handles PATCH /creator/projects/{projectId}/branding
+func (h *BrandingHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateBrandingRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	config, err := h.svc.Update(r.Context(), projectID, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, config)
+}
+
+// InitiateLogoUpload handles POST /creator/projects/{projectId}/branding/logo/init.
+// The logo is uploaded to the returned signed URL and then confirmed.
+func (h *BrandingHandlers) InitiateLogoUpload(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.LogoUploadRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	upload, err := h.svc.InitiateLogoUpload(r.Context(), projectID, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, upload)
+}
+
+// CompleteLogoUpload handles PUT /creator/projects/{projectId}/branding/logo
+func (h *BrandingHandlers) CompleteLogoUpload(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Path string `json:"path"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if req.Path == "" {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "path is required")
+		return
+	}
+
+	config, err := h.svc.CompleteLogoUpload(r.Context(), projectID, req.Path)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, config)
+}
+
+// RemoveLogo handles DELETE /creator/projects/{projectId}/branding/logo
+func (h *BrandingHandlers) RemoveLogo(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	config, err := h.svc.RemoveLogo(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, config)
+}
+
+// PublicConfig handles GET /public/projects/{slug}/config. It needs no
+// authentication; the portal frontend and SDK widgets load it to render.
+func (h *BrandingHandlers) PublicConfig(w http.ResponseWriter, r *http.Request) {
+	config, err := h.svc.GetPublicConfig(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	JSON(w, http.StatusOK, config)
+}
+
+// PublicConfigForProject handles GET /public/config on a custom domain,
+// where the project comes from the Host header
+func (h *BrandingHandlers) PublicConfigForProject(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	config, err := h.svc.GetPublicConfigByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	JSON(w, http.StatusOK, config)
+}
@@ -81,7 +81,7 @@ func (h *CommunityHandler) ListFeatures(w http.ResponseWriter, r *http.Request)
 	// Enrich with vote status if authenticated
 	userID, ok := auth.UserIDFromContext(r.Context())
 	if ok {
-		if err := h.voteSvc.EnrichWithVoteStatus(r.Context(), result.Feedbacks, userID); err != nil {
+		if err := h.voteSvc.EnrichWithVoteStatus(r.Context(), result.Feedbacks, domain.UserVoter(userID)); err != nil {
 			HandleError(w, err)
 			return
 		}
@@ -121,7 +121,7 @@ func (h *CommunityHandler) GetFeature(w http.ResponseWriter, r *http.Request) {
 	// Enrich with vote status
 	userID, ok := auth.UserIDFromContext(r.Context())
 	if ok {
-		hasVoted, err := h.voteSvc.HasVoted(r.Context(), feedbackID, userID)
+		hasVoted, err := h.voteSvc.HasVoted(r.Context(), feedbackID, domain.UserVoter(userID))
 		if err == nil {
 			feedback.HasVoted = hasVoted
 		}
@@ -144,9 +144,15 @@ func (h *CommunityHandler) Vote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	details, err := DecodeVoteDetails(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
 	userID := auth.MustUserIDFromContext(r.Context())
 
-	result, err := h.voteSvc.Vote(r.Context(), projectID, feedbackID, userID)
+	result, err := h.voteSvc.Vote(r.Context(), projectID, feedbackID, domain.UserVoter(userID), details)
 	if err != nil {
 		HandleError(w, err)
 		return
@@ -171,7 +177,7 @@ func (h *CommunityHandler) Unvote(w http.ResponseWriter, r *http.Request) {
 
 	userID := auth.MustUserIDFromContext(r.Context())
 
-	result, err := h.voteSvc.Unvote(r.Context(), projectID, feedbackID, userID)
+	result, err := h.voteSvc.Unvote(r.Context(), projectID, feedbackID, domain.UserVoter(userID))
 	if err != nil {
 		HandleError(w, err)
 		return
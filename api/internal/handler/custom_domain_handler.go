@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// CustomDomainHandlers contains the HTTP handlers for project custom domains
+type CustomDomainHandlers struct {
+	svc    service.CustomDomainService
+	logger zerolog.Logger
+}
+
+// NewCustomDomainHandlers creates a new CustomDomainHandlers instance
+func NewCustomDomainHandlers(svc service.CustomDomainService, logger zerolog.Logger) *CustomDomainHandlers {
+	return &CustomDomainHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Get handles GET /creator/projects/{projectId}/domain
+func (h *CustomDomainHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.svc.Get(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, domain.NewCustomDomainResponse(d))
+}
+
+// Register handles PUT /creator/projects/{projectId}/domain. The response
+// holds the TXT record to publish before calling Verify.
+func (h *CustomDomainHandlers) Register(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.RegisterDomainRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	d, err := h.svc.Register(r.Context(), projectID, req.Hostname)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, domain.NewCustomDomainResponse(d))
+}
+
+// Verify handles POST /creator/projects/{projectId}/domain/verify
+func (h *CustomDomainHandlers) Verify(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.svc.Verify(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, domain.NewCustomDomainResponse(d))
+}
+
+// Remove handles DELETE /creator/projects/{projectId}/domain
+func (h *CustomDomainHandlers) Remove(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Remove(r.Context(), projectID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// CustomDomainMiddleware resolves the project from the Host header of
+// requests made to a verified custom domain and exposes it as the
+// projectId URL parameter, so project routes work without it in the path
+func CustomDomainMiddleware(domains service.CustomDomainService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			projectID, ok := domains.ResolveHost(r.Context(), r.Host)
+			if !ok {
+				Error(w, http.StatusNotFound, "DOMAIN_NOT_FOUND", "No project is served from this domain")
+				return
+			}
+
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				rctx.URLParams.Add("projectId", projectID.String())
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+type noTXTResolver struct{}
+
+func (noTXTResolver) LookupTXT(context.Context, string) ([]string, error) { return nil, nil }
+
+func TestCustomDomainMiddleware(t *testing.T) {
+	projectID := uuid.New()
+	repo := repository.NewMockCustomDomainRepository()
+	repo.On("ListVerified", mock.Anything).Return(map[string]uuid.UUID{"feedback.example.com": projectID}, nil)
+	svc := service.NewCustomDomainService(repo, noTXTResolver{})
+
+	r := chi.NewRouter()
+	r.Route("/domain", func(r chi.Router) {
+		r.Use(CustomDomainMiddleware(svc))
+		r.Route("/portal", func(r chi.Router) {
+			r.Get("/feedback/{feedbackId}", func(w http.ResponseWriter, r *http.Request) {
+				JSON(w, http.StatusOK, map[string]string{
+					"project_id":  chi.URLParam(r, "projectId"),
+					"feedback_id": chi.URLParam(r, "feedbackId"),
+				})
+			})
+		})
+	})
+
+	t.Run("resolves the project from the host", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/domain/portal/feedback/abc", nil)
+		req.Host = "feedback.example.com"
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), projectID.String())
+		assert.Contains(t, rr.Body.String(), `"feedback_id":"abc"`)
+	})
+
+	t.Run("unknown host", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/domain/portal/feedback/abc", nil)
+		req.Host = "api.example.com"
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestCustomDomainHandlers_Register(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("validation error - invalid hostname", func(t *testing.T) {
+		repo := repository.NewMockCustomDomainRepository()
+		h := NewCustomDomainHandlers(service.NewCustomDomainService(repo, noTXTResolver{}), logger)
+
+		for _, hostname := range []string{"localhost", "10.0.0.1", "bad_host.example.com", "-x.example.com"} {
+			body := `{"hostname":"` + hostname + `"}`
+			req := httptest.NewRequest("PUT", "/domain", bytes.NewBufferString(body))
+			req = setupTestContext(req, map[string]string{"projectId": uuid.NewString()})
+			rr := httptest.NewRecorder()
+			h.Register(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, hostname)
+		}
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - returns the TXT record", func(t *testing.T) {
+		projectID := uuid.New()
+		repo := repository.NewMockCustomDomainRepository()
+		repo.On("GetByProject", mock.Anything, projectID).Return(nil, domain.ErrDomainNotFound)
+		repo.On("ListVerified", mock.Anything).Return(map[string]uuid.UUID{}, nil)
+		repo.On("Upsert", mock.Anything, mock.MatchedBy(func(d *domain.CustomDomain) bool {
+			return d.Hostname == "feedback.example.com"
+		})).Return(nil)
+		h := NewCustomDomainHandlers(service.NewCustomDomainService(repo, noTXTResolver{}), logger)
+
+		body := `{"hostname":"Feedback.Example.com."}`
+		req := httptest.NewRequest("PUT", "/domain", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		rr := httptest.NewRecorder()
+		h.Register(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"name":"_fulldisclosure.feedback.example.com"`)
+		assert.Contains(t, rr.Body.String(), `"verified":false`)
+	})
+}
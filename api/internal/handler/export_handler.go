@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// syncExportWriteTimeout bounds how long a streamed export may take
+const syncExportWriteTimeout = 5 * time.Minute
+
+// ExportHandlers contains the feedback export HTTP handlers
+type ExportHandlers struct {
+	svc    service.ExportService
+	logger zerolog.Logger
+}
+
+// NewExportHandlers creates a new ExportHandlers instance
+func NewExportHandlers(svc service.ExportService, logger zerolog.Logger) *ExportHandlers {
+	return &ExportHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Export streams matching feedback as CSV or NDJSON. Exports above
+// domain.ExportSyncRowLimit, or requests with async=true, are queued as a
+// background job and answered with 202 Accepted.
+func (h *ExportHandlers) Export(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	format := domain.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = domain.ExportFormatCSV
+	}
+	if !format.IsValid() {
+		ValidationError(w, map[string]string{"format": "must be csv or ndjson"})
+		return
+	}
+
+	filter, fields := ParseFeedbackFilter(r)
+	if fields != nil {
+		ValidationError(w, fields)
+		return
+	}
+
+	async := r.URL.Query().Get("async") == "true"
+	if !async {
+		total, err := h.svc.Count(r.Context(), projectID, filter)
+		if err != nil {
+			HandleError(w, err)
+			return
+		}
+		async = total > domain.ExportSyncRowLimit
+	}
+
+	if async {
+		job, err := h.svc.StartJob(r.Context(), projectID, userID, filter, format)
+		if err != nil {
+			HandleError(w, err)
+			return
+		}
+		JSON(w, http.StatusAccepted, job)
+		return
+	}
+
+	filename := fmt.Sprintf("feedback-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// Large synchronous exports outlive the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Now().Add(syncExportWriteTimeout))
+
+	// Headers are already sent, so failures can only be logged
+	count, err := h.svc.Write(r.Context(), projectID, filter, format, flushWriter{w})
+	if err != nil {
+		h.logger.Error().Err(err).
+			Str("project_id", projectID.String()).
+			Int("rows_written", count).
+			Msg("Feedback export aborted")
+	}
+}
+
+// ListJobs returns recent background exports for the project
+func (h *ExportHandlers) ListJobs(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	jobs, err := h.svc.ListJobs(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if jobs == nil {
+		jobs = []domain.ExportJob{}
+	}
+
+	JSON(w, http.StatusOK, jobs)
+}
+
+// GetJob returns a background export, including a download URL once complete
+func (h *ExportHandlers) GetJob(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	jobID, err := uuid.Parse(chi.URLParam(r, "jobId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_JOB_ID", "Invalid export job ID")
+		return
+	}
+
+	job, err := h.svc.GetJob(r.Context(), projectID, jobID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, job)
+}
+
+// flushWriter pushes each write to the client so large exports stream
+// instead of buffering in the response
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestParseFeedbackFilter(t *testing.T) {
+	t.Run("parses list filters", func(t *testing.T) {
+		tagID := uuid.New()
+		req := httptest.NewRequest("GET", "/export?type=bug&status=planned&tag="+tagID.String()+"&search=crash&sort_by=vote_count&sort_order=asc", nil)
+
+		filter, fields := ParseFeedbackFilter(req)
+
+		assert.Nil(t, fields)
+		assert.Equal(t, domain.FeedbackTypeBug, *filter.Type)
+		assert.Equal(t, domain.StatusPlanned, *filter.Status)
+		assert.Equal(t, []uuid.UUID{tagID}, filter.TagIDs)
+		assert.Equal(t, "crash", *filter.Search)
+		assert.Equal(t, "vote_count", filter.SortBy)
+		assert.Equal(t, "asc", filter.SortOrder)
+	})
+
+	t.Run("reports invalid values", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/export?assigned_to=nope&tag=bad&status=unknown", nil)
+
+		_, fields := ParseFeedbackFilter(req)
+
+		assert.Contains(t, fields, "assigned_to")
+		assert.Contains(t, fields, "tag")
+		assert.Contains(t, fields, "filter")
+	})
+}
+
+func TestExportHandlers_Export(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("validation error - unknown format", func(t *testing.T) {
+		h := NewExportHandlers(nil, logger)
+
+		projectID := uuid.New()
+		req := httptest.NewRequest("GET", "/creator/projects/"+projectID.String()+"/export?format=xlsx", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Export(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unauthorized - no user ID in context", func(t *testing.T) {
+		h := NewExportHandlers(nil, logger)
+
+		req := httptest.NewRequest("GET", "/export", nil)
+		rr := httptest.NewRecorder()
+		h.Export(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// ParseFeedbackFilter reads the feedback list query parameters shared by the
+// creator list, saved views and exports. Invalid values are returned as
+// field errors suitable for ValidationError.
+func ParseFeedbackFilter(r *http.Request) (domain.SavedViewFilter, map[string]string) {
+	q := r.URL.Query()
+	var filter domain.SavedViewFilter
+	fields := make(map[string]string)
+
+	if v := q.Get("type"); v != "" {
+		t := domain.FeedbackType(v)
+		filter.Type = &t
+	}
+	if v := q.Get("status"); v != "" {
+		s := domain.FeedbackStatus(v)
+		filter.Status = &s
+	}
+	if v := q.Get("assigned_to"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			fields["assigned_to"] = "must be a valid UUID"
+		} else {
+			filter.AssignedTo = &id
+		}
+	}
+	if v := strings.TrimSpace(q.Get("search")); v != "" {
+		filter.Search = &v
+	}
+	for _, v := range q["tag"] {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			fields["tag"] = "must be a valid UUID"
+			continue
+		}
+		filter.TagIDs = append(filter.TagIDs, id)
+	}
+	filter.SortBy = q.Get("sort_by")
+	filter.SortOrder = q.Get("sort_order")
+
+	if err := filter.Validate(); err != nil {
+		fields["filter"] = err.Error()
+	}
+
+	if len(fields) > 0 {
+		return filter, fields
+	}
+	return filter, nil
+}
+
+// parseAnalyticsFilter reads from, to, interval, tag and limit query
+// parameters. The range defaults to the 30 days before now; dates may be
+// RFC 3339 timestamps or YYYY-MM-DD, and a date-only "to" includes that day.
+func parseAnalyticsFilter(r *http.Request, now time.Time) (domain.AnalyticsFilter, map[string]string) {
+	q := r.URL.Query()
+	fields := make(map[string]string)
+	filter := domain.AnalyticsFilter{
+		From:     now.AddDate(0, 0, -30),
+		To:       now,
+		Interval: domain.IntervalDay,
+		Limit:    10,
+	}
+
+	if v := q.Get("from"); v != "" {
+		if t, err := parseDateParam(v, false); err != nil {
+			fields["from"] = "must be RFC 3339 or YYYY-MM-DD"
+		} else {
+			filter.From = t
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if t, err := parseDateParam(v, true); err != nil {
+			fields["to"] = "must be RFC 3339 or YYYY-MM-DD"
+		} else {
+			filter.To = t
+		}
+	}
+	if v := q.Get("interval"); v != "" {
+		filter.Interval = domain.AnalyticsInterval(v)
+	}
+	for _, v := range q["tag"] {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			fields["tag"] = "must be a valid UUID"
+			continue
+		}
+		filter.TagIDs = append(filter.TagIDs, id)
+	}
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err != nil || n <= 0 || n > 100 {
+			fields["limit"] = "must be between 1 and 100"
+		} else {
+			filter.Limit = n
+		}
+	}
+
+	if len(fields) == 0 {
+		if err := filter.Validate(); err != nil {
+			fields["filter"] = err.Error()
+		}
+	}
+
+	if len(fields) > 0 {
+		return filter, fields
+	}
+	return filter, nil
+}
+
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
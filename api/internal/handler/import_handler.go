@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// maxImportUploadBytes caps the size of an uploaded import file
+const maxImportUploadBytes = 50 << 20
+
+// ImportHandlers contains the bulk feedback import HTTP handlers
+type ImportHandlers struct {
+	svc    service.ImportService
+	logger zerolog.Logger
+}
+
+// NewImportHandlers creates a new ImportHandlers instance
+func NewImportHandlers(svc service.ImportService, logger zerolog.Logger) *ImportHandlers {
+	return &ImportHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// ImportJSON imports a domain.ImportRequest document. With dry_run=true
+// rows are validated and checked for earlier imports but nothing is written.
+func (h *ImportHandlers) ImportJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+
+	var req domain.ImportRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	result, err := h.svc.Import(r.Context(), projectID, userID, req, r.URL.Query().Get("dry_run") == "true")
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	h.logResult(projectID, req.Source, result)
+	JSON(w, http.StatusOK, result)
+}
+
+// ImportCSV imports a multipart upload with a "file" CSV part, a "mapping"
+// JSON part (domain.ImportColumnMapping), a "source" name and an optional
+// "dry_run" flag.
+func (h *ImportHandlers) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FORM", "Expected a multipart form upload")
+		return
+	}
+
+	var mapping domain.ImportColumnMapping
+	if err := json.Unmarshal([]byte(r.FormValue("mapping")), &mapping); err != nil {
+		ValidationError(w, map[string]string{"mapping": "must be a JSON column mapping"})
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		ValidationError(w, map[string]string{"file": "CSV file is required"})
+		return
+	}
+	defer file.Close()
+
+	source := r.FormValue("source")
+	dryRun := r.FormValue("dry_run") == "true" || r.URL.Query().Get("dry_run") == "true"
+
+	result, err := h.svc.ImportCSV(r.Context(), projectID, userID, source, mapping, file, dryRun)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	h.logResult(projectID, source, result)
+	JSON(w, http.StatusOK, result)
+}
+
+func (h *ImportHandlers) logResult(projectID uuid.UUID, source string, result *domain.ImportResult) {
+	h.logger.Info().
+		Str("project_id", projectID.String()).
+		Str("source", source).
+		Bool("dry_run", result.DryRun).
+		Int("total", result.Total).
+		Int("created", result.Created).
+		Int("skipped", result.Skipped).
+		Int("invalid", result.Invalid).
+		Int("failed", result.Failed).
+		Msg("Feedback import finished")
+}
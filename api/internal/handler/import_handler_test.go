@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportHandlers_ImportCSV(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("validation error - mapping is not JSON", func(t *testing.T) {
+		h := NewImportHandlers(nil, logger)
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		mw.WriteField("source", "canny")
+		mw.WriteField("mapping", "title=Title")
+		part, _ := mw.CreateFormFile("file", "export.csv")
+		part.Write([]byte("ID,Title\n1,Dark mode\n"))
+		mw.Close()
+
+		projectID := uuid.New()
+		req := httptest.NewRequest("POST", "/creator/projects/"+projectID.String()+"/import/csv", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.ImportCSV(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "mapping")
+	})
+
+	t.Run("bad request - not a multipart upload", func(t *testing.T) {
+		h := NewImportHandlers(nil, logger)
+
+		projectID := uuid.New()
+		req := httptest.NewRequest("POST", "/import/csv", bytes.NewBufferString("ID,Title"))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.ImportCSV(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
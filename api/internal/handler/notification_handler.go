@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// NotificationHandlers contains the in-app notification inbox HTTP
+// handlers. The inbox is per user per project and serves team members and
+// portal users alike; preferences are for team members.
+type NotificationHandlers struct {
+	repo   repository.InboxRepository
+	logger zerolog.Logger
+}
+
+// NewNotificationHandlers creates a new NotificationHandlers instance
+func NewNotificationHandlers(repo repository.InboxRepository, logger zerolog.Logger) *NotificationHandlers {
+	return &NotificationHandlers{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// UnreadCountResponse is the inbox badge count
+type UnreadCountResponse struct {
+	Unread int `json:"unread"`
+}
+
+// MarkAllReadResponse reports how many notifications were marked read
+type MarkAllReadResponse struct {
+	Marked int `json:"marked"`
+}
+
+// List returns the user's notifications, newest first. Pass unread=true to
+// list only unread ones.
+func (h *NotificationHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, projectID, ok := parseInboxRoute(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, total, err := h.repo.List(r.Context(), projectID, userID, unreadOnly, limit, offset)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if notifications == nil {
+		notifications = []domain.Notification{}
+	}
+
+	page := (offset / limit) + 1
+	totalPages := (total + limit - 1) / limit
+
+	Paginated(w, notifications, total, page, limit, totalPages)
+}
+
+// UnreadCount returns the number of unread notifications
+func (h *NotificationHandlers) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID, projectID, ok := parseInboxRoute(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.repo.UnreadCount(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, UnreadCountResponse{Unread: count})
+}
+
+// MarkRead marks one of the user's notifications read
+func (h *NotificationHandlers) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, projectID, ok := parseInboxRoute(w, r)
+	if !ok {
+		return
+	}
+
+	notificationID, err := uuid.Parse(chi.URLParam(r, "notificationId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_NOTIFICATION_ID", "Invalid notification ID")
+		return
+	}
+
+	if err := h.repo.MarkRead(r.Context(), projectID, userID, notificationID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// MarkAllRead marks all of the user's notifications read
+func (h *NotificationHandlers) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, projectID, ok := parseInboxRoute(w, r)
+	if !ok {
+		return
+	}
+
+	marked, err := h.repo.MarkAllRead(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, MarkAllReadResponse{Marked: marked})
+}
+
+// GetPreferences returns the team member's notification preferences
+func (h *NotificationHandlers) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, projectID, ok := parseInboxRoute(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.repo.GetPreferences(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences changes the team member's notification preferences.
+// Fields left out of the body keep their current values.
+func (h *NotificationHandlers) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, projectID, ok := parseInboxRoute(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.repo.GetPreferences(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := DecodeJSON(r, prefs); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := h.repo.UpdatePreferences(r.Context(), projectID, userID, *prefs); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, prefs)
+}
+
+func parseInboxRoute(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, projectID, true
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func TestNotificationHandlers_List(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - unread page", func(t *testing.T) {
+		repo := repository.NewMockInboxRepository()
+		h := NewNotificationHandlers(repo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		notifications := []domain.Notification{{
+			ID:               uuid.New(),
+			UserID:           userID,
+			ProjectID:        projectID,
+			NotificationType: domain.NotificationTypeMention,
+		}}
+
+		repo.On("List", mock.Anything, projectID, userID, true, 10, 10).Return(notifications, 11, nil)
+
+		req := httptest.NewRequest("GET", "/notifications?unread=true&limit=10&offset=10", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		rr := httptest.NewRecorder()
+		h.List(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"notification_type":"mention"`)
+		assert.Contains(t, rr.Body.String(), `"page":2`)
+		assert.Contains(t, rr.Body.String(), `"total_pages":2`)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success - empty inbox", func(t *testing.T) {
+		repo := repository.NewMockInboxRepository()
+		h := NewNotificationHandlers(repo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+
+		repo.On("List", mock.Anything, projectID, userID, false, 20, 0).Return(nil, 0, nil)
+
+		req := httptest.NewRequest("GET", "/notifications", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		rr := httptest.NewRecorder()
+		h.List(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"data":[]`)
+	})
+
+	t.Run("unauthorized - no user ID", func(t *testing.T) {
+		h := NewNotificationHandlers(repository.NewMockInboxRepository(), logger)
+
+		req := httptest.NewRequest("GET", "/notifications", nil)
+		req = setupTestContext(req, map[string]string{"projectId": uuid.NewString()})
+		rr := httptest.NewRecorder()
+		h.List(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestNotificationHandlers_MarkRead(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockInboxRepository()
+		h := NewNotificationHandlers(repo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		notificationID := uuid.New()
+
+		repo.On("MarkRead", mock.Anything, projectID, userID, notificationID).Return(nil)
+
+		req := httptest.NewRequest("POST", "/read", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "notificationId": notificationID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		rr := httptest.NewRecorder()
+		h.MarkRead(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not found - someone else's notification", func(t *testing.T) {
+		repo := repository.NewMockInboxRepository()
+		h := NewNotificationHandlers(repo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		notificationID := uuid.New()
+
+		repo.On("MarkRead", mock.Anything, projectID, userID, notificationID).Return(domain.ErrNotificationNotFound)
+
+		req := httptest.NewRequest("POST", "/read", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "notificationId": notificationID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		rr := httptest.NewRecorder()
+		h.MarkRead(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestNotificationHandlers_MarkAllReadAndUnreadCount(t *testing.T) {
+	repo := repository.NewMockInboxRepository()
+	h := NewNotificationHandlers(repo, zerolog.Nop())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	repo.On("MarkAllRead", mock.Anything, projectID, userID).Return(3, nil)
+	repo.On("UnreadCount", mock.Anything, projectID, userID).Return(0, nil)
+
+	req := httptest.NewRequest("POST", "/read-all", nil)
+	req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+	req = withAuthContext(req, userID, "test@example.com")
+	rr := httptest.NewRecorder()
+	h.MarkAllRead(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"marked":3}`, rr.Body.String())
+
+	req = httptest.NewRequest("GET", "/unread-count", nil)
+	req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+	req = withAuthContext(req, userID, "test@example.com")
+	rr = httptest.NewRecorder()
+	h.UnreadCount(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"unread":0}`, rr.Body.String())
+}
+
+func TestNotificationHandlers_UpdatePreferences(t *testing.T) {
+	repo := repository.NewMockInboxRepository()
+	h := NewNotificationHandlers(repo, zerolog.Nop())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	current := domain.DefaultNotificationPreferences()
+
+	want := domain.DefaultNotificationPreferences()
+	want.Mentions = false
+	want.Inbox.SavedViewMatches = false
+
+	repo.On("GetPreferences", mock.Anything, projectID, userID).Return(&current, nil)
+	repo.On("UpdatePreferences", mock.Anything, projectID, userID, want).Return(nil)
+
+	body := `{"mentions": false, "inbox": {"saved_view_matches": false}}`
+	req := httptest.NewRequest("PATCH", "/notifications/preferences", strings.NewReader(body))
+	req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+	req = withAuthContext(req, userID, "test@example.com")
+	rr := httptest.NewRecorder()
+	h.UpdatePreferences(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"assignments":true`)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// OrganizationHandlers contains the HTTP handlers for organizations and
+// their members
+type OrganizationHandlers struct {
+	svc    service.OrganizationService
+	logger zerolog.Logger
+}
+
+// NewOrganizationHandlers creates a new OrganizationHandlers instance
+func NewOrganizationHandlers(svc service.OrganizationService, logger zerolog.Logger) *OrganizationHandlers {
+	return &OrganizationHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// List handles GET /creator/organizations
+func (h *OrganizationHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID := auth.MustUserIDFromContext(r.Context())
+
+	orgs, err := h.svc.ListForUser(r.Context(), userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, orgs)
+}
+
+// Create handles POST /creator/organizations. The caller becomes the owner.
+func (h *OrganizationHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID := auth.MustUserIDFromContext(r.Context())
+
+	var req domain.CreateOrganizationRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	org, err := h.svc.Create(r.Context(), userID, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, org)
+}
+
+// Get handles GET /creator/organizations/{orgId}
+func (h *OrganizationHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	member := auth.MustOrganizationMemberFromContext(r.Context())
+
+	org, err := h.svc.Get(r.Context(), member.OrganizationID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, org)
+}
+
+// Update handles PATCH /creator/organizations/{orgId}
+func (h *OrganizationHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	member := auth.MustOrganizationMemberFromContext(r.Context())
+
+	var req domain.UpdateOrganizationRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	org, err := h.svc.Update(r.Context(), member.OrganizationID, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, org)
+}
+
+// ListProjects handles GET /creator/organizations/{orgId}/projects
+func (h *OrganizationHandlers) ListProjects(w http.ResponseWriter, r *http.Request) {
+	member := auth.MustOrganizationMemberFromContext(r.Context())
+
+	projects, err := h.svc.ListProjects(r.Context(), member.OrganizationID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, projects)
+}
+
+// ListMembers handles GET /creator/organizations/{orgId}/members
+func (h *OrganizationHandlers) ListMembers(w http.ResponseWriter, r *http.Request) {
+	member := auth.MustOrganizationMemberFromContext(r.Context())
+
+	members, err := h.svc.ListMembers(r.Context(), member.OrganizationID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, members)
+}
+
+// AddMember handles POST /creator/organizations/{orgId}/members
+func (h *OrganizationHandlers) AddMember(w http.ResponseWriter, r *http.Request) {
+	actor := auth.MustOrganizationMemberFromContext(r.Context())
+
+	var req domain.OrganizationMemberRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(true); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	member, err := h.svc.AddMember(r.Context(), actor, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, member)
+}
+
+// UpdateMember handles PATCH /creator/organizations/{orgId}/members/{userId}
+func (h *OrganizationHandlers) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	actor := auth.MustOrganizationMemberFromContext(r.Context())
+	userID, ok := parseMemberUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.OrganizationMemberRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(false); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	member, err := h.svc.UpdateMemberRole(r.Context(), actor, userID, req.Role)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, member)
+}
+
+// RemoveMember handles DELETE /creator/organizations/{orgId}/members/{userId}
+func (h *OrganizationHandlers) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	actor := auth.MustOrganizationMemberFromContext(r.Context())
+	userID, ok := parseMemberUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RemoveMember(r.Context(), actor, userID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// Search handles GET /creator/organizations/{orgId}/search?q=. It searches
+// feedback titles and descriptions across the organization's projects.
+func (h *OrganizationHandlers) Search(w http.ResponseWriter, r *http.Request) {
+	member := auth.MustOrganizationMemberFromContext(r.Context())
+
+	results, err := h.svc.Search(r.Context(), member.OrganizationID, r.URL.Query().Get("q"))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, results)
+}
+
+func parseMemberUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
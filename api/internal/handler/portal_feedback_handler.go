@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/service"
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+// attachmentURLExpiry is how long portal attachment download links stay valid
+const attachmentURLExpiry = 15 * time.Minute
+
+// PortalFeedbackHandlers contains the portal submission, item and comment HTTP handlers
+type PortalFeedbackHandlers struct {
+	repo        repository.PortalRepository
+	projectRepo repository.ProjectRepository
+	commentSvc  service.CommentService
+	storage     storage.ObjectStorage // Optional; attachments are listed without links when nil
+	logger      zerolog.Logger
+}
+
+// NewPortalFeedbackHandlers creates a new PortalFeedbackHandlers instance
+func NewPortalFeedbackHandlers(
+	repo repository.PortalRepository,
+	projectRepo repository.ProjectRepository,
+	commentSvc service.CommentService,
+	storage storage.ObjectStorage,
+	logger zerolog.Logger,
+) *PortalFeedbackHandlers {
+	return &PortalFeedbackHandlers{
+		repo:        repo,
+		projectRepo: projectRepo,
+		commentSvc:  commentSvc,
+		storage:     storage,
+		logger:      logger,
+	}
+}
+
+// PortalCommentRequest represents the request body for a portal comment
+type PortalCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// Submit creates feedback from the portal. Signed-out submissions are only
+// accepted when the project allows anonymous feedback.
+func (h *PortalFeedbackHandlers) Submit(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	var userID *uuid.UUID
+	if uid, ok := auth.UserIDFromContext(r.Context()); ok {
+		userID = &uid
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if project.ArchivedAt != nil {
+		HandleError(w, domain.ErrProjectNotFound)
+		return
+	}
+
+	if userID == nil && !project.Settings.AllowAnonymousFeedback {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Sign in to submit feedback")
+		return
+	}
+
+	var req domain.PortalSubmission
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(project.Settings, userID == nil); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	feedback := &domain.Feedback{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		Title:       req.Title,
+		Description: req.Description,
+		Type:        req.Type,
+		Status:      domain.StatusNew,
+		Visibility:  project.Settings.GetDefaultVisibility(req.Type),
+		Source:      "portal",
+	}
+
+	if userID != nil {
+		feedback.AuthorID = userID
+		if email, ok := auth.UserEmailFromContext(r.Context()); ok && email != "" {
+			feedback.SubmitterEmail = &email
+		}
+	} else {
+		anonymous := "anonymous"
+		feedback.SubmitterIdentifier = &anonymous
+		if req.Email != nil && *req.Email != "" {
+			feedback.SubmitterEmail = req.Email
+		}
+		feedback.SubmitterName = req.Name
+	}
+
+	if err := h.repo.CreateFeedback(r.Context(), feedback, userID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, &domain.PortalFeedbackDetail{
+		PortalFeedbackSummary: domain.PortalFeedbackSummary{
+			ID:          feedback.ID,
+			Title:       feedback.Title,
+			Description: feedback.Description,
+			Type:        string(feedback.Type),
+			Status:      string(feedback.Status),
+			CreatedAt:   feedback.CreatedAt,
+			UpdatedAt:   feedback.UpdatedAt,
+		},
+		Visibility:    feedback.Visibility,
+		IsMine:        userID != nil,
+		Comments:      []domain.PortalComment{},
+		Attachments:   []domain.PortalAttachment{},
+		StatusHistory: []domain.StatusChange{},
+	})
+}
+
+// Get returns one item with its COMMUNITY comments, attachments and status
+// history. Team-only items are visible only to their submitter.
+func (h *PortalFeedbackHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	detail, _, ok := h.loadFeedback(w, r)
+	if !ok {
+		return
+	}
+
+	var userID *uuid.UUID
+	if uid, ok := auth.UserIDFromContext(r.Context()); ok {
+		userID = &uid
+	}
+
+	comments, err := h.repo.ListComments(r.Context(), detail.ID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	attachments, err := h.repo.ListAttachments(r.Context(), detail.ID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	history, err := h.repo.ListStatusHistory(r.Context(), detail.ID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if h.storage != nil {
+		for i := range attachments {
+			url, err := h.storage.GenerateDownloadURL(r.Context(), attachments[i].StoragePath, attachmentURLExpiry)
+			if err != nil {
+				h.logger.Error().Err(err).
+					Str("attachment_id", attachments[i].ID.String()).
+					Msg("Failed to sign attachment download URL")
+				continue
+			}
+			attachments[i].DownloadURL = url
+		}
+	}
+
+	detail.Comments = comments
+	detail.Attachments = attachments
+	detail.StatusHistory = history
+
+	JSON(w, http.StatusOK, detail)
+}
+
+// Comment adds a COMMUNITY comment from the current user when the project
+// has community comments enabled
+func (h *PortalFeedbackHandlers) Comment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var req PortalCommentRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		ValidationError(w, map[string]string{"body": "is required"})
+		return
+	}
+	if len(body) > domain.MaxPortalCommentLength {
+		ValidationError(w, map[string]string{"body": "is too long"})
+		return
+	}
+
+	detail, projectID, ok := h.loadFeedback(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if !project.Settings.CommunityCommentsEnabled {
+		Error(w, http.StatusForbidden, "COMMENTS_DISABLED", "Comments are disabled for this project")
+		return
+	}
+
+	// Portal users comment as their linked SDK user
+	sdkUserID, authorName, err := h.repo.LinkSDKUser(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	comment, err := h.commentSvc.Create(r.Context(), service.CreateCommentRequest{
+		ProjectID:  projectID,
+		FeedbackID: detail.ID,
+		AuthorID:   userID,
+		SDKUserID:  &sdkUserID,
+		Body:       body,
+		Visibility: domain.VisibilityCommunity,
+	})
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, &domain.PortalComment{
+		ID:         comment.ID,
+		Body:       comment.Body,
+		AuthorName: authorName,
+		IsMine:     true,
+		CreatedAt:  comment.CreatedAt,
+	})
+}
+
+// loadFeedback fetches the route's item and hides items the caller may not
+// see as not found
+func (h *PortalFeedbackHandlers) loadFeedback(w http.ResponseWriter, r *http.Request) (*domain.PortalFeedbackDetail, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return nil, uuid.Nil, false
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return nil, uuid.Nil, false
+	}
+
+	var userID *uuid.UUID
+	if uid, ok := auth.UserIDFromContext(r.Context()); ok {
+		userID = &uid
+	}
+
+	detail, err := h.repo.GetFeedbackDetail(r.Context(), projectID, feedbackID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return nil, uuid.Nil, false
+	}
+
+	if !detail.IsVisibleToPortal() {
+		HandleError(w, domain.ErrFeedbackNotFound)
+		return nil, uuid.Nil, false
+	}
+
+	return detail, projectID, true
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+func testProject(mutate func(*domain.ProjectSettings)) *domain.Project {
+	settings := domain.DefaultProjectSettings()
+	if mutate != nil {
+		mutate(&settings)
+	}
+	return &domain.Project{ID: uuid.New(), Name: "Test", Settings: settings}
+}
+
+func TestPortalFeedbackHandlers_Submit(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - signed in user gets default visibility", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(nil)
+		userID := uuid.New()
+
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+		mockRepo.On("CreateFeedback", mock.Anything, mock.MatchedBy(func(f *domain.Feedback) bool {
+			return f.ProjectID == project.ID && f.Type == domain.FeedbackTypeBug &&
+				f.Visibility == domain.VisibilityTeamOnly && f.AuthorID != nil && *f.AuthorID == userID &&
+				f.SubmitterEmail != nil && *f.SubmitterEmail == "test@example.com" && f.Source == "portal"
+		}), &userID).Return(nil)
+
+		body := `{"title":"Crash on save","description":"It crashes","type":"bug"}`
+		req := httptest.NewRequest("POST", "/feedback", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Submit(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized - anonymous feedback disabled", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) { s.AllowAnonymousFeedback = false })
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+
+		body := `{"title":"Idea","description":"Do it"}`
+		req := httptest.NewRequest("POST", "/feedback", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Submit(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockRepo.AssertNotCalled(t, "CreateFeedback", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation error - anonymous without required email", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) { s.RequireEmailForAnonymous = true })
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+
+		body := `{"title":"Idea","description":"Do it"}`
+		req := httptest.NewRequest("POST", "/feedback", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Submit(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success - anonymous submission with email", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) { s.RequireEmailForAnonymous = true })
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+		mockRepo.On("CreateFeedback", mock.Anything, mock.MatchedBy(func(f *domain.Feedback) bool {
+			return f.AuthorID == nil && f.SubmitterEmail != nil && *f.SubmitterEmail == "anon@example.com" &&
+				f.Type == domain.FeedbackTypeFeature && f.Visibility == domain.VisibilityCommunity
+		}), (*uuid.UUID)(nil)).Return(nil)
+
+		body := `{"title":"Idea","description":"Do it","email":" anon@example.com "}`
+		req := httptest.NewRequest("POST", "/feedback", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Submit(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error - type not accepted by the portal", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) {
+			s.Branding.AcceptedTypes = []domain.FeedbackType{domain.FeedbackTypeFeature}
+		})
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+
+		body := `{"title":"Crash","description":"It crashes","type":"bug"}`
+		req := httptest.NewRequest("POST", "/feedback", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Submit(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockRepo.AssertNotCalled(t, "CreateFeedback", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - missing type defaults to an accepted one", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) {
+			s.Branding.AcceptedTypes = []domain.FeedbackType{domain.FeedbackTypeBug}
+		})
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+		mockRepo.On("CreateFeedback", mock.Anything, mock.MatchedBy(func(f *domain.Feedback) bool {
+			return f.Type == domain.FeedbackTypeBug
+		}), (*uuid.UUID)(nil)).Return(nil)
+
+		body := `{"title":"Crash","description":"It crashes"}`
+		req := httptest.NewRequest("POST", "/feedback", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Submit(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPortalFeedbackHandlers_Get(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("not found - team only item from someone else", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, nil, nil, nil, logger)
+
+		projectID := uuid.New()
+		detail := &domain.PortalFeedbackDetail{Visibility: domain.VisibilityTeamOnly}
+		detail.ID = uuid.New()
+
+		mockRepo.On("GetFeedbackDetail", mock.Anything, projectID, detail.ID, (*uuid.UUID)(nil)).Return(detail, nil)
+
+		req := httptest.NewRequest("GET", "/feedback/"+detail.ID.String(), nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "feedbackId": detail.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Get(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockRepo.AssertNotCalled(t, "ListComments", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - includes comments, attachments and history", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, nil, nil, nil, logger)
+
+		projectID := uuid.New()
+		userID := uuid.New()
+		detail := &domain.PortalFeedbackDetail{Visibility: domain.VisibilityCommunity}
+		detail.ID = uuid.New()
+
+		mockRepo.On("GetFeedbackDetail", mock.Anything, projectID, detail.ID, &userID).Return(detail, nil)
+		mockRepo.On("ListComments", mock.Anything, detail.ID, &userID).Return([]domain.PortalComment{{ID: uuid.New(), Body: "+1"}}, nil)
+		mockRepo.On("ListAttachments", mock.Anything, detail.ID).Return([]domain.PortalAttachment{}, nil)
+		mockRepo.On("ListStatusHistory", mock.Anything, detail.ID).Return([]domain.StatusChange{{To: domain.StatusPlanned}}, nil)
+
+		req := httptest.NewRequest("GET", "/feedback/"+detail.ID.String(), nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "feedbackId": detail.ID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Get(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"body":"+1"`)
+		assert.Contains(t, rr.Body.String(), `"to":"planned"`)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPortalFeedbackHandlers_Comment(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("forbidden - community comments disabled", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, nil, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) { s.CommunityCommentsEnabled = false })
+		userID := uuid.New()
+		detail := &domain.PortalFeedbackDetail{Visibility: domain.VisibilityCommunity}
+		detail.ID = uuid.New()
+
+		mockRepo.On("GetFeedbackDetail", mock.Anything, project.ID, detail.ID, &userID).Return(detail, nil)
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+
+		req := httptest.NewRequest("POST", "/comments", bytes.NewBufferString(`{"body":"Me too"}`))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String(), "feedbackId": detail.ID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Comment(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockRepo.AssertNotCalled(t, "LinkSDKUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - creates comment as the linked SDK user", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		comments := &recordingCommentService{}
+		h := NewPortalFeedbackHandlers(mockRepo, projectRepo, comments, nil, logger)
+
+		project := testProject(nil)
+		userID, sdkUserID := uuid.New(), uuid.New()
+		name := "Pat"
+		detail := &domain.PortalFeedbackDetail{Visibility: domain.VisibilityCommunity}
+		detail.ID = uuid.New()
+
+		mockRepo.On("GetFeedbackDetail", mock.Anything, project.ID, detail.ID, &userID).Return(detail, nil)
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+		mockRepo.On("LinkSDKUser", mock.Anything, project.ID, userID).Return(sdkUserID, &name, nil)
+
+		req := httptest.NewRequest("POST", "/comments", bytes.NewBufferString(`{"body":"  Me too @sam "}`))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String(), "feedbackId": detail.ID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Comment(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"author_name":"Pat"`)
+		assert.Contains(t, rr.Body.String(), `"is_mine":true`)
+		mockRepo.AssertExpectations(t)
+
+		require.Len(t, comments.created, 1, "mentions are parsed by the comment service")
+		assert.Equal(t, service.CreateCommentRequest{
+			ProjectID:  project.ID,
+			FeedbackID: detail.ID,
+			AuthorID:   userID,
+			SDKUserID:  &sdkUserID,
+			Body:       "Me too @sam",
+			Visibility: domain.VisibilityCommunity,
+		}, comments.created[0])
+	})
+}
+
+// recordingCommentService records the comments it is asked to create
+type recordingCommentService struct {
+	service.CommentService
+	created []service.CreateCommentRequest
+}
+
+func (s *recordingCommentService) Create(_ context.Context, req service.CreateCommentRequest) (*domain.Comment, error) {
+	s.created = append(s.created, req)
+	return &domain.Comment{
+		ID:         uuid.New(),
+		FeedbackID: req.FeedbackID,
+		AuthorID:   req.AuthorID,
+		SDKUserID:  req.SDKUserID,
+		Body:       req.Body,
+		Visibility: req.Visibility,
+		CreatedAt:  time.Now(),
+	}, nil
+}
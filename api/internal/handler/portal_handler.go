@@ -176,7 +176,13 @@ func (h *PortalHandlers) Vote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.repo.CreateVote(r.Context(), feedbackID, userID, projectID); err != nil {
+	details, err := DecodeVoteDetails(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := h.repo.CreateVote(r.Context(), feedbackID, userID, projectID, details); err != nil {
 		HandleError(w, err)
 		return
 	}
@@ -184,6 +190,29 @@ func (h *PortalHandlers) Vote(w http.ResponseWriter, r *http.Request) {
 	NoContent(w)
 }
 
+// GetVoteBudget returns the user's active votes and how many they have left
+func (h *PortalHandlers) GetVoteBudget(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	usage, err := h.repo.GetVoteBudget(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, usage)
+}
+
 // Unvote removes a vote from a feature request
 func (h *PortalHandlers) Unvote(w http.ResponseWriter, r *http.Request) {
 	userID, ok := auth.UserIDFromContext(r.Context())
@@ -256,7 +256,7 @@ func TestPortalHandlers_Vote(t *testing.T) {
 		projectID := uuid.New()
 		feedbackID := uuid.New()
 
-		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID).Return(nil)
+		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID, domain.VoteDetails{}).Return(nil)
 
 		req := httptest.NewRequest("POST", "/portal/"+projectID.String()+"/feature-requests/"+feedbackID.String()+"/vote", nil)
 		req = setupTestContext(req, map[string]string{
@@ -319,7 +319,7 @@ func TestPortalHandlers_Vote(t *testing.T) {
 		projectID := uuid.New()
 		feedbackID := uuid.New()
 
-		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID).Return(domain.ErrNotFound)
+		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID, domain.VoteDetails{}).Return(domain.ErrNotFound)
 
 		req := httptest.NewRequest("POST", "/portal/"+projectID.String()+"/feature-requests/"+feedbackID.String()+"/vote", nil)
 		req = setupTestContext(req, map[string]string{
@@ -334,6 +334,125 @@ func TestPortalHandlers_Vote(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("conflict - vote budget exhausted", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalHandlers(mockRepo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+
+		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID, domain.VoteDetails{}).Return(domain.ErrVoteBudgetExhausted)
+
+		req := httptest.NewRequest("POST", "/portal/"+projectID.String()+"/feature-requests/"+feedbackID.String()+"/vote", nil)
+		req = setupTestContext(req, map[string]string{
+			"projectId":  projectID.String(),
+			"feedbackId": feedbackID.String(),
+		})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Vote(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), "vote_budget_exhausted")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - records importance and note with the vote", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalHandlers(mockRepo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+
+		note := "Blocks our rollout"
+		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID, domain.VoteDetails{
+			Importance: domain.ImportanceCritical,
+			Note:       &note,
+		}).Return(nil).Once()
+
+		body := bytes.NewBufferString(`{"importance": "critical", "note": "  Blocks our rollout "}`)
+		req := httptest.NewRequest("POST", "/portal/"+projectID.String()+"/feature-requests/"+feedbackID.String()+"/vote", body)
+		req = setupTestContext(req, map[string]string{
+			"projectId":  projectID.String(),
+			"feedbackId": feedbackID.String(),
+		})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Vote(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("forbidden - voting disabled", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalHandlers(mockRepo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+
+		mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID, domain.VoteDetails{}).Return(domain.ErrVotingDisabled)
+
+		req := httptest.NewRequest("POST", "/portal/"+projectID.String()+"/feature-requests/"+feedbackID.String()+"/vote", nil)
+		req = setupTestContext(req, map[string]string{
+			"projectId":  projectID.String(),
+			"feedbackId": feedbackID.String(),
+		})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Vote(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "voting_disabled")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPortalHandlers_GetVoteBudget(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - returns remaining votes", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalHandlers(mockRepo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+
+		mockRepo.On("GetVoteBudget", mock.Anything, projectID, userID).Return(domain.NewVoteBudgetUsage(10, 7), nil)
+
+		req := httptest.NewRequest("GET", "/portal/"+projectID.String()+"/me/votes", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.GetVoteBudget(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"budget":10,"used":7,"remaining":3}`, rr.Body.String())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized - no user ID", func(t *testing.T) {
+		mockRepo := repository.NewMockPortalRepository()
+		h := NewPortalHandlers(mockRepo, logger)
+
+		projectID := uuid.New()
+
+		req := httptest.NewRequest("GET", "/portal/"+projectID.String()+"/me/votes", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+
+		rr := httptest.NewRecorder()
+		h.GetVoteBudget(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
 }
 
 func TestPortalHandlers_Unvote(t *testing.T) {
@@ -180,7 +180,7 @@ func TestPortalVotingFlow(t *testing.T) {
 		// Step 1: User votes on a feature
 		mockRepo.On("CreateProfile", mock.Anything, portalUserID, projectID).Return(nil).Once()
 		mockRepo.On("LinkSDKUsersByEmail", mock.Anything, portalUserID, projectID, userEmail).Return(int64(0), nil).Once()
-		mockRepo.On("CreateVote", mock.Anything, feedbackID, portalUserID, projectID).Return(nil).Once()
+		mockRepo.On("CreateVote", mock.Anything, feedbackID, portalUserID, projectID, domain.VoteDetails{}).Return(nil).Once()
 
 		voteReq := httptest.NewRequest("POST", "/portal/"+projectID.String()+"/feature-requests/"+feedbackID.String()+"/vote", nil)
 		voteRec := httptest.NewRecorder()
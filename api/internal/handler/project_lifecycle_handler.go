@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// ProjectLifecycleHandlers contains the HTTP handlers for archiving,
+// restoring, deleting and transferring projects
+type ProjectLifecycleHandlers struct {
+	svc    service.ProjectLifecycleService
+	logger zerolog.Logger
+}
+
+// NewProjectLifecycleHandlers creates a new ProjectLifecycleHandlers instance
+func NewProjectLifecycleHandlers(svc service.ProjectLifecycleService, logger zerolog.Logger) *ProjectLifecycleHandlers {
+	return &ProjectLifecycleHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Archive handles POST /creator/projects/{projectId}/archive
+func (h *ProjectLifecycleHandlers) Archive(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.svc.Archive(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, project)
+}
+
+// Restore handles POST /creator/projects/{projectId}/restore
+func (h *ProjectLifecycleHandlers) Restore(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.svc.Restore(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, project)
+}
+
+// Delete handles DELETE /creator/projects/{projectId}. The project is
+// archived at once and purged when deletion_scheduled_at passes.
+func (h *ProjectLifecycleHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := requireSessionFor(w, r, "Projects can only be deleted from a signed-in session"); !ok {
+		return
+	}
+
+	project, err := h.svc.Delete(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusAccepted, project)
+}
+
+// GetTransfer handles GET /creator/projects/{projectId}/transfer
+func (h *ProjectLifecycleHandlers) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.svc.GetPendingTransfer(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, transfer)
+}
+
+// RequestTransfer handles POST /creator/projects/{projectId}/transfer
+func (h *ProjectLifecycleHandlers) RequestTransfer(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := requireSessionFor(w, r, "Ownership can only be transferred from a signed-in session")
+	if !ok {
+		return
+	}
+
+	var req domain.TransferProjectRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	transfer, err := h.svc.RequestTransfer(r.Context(), projectID, userID, req.UserID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, transfer)
+}
+
+// CancelTransfer handles DELETE /creator/projects/{projectId}/transfer
+func (h *ProjectLifecycleHandlers) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.CancelTransfer(r.Context(), projectID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// AcceptTransfer handles POST /creator/projects/{projectId}/transfer/accept.
+// Only the member the transfer names may accept it.
+func (h *ProjectLifecycleHandlers) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := requireSessionFor(w, r, "Ownership can only be accepted from a signed-in session")
+	if !ok {
+		return
+	}
+
+	transfer, err := h.svc.AcceptTransfer(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, transfer)
+}
+
+func parseProjectID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return uuid.Nil, false
+	}
+	return projectID, true
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// ProjectRoleHandlers contains the HTTP handlers for custom project roles
+// and permissions
+type ProjectRoleHandlers struct {
+	svc    service.ProjectRoleService
+	logger zerolog.Logger
+}
+
+// NewProjectRoleHandlers creates a new ProjectRoleHandlers instance
+func NewProjectRoleHandlers(svc service.ProjectRoleService, logger zerolog.Logger) *ProjectRoleHandlers {
+	return &ProjectRoleHandlers{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// permissionsResponse describes the caller's effective permissions
+type permissionsResponse struct {
+	Role        domain.Role         `json:"role"`
+	CustomRole  *domain.ProjectRole `json:"custom_role,omitempty"`
+	Permissions []domain.Permission `json:"permissions"`
+}
+
+// MyPermissions handles GET /creator/projects/{projectId}/permissions
+func (h *ProjectRoleHandlers) MyPermissions(w http.ResponseWriter, r *http.Request) {
+	membership := auth.MustMembershipFromContext(r.Context())
+
+	JSON(w, http.StatusOK, permissionsResponse{
+		Role:        membership.Role,
+		CustomRole:  membership.CustomRole,
+		Permissions: membership.Permissions(),
+	})
+}
+
+// List handles GET /creator/projects/{projectId}/roles
+func (h *ProjectRoleHandlers) List(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+
+	roles, err := h.svc.List(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, roles)
+}
+
+// Create handles POST /creator/projects/{projectId}/roles
+func (h *ProjectRoleHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	actor := auth.MustMembershipFromContext(r.Context())
+
+	var req domain.ProjectRoleRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(true); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	role, err := h.svc.Create(r.Context(), actor, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, role)
+}
+
+// Update handles PATCH /creator/projects/{projectId}/roles/{roleId}
+func (h *ProjectRoleHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	actor := auth.MustMembershipFromContext(r.Context())
+	roleID, ok := parseRoleID(w, r)
+	if !ok {
+		return
+	}
+
+	var req domain.ProjectRoleRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(false); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	role, err := h.svc.Update(r.Context(), actor, roleID, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, role)
+}
+
+// Delete handles DELETE /creator/projects/{projectId}/roles/{roleId}. Roles
+// still assigned to members can't be deleted.
+func (h *ProjectRoleHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := parseProjectID(w, r)
+	if !ok {
+		return
+	}
+	roleID, ok := parseRoleID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), projectID, roleID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// Assign handles PUT /creator/projects/{projectId}/members/{memberId}/role.
+// A null custom_role_id restores the member's built-in role defaults.
+func (h *ProjectRoleHandlers) Assign(w http.ResponseWriter, r *http.Request) {
+	actor := auth.MustMembershipFromContext(r.Context())
+	membershipID, err := uuid.Parse(chi.URLParam(r, "memberId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_MEMBER_ID", "Invalid member ID")
+		return
+	}
+
+	var req domain.AssignProjectRoleRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	membership, err := h.svc.Assign(r.Context(), actor, membershipID, req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, membership)
+}
+
+func parseRoleID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	roleID, err := uuid.Parse(chi.URLParam(r, "roleId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_ROLE_ID", "Invalid role ID")
+		return uuid.Nil, false
+	}
+	return roleID, true
+}
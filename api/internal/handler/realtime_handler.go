@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// RealtimeHandlers contains the Server-Sent Events stream handlers. The
+// same handlers serve a whole project or, under a feedbackId route, one
+// item.
+type RealtimeHandlers struct {
+	broker    *service.RealtimeBroker
+	heartbeat time.Duration
+	logger    zerolog.Logger
+}
+
+// NewRealtimeHandlers creates a new RealtimeHandlers instance
+func NewRealtimeHandlers(broker *service.RealtimeBroker, logger zerolog.Logger) *RealtimeHandlers {
+	return &RealtimeHandlers{
+		broker:    broker,
+		heartbeat: 25 * time.Second,
+		logger:    logger,
+	}
+}
+
+// TeamStream streams every change to team members
+func (h *RealtimeHandlers) TeamStream(w http.ResponseWriter, r *http.Request) {
+	h.stream(w, r, true)
+}
+
+// PublicStream streams COMMUNITY changes to portal visitors
+func (h *RealtimeHandlers) PublicStream(w http.ResponseWriter, r *http.Request) {
+	h.stream(w, r, false)
+}
+
+func (h *RealtimeHandlers) stream(w http.ResponseWriter, r *http.Request, isTeam bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	var feedbackID *uuid.UUID
+	if param := chi.URLParam(r, "feedbackId"); param != "" {
+		id, err := uuid.Parse(param)
+		if err != nil {
+			Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+			return
+		}
+		feedbackID = &id
+	}
+
+	lastID, err := lastEventID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_LAST_EVENT_ID", "Last-Event-ID must be an event ID")
+		return
+	}
+
+	// Streams outlive the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	// Subscribe before replaying so nothing falls between the two
+	sub := h.broker.Subscribe(projectID, feedbackID, isTeam)
+	defer h.broker.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Events sent while replaying may also be queued on the subscription
+	replayed := make(map[int64]bool)
+	if lastID > 0 {
+		missed, complete, err := h.broker.Replay(r.Context(), sub, lastID)
+		if err != nil {
+			h.logger.Error().Err(err).Str("project_id", projectID.String()).Msg("Failed to replay realtime events")
+			complete = false
+		}
+		if !complete {
+			fmt.Fprintf(w, "event: %s\ndata: {}\n\n", domain.RealtimeReset)
+		}
+		for i := range missed {
+			writeRealtimeEvent(w, &missed[i])
+			replayed[missed[i].ID] = true
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				return // Too slow to keep up; the client resumes on reconnect
+			}
+			if replayed[event.ID] {
+				continue // Already sent while replaying
+			}
+			writeRealtimeEvent(w, &event)
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// lastEventID reads the ID of the last event the client saw, sent by
+// EventSource as Last-Event-ID on reconnect or by other clients as the
+// last_event_id query parameter
+func lastEventID(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event ID %q", value)
+	}
+	return id, nil
+}
+
+func writeRealtimeEvent(w http.ResponseWriter, e *domain.RealtimeEvent) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.EventType, data)
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+func TestRealtimeHandlers_Stream(t *testing.T) {
+	logger := zerolog.Nop()
+	projectID := uuid.New()
+	feedbackID := uuid.New()
+
+	event := func(id int64, visibility domain.Visibility) domain.RealtimeEvent {
+		return domain.RealtimeEvent{
+			ID:         id,
+			ProjectID:  projectID,
+			FeedbackID: &feedbackID,
+			EventType:  domain.RealtimeCommentCreated,
+			Visibility: visibility,
+		}
+	}
+
+	t.Run("resumes after Last-Event-ID without team-only changes", func(t *testing.T) {
+		repo := repository.NewMockRealtimeRepository()
+		broker := service.NewRealtimeBroker(repo)
+		h := NewRealtimeHandlers(broker, logger)
+
+		// Live events arrive while the handler replays; the stream ends
+		// once they have been delivered
+		repo.On("ListSince", mock.Anything, projectID, int64(5), mock.Anything).
+			Run(func(mock.Arguments) {
+				broker.Publish(event(7, domain.VisibilityCommunity))
+				broker.Publish(event(8, domain.VisibilityTeamOnly))
+				broker.Publish(event(9, domain.VisibilityCommunity))
+				broker.DisconnectAll()
+			}).
+			Return([]domain.RealtimeEvent{event(6, domain.VisibilityTeamOnly), event(7, domain.VisibilityCommunity)}, true, nil)
+
+		req := httptest.NewRequest("GET", "/events", nil)
+		req.Header.Set("Last-Event-ID", "5")
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		rr := httptest.NewRecorder()
+		h.PublicStream(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+
+		body := rr.Body.String()
+		assert.Equal(t, 1, strings.Count(body, "id: 7\n"))
+		assert.Contains(t, body, "id: 9\nevent: comment_created\n")
+		assert.NotContains(t, body, "id: 6\n")
+		assert.NotContains(t, body, "id: 8\n")
+		assert.NotContains(t, body, "event: reset")
+	})
+
+	t.Run("only skips live events that were replayed", func(t *testing.T) {
+		repo := repository.NewMockRealtimeRepository()
+		broker := service.NewRealtimeBroker(repo)
+		h := NewRealtimeHandlers(broker, logger)
+
+		repo.On("ListSince", mock.Anything, projectID, int64(5), mock.Anything).
+			Run(func(mock.Arguments) {
+				broker.Publish(event(9, domain.VisibilityCommunity))
+				broker.Publish(event(8, domain.VisibilityCommunity))
+				broker.DisconnectAll()
+			}).
+			Return([]domain.RealtimeEvent{event(9, domain.VisibilityCommunity)}, true, nil)
+
+		req := httptest.NewRequest("GET", "/events", nil)
+		req.Header.Set("Last-Event-ID", "5")
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		rr := httptest.NewRecorder()
+		h.PublicStream(rr, req)
+
+		body := rr.Body.String()
+		assert.Equal(t, 1, strings.Count(body, "id: 9\n"))
+		assert.Equal(t, 1, strings.Count(body, "id: 8\n"))
+	})
+
+	t.Run("asks the client to reload when events were pruned", func(t *testing.T) {
+		repo := repository.NewMockRealtimeRepository()
+		broker := service.NewRealtimeBroker(repo)
+		h := NewRealtimeHandlers(broker, logger)
+
+		repo.On("ListSince", mock.Anything, projectID, int64(1), mock.Anything).
+			Run(func(mock.Arguments) { broker.DisconnectAll() }).
+			Return(nil, false, nil)
+
+		req := httptest.NewRequest("GET", "/events?last_event_id=1", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "feedbackId": feedbackID.String()})
+		rr := httptest.NewRecorder()
+		h.TeamStream(rr, req)
+
+		assert.Contains(t, rr.Body.String(), "event: reset\n")
+	})
+
+	t.Run("bad request - invalid Last-Event-ID", func(t *testing.T) {
+		h := NewRealtimeHandlers(service.NewRealtimeBroker(repository.NewMockRealtimeRepository()), logger)
+
+		req := httptest.NewRequest("GET", "/events", nil)
+		req.Header.Set("Last-Event-ID", "abc")
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		rr := httptest.NewRecorder()
+		h.PublicStream(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// SavedViewHandlers contains the creator saved view HTTP handlers
+type SavedViewHandlers struct {
+	repo   repository.SavedViewRepository
+	logger zerolog.Logger
+}
+
+// NewSavedViewHandlers creates a new SavedViewHandlers instance
+func NewSavedViewHandlers(repo repository.SavedViewRepository, logger zerolog.Logger) *SavedViewHandlers {
+	return &SavedViewHandlers{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateSavedViewRequest represents the request body for creating a view
+type CreateSavedViewRequest struct {
+	Name   string                 `json:"name"`
+	Shared bool                   `json:"shared"`
+	Notify bool                   `json:"notify"`
+	Filter domain.SavedViewFilter `json:"filter"`
+}
+
+// UpdateSavedViewRequest represents the request body for updating a view
+type UpdateSavedViewRequest struct {
+	Name     *string                 `json:"name,omitempty"`
+	Shared   *bool                   `json:"shared,omitempty"`
+	Notify   *bool                   `json:"notify,omitempty"`
+	Filter   *domain.SavedViewFilter `json:"filter,omitempty"`
+	Position *int                    `json:"position,omitempty"`
+}
+
+// List returns the user's own views followed by views shared with the team
+func (h *SavedViewHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	views, err := h.repo.ListVisible(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if views == nil {
+		views = []domain.SavedView{}
+	}
+
+	JSON(w, http.StatusOK, views)
+}
+
+// Create saves a new view for the current user
+func (h *SavedViewHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	var req CreateSavedViewRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	view := domain.NewSavedView(projectID, userID, req.Name, req.Shared, req.Filter)
+	view.Notify = req.Notify
+	if err := view.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+	if view.Shared && !canShareViews(r) {
+		Error(w, http.StatusForbidden, "FORBIDDEN", "Sharing views requires triage access")
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), view); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, view)
+}
+
+// Update modifies a view owned by the current user
+func (h *SavedViewHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	view, ok := h.loadView(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if !view.CanEdit(userID) {
+		Error(w, http.StatusForbidden, "FORBIDDEN", "Only the owner can modify this view")
+		return
+	}
+
+	var req UpdateSavedViewRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if req.Name != nil {
+		view.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Shared != nil {
+		// Only publishing needs triage access; owners may still edit or
+		// unshare views they shared before
+		if *req.Shared && !canShareViews(r) {
+			Error(w, http.StatusForbidden, "FORBIDDEN", "Sharing views requires triage access")
+			return
+		}
+		view.Shared = *req.Shared
+	}
+	if req.Notify != nil {
+		view.Notify = *req.Notify
+	}
+	if req.Filter != nil {
+		view.Filter = *req.Filter
+	}
+	if req.Position != nil {
+		view.Position = *req.Position
+	}
+
+	if err := view.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	if err := h.repo.Update(r.Context(), view); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, view)
+}
+
+// Delete removes a view owned by the current user
+func (h *SavedViewHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	view, ok := h.loadView(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if !view.CanEdit(userID) {
+		Error(w, http.StatusForbidden, "FORBIDDEN", "Only the owner can delete this view")
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), view.ID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// Counts returns total and new-since-last-visit counts for the sidebar badges
+func (h *SavedViewHandlers) Counts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	counts, err := h.repo.Counts(r.Context(), projectID, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if counts == nil {
+		counts = []domain.SavedViewCount{}
+	}
+
+	JSON(w, http.StatusOK, counts)
+}
+
+// MarkSeen records that the current user opened a view, clearing its new badge
+func (h *SavedViewHandlers) MarkSeen(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	view, ok := h.loadView(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.MarkSeen(r.Context(), view.ID, userID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// canShareViews reports whether the caller may publish views to the whole
+// team. Personal views only need read access to the board.
+func canShareViews(r *http.Request) bool {
+	membership, ok := auth.MembershipFromContext(r.Context())
+	return ok && membership.HasPermission(domain.PermissionFeedbackTriage)
+}
+
+// loadView fetches the view from the URL. Views from other projects and
+// other users' private views are reported as not found.
+func (h *SavedViewHandlers) loadView(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (*domain.SavedView, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return nil, false
+	}
+
+	viewID, err := uuid.Parse(chi.URLParam(r, "viewId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_VIEW_ID", "Invalid view ID")
+		return nil, false
+	}
+
+	view, err := h.repo.GetByID(r.Context(), viewID)
+	if err != nil {
+		HandleError(w, err)
+		return nil, false
+	}
+
+	if view.ProjectID != projectID || !view.IsVisibleTo(userID) {
+		HandleError(w, domain.ErrSavedViewNotFound)
+		return nil, false
+	}
+
+	return view, true
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func TestSavedViewHandlers_Create(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - creates view with filter", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *domain.SavedView) bool {
+			return v.OwnerID == userID && v.ProjectID == projectID && v.Name == "Open bugs" &&
+				v.Shared && v.Filter.Type != nil && *v.Filter.Type == domain.FeedbackTypeBug
+		})).Return(nil)
+
+		body := `{"name":"  Open bugs ","shared":true,"filter":{"type":"bug","sort_by":"vote_count"}}`
+		req := httptest.NewRequest("POST", "/creator/projects/"+projectID.String()+"/views", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		req = req.WithContext(auth.ContextWithMembership(req.Context(), &domain.Membership{ProjectID: projectID, Role: domain.RoleMember}))
+
+		rr := httptest.NewRecorder()
+		h.Create(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("forbidden - viewers can't share views", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		projectID := uuid.New()
+		body := `{"name":"Open bugs","shared":true,"filter":{"type":"bug"}}`
+		req := httptest.NewRequest("POST", "/creator/projects/"+projectID.String()+"/views", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+		req = req.WithContext(auth.ContextWithMembership(req.Context(), &domain.Membership{ProjectID: projectID, Role: domain.RoleViewer}))
+
+		rr := httptest.NewRecorder()
+		h.Create(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation error - invalid sort", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		projectID := uuid.New()
+		body := `{"name":"Mine","filter":{"sort_by":"title"}}`
+		req := httptest.NewRequest("POST", "/creator/projects/"+projectID.String()+"/views", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Create(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSavedViewHandlers_Update(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("forbidden - shared view owned by someone else", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		projectID := uuid.New()
+		view := &domain.SavedView{ID: uuid.New(), ProjectID: projectID, OwnerID: uuid.New(), Name: "Team", Shared: true}
+
+		mockRepo.On("GetByID", mock.Anything, view.ID).Return(view, nil)
+
+		req := httptest.NewRequest("PATCH", "/views", bytes.NewBufferString(`{"name":"Renamed"}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "viewId": view.ID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Update(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found - private view owned by someone else", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		projectID := uuid.New()
+		view := &domain.SavedView{ID: uuid.New(), ProjectID: projectID, OwnerID: uuid.New(), Name: "Private"}
+
+		mockRepo.On("GetByID", mock.Anything, view.ID).Return(view, nil)
+
+		req := httptest.NewRequest("PATCH", "/views", bytes.NewBufferString(`{"name":"Renamed"}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "viewId": view.ID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Update(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	sharedViewRequest := func(view *domain.SavedView, body string, role domain.Role) *http.Request {
+		req := httptest.NewRequest("PATCH", "/views", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": view.ProjectID.String(), "viewId": view.ID.String()})
+		req = withAuthContext(req, view.OwnerID, "test@example.com")
+		return req.WithContext(auth.ContextWithMembership(req.Context(), &domain.Membership{ProjectID: view.ProjectID, Role: role}))
+	}
+
+	t.Run("success - viewers may rename views they shared before", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		view := &domain.SavedView{ID: uuid.New(), ProjectID: uuid.New(), OwnerID: uuid.New(), Name: "Team", Shared: true}
+		mockRepo.On("GetByID", mock.Anything, view.ID).Return(view, nil)
+		mockRepo.On("Update", mock.Anything, view).Return(nil)
+
+		rr := httptest.NewRecorder()
+		h.Update(rr, sharedViewRequest(view, `{"name":"Renamed"}`, domain.RoleViewer))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Renamed", view.Name)
+		assert.True(t, view.Shared)
+	})
+
+	t.Run("success - viewers may unshare views", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		view := &domain.SavedView{ID: uuid.New(), ProjectID: uuid.New(), OwnerID: uuid.New(), Name: "Team", Shared: true}
+		mockRepo.On("GetByID", mock.Anything, view.ID).Return(view, nil)
+		mockRepo.On("Update", mock.Anything, view).Return(nil)
+
+		rr := httptest.NewRecorder()
+		h.Update(rr, sharedViewRequest(view, `{"shared":false}`, domain.RoleViewer))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.False(t, view.Shared)
+	})
+
+	t.Run("forbidden - viewers can't share views", func(t *testing.T) {
+		mockRepo := repository.NewMockSavedViewRepository()
+		h := NewSavedViewHandlers(mockRepo, logger)
+
+		view := &domain.SavedView{ID: uuid.New(), ProjectID: uuid.New(), OwnerID: uuid.New(), Name: "Mine"}
+		mockRepo.On("GetByID", mock.Anything, view.ID).Return(view, nil)
+
+		rr := httptest.NewRecorder()
+		h.Update(rr, sharedViewRequest(view, `{"shared":true}`, domain.RoleViewer))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSavedViewHandlers_Counts(t *testing.T) {
+	logger := zerolog.Nop()
+
+	mockRepo := repository.NewMockSavedViewRepository()
+	h := NewSavedViewHandlers(mockRepo, logger)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	counts := []domain.SavedViewCount{{ViewID: uuid.New(), Total: 12, New: 3}}
+
+	mockRepo.On("Counts", mock.Anything, projectID, userID).Return(counts, nil)
+
+	req := httptest.NewRequest("GET", "/views/counts", nil)
+	req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+	req = withAuthContext(req, userID, "test@example.com")
+
+	rr := httptest.NewRecorder()
+	h.Counts(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []domain.SavedViewCount
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, counts, response)
+}
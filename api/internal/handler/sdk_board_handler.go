@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// SDKUserHeader carries the external ID an SDK user was identified with.
+// Like submitter identifiers it is trusted as sent by the token holder.
+const SDKUserHeader = "X-SDK-User"
+
+// SDKBoardHandlers contains the SDK voting board HTTP handlers used by
+// in-app widgets
+type SDKBoardHandlers struct {
+	repo        repository.SDKBoardRepository
+	projectRepo repository.ProjectRepository
+	voteSvc     service.VoteService
+	logger      zerolog.Logger
+}
+
+// NewSDKBoardHandlers creates a new SDKBoardHandlers instance
+func NewSDKBoardHandlers(
+	repo repository.SDKBoardRepository,
+	projectRepo repository.ProjectRepository,
+	voteSvc service.VoteService,
+	logger zerolog.Logger,
+) *SDKBoardHandlers {
+	return &SDKBoardHandlers{
+		repo:        repo,
+		projectRepo: projectRepo,
+		voteSvc:     voteSvc,
+		logger:      logger,
+	}
+}
+
+// ListFeatures returns the project's COMMUNITY feature requests. has_voted
+// is set when the request names an identified SDK user.
+func (h *SDKBoardHandlers) ListFeatures(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := auth.SDKProjectFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "SDK authentication required")
+		return
+	}
+
+	sdkUserID, ok := h.optionalSDKUser(w, r, projectID)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	feedback, total, err := h.repo.ListFeatures(r.Context(), projectID, sdkUserID, limit, offset)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if feedback == nil {
+		feedback = []domain.PortalFeedbackSummary{}
+	}
+
+	page := (offset / limit) + 1
+	totalPages := (total + limit - 1) / limit
+
+	Paginated(w, feedback, total, page, limit, totalPages)
+}
+
+// GetFeature returns one item. Team-only items are visible only to the SDK
+// user who submitted them.
+func (h *SDKBoardHandlers) GetFeature(w http.ResponseWriter, r *http.Request) {
+	detail, _, ok := h.loadFeature(w, r)
+	if !ok {
+		return
+	}
+
+	JSON(w, http.StatusOK, detail)
+}
+
+// ListComments returns an item's COMMUNITY comments
+func (h *SDKBoardHandlers) ListComments(w http.ResponseWriter, r *http.Request) {
+	detail, sdkUserID, ok := h.loadFeature(w, r)
+	if !ok {
+		return
+	}
+
+	comments, err := h.repo.ListComments(r.Context(), detail.ID, sdkUserID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, comments)
+}
+
+// Vote adds the identified SDK user's vote to an item
+func (h *SDKBoardHandlers) Vote(w http.ResponseWriter, r *http.Request) {
+	h.changeVote(w, r, true)
+}
+
+// Unvote removes the identified SDK user's vote from an item
+func (h *SDKBoardHandlers) Unvote(w http.ResponseWriter, r *http.Request) {
+	h.changeVote(w, r, false)
+}
+
+// ListMyFeedback returns the feedback the identified SDK user submitted,
+// with its current status
+func (h *SDKBoardHandlers) ListMyFeedback(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := auth.SDKProjectFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "SDK authentication required")
+		return
+	}
+
+	sdkUserID, ok := h.requireSDKUser(w, r, projectID)
+	if !ok {
+		return
+	}
+
+	feedback, err := h.repo.ListMyFeedback(r.Context(), projectID, sdkUserID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, feedback)
+}
+
+// GetVoteBudget returns the identified SDK user's active votes and how many
+// they have left
+func (h *SDKBoardHandlers) GetVoteBudget(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := auth.SDKProjectFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "SDK authentication required")
+		return
+	}
+
+	sdkUserID, ok := h.requireSDKUser(w, r, projectID)
+	if !ok {
+		return
+	}
+
+	usage, err := h.voteSvc.Budget(r.Context(), projectID, domain.SDKUserVoter(sdkUserID))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, usage)
+}
+
+// changeVote adds or removes the SDK user's vote on a visible item when the
+// project has voting enabled
+func (h *SDKBoardHandlers) changeVote(w http.ResponseWriter, r *http.Request, add bool) {
+	projectID, ok := auth.SDKProjectFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "SDK authentication required")
+		return
+	}
+
+	sdkUserID, ok := h.requireSDKUser(w, r, projectID)
+	if !ok {
+		return
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return
+	}
+
+	detail, err := h.repo.GetFeature(r.Context(), projectID, feedbackID, &sdkUserID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if !detail.IsVisibleToPortal() {
+		HandleError(w, domain.ErrFeedbackNotFound)
+		return
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if !project.Settings.VotingEnabled {
+		Error(w, http.StatusForbidden, "VOTING_DISABLED", "Voting is disabled for this project")
+		return
+	}
+
+	voter := domain.SDKUserVoter(sdkUserID)
+	var result *service.VoteResult
+	if add {
+		details, derr := DecodeVoteDetails(r)
+		if derr != nil {
+			HandleError(w, derr)
+			return
+		}
+		result, err = h.voteSvc.Vote(r.Context(), projectID, feedbackID, voter, details)
+	} else {
+		result, err = h.voteSvc.Unvote(r.Context(), projectID, feedbackID, voter)
+	}
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, VoteResultResponse(result))
+}
+
+// loadFeature fetches the route's item for the optional SDK user and hides
+// items they may not see as not found
+func (h *SDKBoardHandlers) loadFeature(w http.ResponseWriter, r *http.Request) (*domain.PortalFeedbackDetail, *uuid.UUID, bool) {
+	projectID, ok := auth.SDKProjectFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "SDK authentication required")
+		return nil, nil, false
+	}
+
+	sdkUserID, ok := h.optionalSDKUser(w, r, projectID)
+	if !ok {
+		return nil, nil, false
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return nil, nil, false
+	}
+
+	detail, err := h.repo.GetFeature(r.Context(), projectID, feedbackID, sdkUserID)
+	if err != nil {
+		HandleError(w, err)
+		return nil, nil, false
+	}
+
+	if !detail.IsVisibleToPortal() {
+		HandleError(w, domain.ErrFeedbackNotFound)
+		return nil, nil, false
+	}
+
+	return detail, sdkUserID, true
+}
+
+// optionalSDKUser resolves the SDK user named in the request, if any.
+// Unknown users are read as anonymous.
+func (h *SDKBoardHandlers) optionalSDKUser(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (*uuid.UUID, bool) {
+	externalID := strings.TrimSpace(r.Header.Get(SDKUserHeader))
+	if externalID == "" {
+		return nil, true
+	}
+
+	id, err := h.repo.GetSDKUserID(r.Context(), projectID, externalID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSDKUserNotFound) {
+			return nil, true
+		}
+		HandleError(w, err)
+		return nil, false
+	}
+
+	return &id, true
+}
+
+// requireSDKUser resolves the SDK user named in the request, who must have
+// been identified before
+func (h *SDKBoardHandlers) requireSDKUser(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	externalID := strings.TrimSpace(r.Header.Get(SDKUserHeader))
+	if externalID == "" {
+		Error(w, http.StatusUnauthorized, "SDK_USER_REQUIRED", "Identify the user and send "+SDKUserHeader)
+		return uuid.Nil, false
+	}
+
+	id, err := h.repo.GetSDKUserID(r.Context(), projectID, externalID)
+	if err != nil {
+		HandleError(w, err)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
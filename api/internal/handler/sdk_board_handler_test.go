@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func sdkRequest(method, target string, projectID uuid.UUID, externalID string, params map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	if externalID != "" {
+		req.Header.Set(SDKUserHeader, externalID)
+	}
+	req = setupTestContext(req, params)
+	return req.WithContext(auth.ContextWithSDKProject(req.Context(), projectID))
+}
+
+func TestSDKBoardHandlers_ListFeatures(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - identified user gets has_voted", func(t *testing.T) {
+		mockRepo := repository.NewMockSDKBoardRepository()
+		h := NewSDKBoardHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+		sdkUserID := uuid.New()
+
+		mockRepo.On("GetSDKUserID", mock.Anything, projectID, "user-42").Return(sdkUserID, nil)
+		mockRepo.On("ListFeatures", mock.Anything, projectID, &sdkUserID, 20, 0).
+			Return([]domain.PortalFeedbackSummary{{ID: uuid.New(), Title: "Dark mode", HasVoted: true}}, 1, nil)
+
+		req := sdkRequest("GET", "/sdk/feature-requests", projectID, "user-42", nil)
+		rr := httptest.NewRecorder()
+		h.ListFeatures(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"has_voted":true`)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - unknown user reads anonymously", func(t *testing.T) {
+		mockRepo := repository.NewMockSDKBoardRepository()
+		h := NewSDKBoardHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+
+		mockRepo.On("GetSDKUserID", mock.Anything, projectID, "stranger").Return(uuid.Nil, domain.ErrSDKUserNotFound)
+		mockRepo.On("ListFeatures", mock.Anything, projectID, (*uuid.UUID)(nil), 20, 0).
+			Return([]domain.PortalFeedbackSummary{}, 0, nil)
+
+		req := sdkRequest("GET", "/sdk/feature-requests", projectID, "stranger", nil)
+		rr := httptest.NewRecorder()
+		h.ListFeatures(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized - no SDK project in context", func(t *testing.T) {
+		h := NewSDKBoardHandlers(repository.NewMockSDKBoardRepository(), nil, nil, logger)
+
+		req := httptest.NewRequest("GET", "/sdk/feature-requests", nil)
+		rr := httptest.NewRecorder()
+		h.ListFeatures(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestSDKBoardHandlers_GetFeature(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("not found - team only item from another user", func(t *testing.T) {
+		mockRepo := repository.NewMockSDKBoardRepository()
+		h := NewSDKBoardHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+		detail := &domain.PortalFeedbackDetail{Visibility: domain.VisibilityTeamOnly}
+		detail.ID = uuid.New()
+
+		mockRepo.On("GetFeature", mock.Anything, projectID, detail.ID, (*uuid.UUID)(nil)).Return(detail, nil)
+
+		req := sdkRequest("GET", "/sdk/feature-requests/"+detail.ID.String(), projectID, "", map[string]string{"feedbackId": detail.ID.String()})
+		rr := httptest.NewRecorder()
+		h.GetFeature(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSDKBoardHandlers_Vote(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("unauthorized - no identified user", func(t *testing.T) {
+		mockRepo := repository.NewMockSDKBoardRepository()
+		h := NewSDKBoardHandlers(mockRepo, nil, nil, logger)
+
+		feedbackID := uuid.New()
+		req := sdkRequest("POST", "/vote", uuid.New(), "", map[string]string{"feedbackId": feedbackID.String()})
+		rr := httptest.NewRecorder()
+		h.Vote(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "SDK_USER_REQUIRED")
+	})
+
+	t.Run("not found - user was never identified", func(t *testing.T) {
+		mockRepo := repository.NewMockSDKBoardRepository()
+		h := NewSDKBoardHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+		mockRepo.On("GetSDKUserID", mock.Anything, projectID, "ghost").Return(uuid.Nil, domain.ErrSDKUserNotFound)
+
+		req := sdkRequest("POST", "/vote", projectID, "ghost", map[string]string{"feedbackId": feedbackID.String()})
+		rr := httptest.NewRecorder()
+		h.Vote(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("forbidden - voting disabled", func(t *testing.T) {
+		mockRepo := repository.NewMockSDKBoardRepository()
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewSDKBoardHandlers(mockRepo, projectRepo, nil, logger)
+
+		project := testProject(func(s *domain.ProjectSettings) { s.VotingEnabled = false })
+		sdkUserID := uuid.New()
+		detail := &domain.PortalFeedbackDetail{Visibility: domain.VisibilityCommunity}
+		detail.ID = uuid.New()
+
+		mockRepo.On("GetSDKUserID", mock.Anything, project.ID, "user-42").Return(sdkUserID, nil)
+		mockRepo.On("GetFeature", mock.Anything, project.ID, detail.ID, &sdkUserID).Return(detail, nil)
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+
+		req := sdkRequest("POST", "/vote", project.ID, "user-42", map[string]string{"feedbackId": detail.ID.String()})
+		rr := httptest.NewRecorder()
+		h.Vote(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestSDKBoardHandlers_ListMyFeedback(t *testing.T) {
+	logger := zerolog.Nop()
+
+	mockRepo := repository.NewMockSDKBoardRepository()
+	h := NewSDKBoardHandlers(mockRepo, nil, nil, logger)
+
+	projectID := uuid.New()
+	sdkUserID := uuid.New()
+
+	mockRepo.On("GetSDKUserID", mock.Anything, projectID, "user-42").Return(sdkUserID, nil)
+	mockRepo.On("ListMyFeedback", mock.Anything, projectID, sdkUserID).
+		Return([]domain.PortalFeedbackSummary{{ID: uuid.New(), Title: "Crash", Status: "planned"}}, nil)
+
+	req := sdkRequest("GET", "/sdk/my-feedback", projectID, "user-42", nil)
+	rr := httptest.NewRecorder()
+	h.ListMyFeedback(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"planned"`)
+	mockRepo.AssertExpectations(t)
+}
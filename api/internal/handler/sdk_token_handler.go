@@ -23,15 +23,17 @@ func NewSDKTokenHandler(db *pgxpool.Pool) *SDKTokenHandler {
 
 // SDKTokenResponse represents an SDK token in API responses
 type SDKTokenResponse struct {
-	ID             uuid.UUID  `json:"id"`
-	Name           string     `json:"name"`
-	Token          string     `json:"token,omitempty"` // Only returned on create
-	TokenPrefix    string     `json:"token_prefix"`
-	AllowedOrigins []string   `json:"allowed_origins"`
-	RateLimit      int        `json:"rate_limit"`
-	IsActive       bool       `json:"is_active"`
-	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	Token            string     `json:"token,omitempty"` // Only returned on create
+	TokenPrefix      string     `json:"token_prefix"`
+	AllowedOrigins   []string   `json:"allowed_origins"`
+	AllowAnyOrigin   bool       `json:"allow_any_origin"`
+	AllowedBundleIDs []string   `json:"allowed_bundle_ids"`
+	RateLimit        int        `json:"rate_limit"`
+	IsActive         bool       `json:"is_active"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
 
 // List handles GET /creator/projects/{projectId}/sdk-tokens
@@ -43,8 +45,8 @@ func (h *SDKTokenHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := `
-		SELECT id, name, token_prefix, allowed_origins, rate_limit_per_minute,
-		       is_active, last_used_at, created_at
+		SELECT id, name, token_prefix, allowed_origins, allow_any_origin,
+		       allowed_bundle_ids, rate_limit_per_minute, is_active, last_used_at, created_at
 		FROM sdk_tokens
 		WHERE project_id = $1 AND revoked_at IS NULL
 		ORDER BY created_at DESC
@@ -68,6 +70,8 @@ func (h *SDKTokenHandler) List(w http.ResponseWriter, r *http.Request) {
 			&token.Name,
 			&token.TokenPrefix,
 			&allowedOrigins,
+			&token.AllowAnyOrigin,
+			&token.AllowedBundleIDs,
 			&token.RateLimit,
 			&token.IsActive,
 			&lastUsedAt,
@@ -82,6 +86,9 @@ func (h *SDKTokenHandler) List(w http.ResponseWriter, r *http.Request) {
 		if allowedOrigins == nil {
 			token.AllowedOrigins = []string{}
 		}
+		if token.AllowedBundleIDs == nil {
+			token.AllowedBundleIDs = []string{}
+		}
 		token.LastUsedAt = lastUsedAt
 		tokens = append(tokens, token)
 	}
@@ -97,9 +104,39 @@ func (h *SDKTokenHandler) List(w http.ResponseWriter, r *http.Request) {
 type CreateSDKTokenRequest struct {
 	Name               string   `json:"name"`
 	AllowedOrigins     []string `json:"allowed_origins"`
+	AllowAnyOrigin     bool     `json:"allow_any_origin"`
+	AllowedBundleIDs   []string `json:"allowed_bundle_ids"`
 	RateLimitPerMinute int      `json:"rate_limit_per_minute"`
 }
 
+// Validate checks the token name and client allow-lists, returning field
+// errors keyed by JSON field name.
+func (r *CreateSDKTokenRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+	if r.Name == "" {
+		errors["name"] = "Name is required"
+	}
+	if len(r.Name) > 100 {
+		errors["name"] = "Name must be 100 characters or less"
+	}
+	for _, origin := range r.AllowedOrigins {
+		if _, err := auth.ParseOriginPattern(origin); err != nil {
+			errors["allowed_origins"] = err.Error()
+			break
+		}
+	}
+	for _, bundleID := range r.AllowedBundleIDs {
+		if err := auth.ValidateBundleID(bundleID); err != nil {
+			errors["allowed_bundle_ids"] = err.Error()
+			break
+		}
+	}
+	if !r.AllowAnyOrigin && len(r.AllowedOrigins) == 0 && len(r.AllowedBundleIDs) == 0 {
+		errors["allowed_origins"] = "Add at least one origin or bundle ID, or allow any origin"
+	}
+	return errors
+}
+
 // Create handles POST /creator/projects/{projectId}/sdk-tokens
 func (h *SDKTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
@@ -114,15 +151,7 @@ func (h *SDKTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validation
-	errors := make(map[string]string)
-	if req.Name == "" {
-		errors["name"] = "Name is required"
-	}
-	if len(req.Name) > 100 {
-		errors["name"] = "Name must be 100 characters or less"
-	}
-	if len(errors) > 0 {
+	if errors := req.Validate(); len(errors) > 0 {
 		ValidationError(w, errors)
 		return
 	}
@@ -134,6 +163,9 @@ func (h *SDKTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if req.AllowedOrigins == nil {
 		req.AllowedOrigins = []string{}
 	}
+	if req.AllowedBundleIDs == nil {
+		req.AllowedBundleIDs = []string{}
+	}
 
 	// Generate token
 	token, tokenHash, err := auth.GenerateToken()
@@ -150,8 +182,9 @@ func (h *SDKTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var createdAt time.Time
 
 	insertQuery := `
-		INSERT INTO sdk_tokens (project_id, name, token_hash, token_prefix, allowed_origins, rate_limit_per_minute, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, true)
+		INSERT INTO sdk_tokens (project_id, name, token_hash, token_prefix, allowed_origins,
+		                        allow_any_origin, allowed_bundle_ids, rate_limit_per_minute, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, true)
 		RETURNING id, created_at
 	`
 
@@ -163,6 +196,8 @@ func (h *SDKTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 		tokenHash,
 		tokenPrefix,
 		req.AllowedOrigins,
+		req.AllowAnyOrigin,
+		req.AllowedBundleIDs,
 		req.RateLimitPerMinute,
 	).Scan(&tokenID, &createdAt)
 
@@ -173,14 +208,16 @@ func (h *SDKTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Return response with the full token (only time it's shown!)
 	response := SDKTokenResponse{
-		ID:             tokenID,
-		Name:           req.Name,
-		Token:          token, // Only returned on create!
-		TokenPrefix:    tokenPrefix,
-		AllowedOrigins: req.AllowedOrigins,
-		RateLimit:      req.RateLimitPerMinute,
-		IsActive:       true,
-		CreatedAt:      createdAt,
+		ID:               tokenID,
+		Name:             req.Name,
+		Token:            token, // Only returned on create!
+		TokenPrefix:      tokenPrefix,
+		AllowedOrigins:   req.AllowedOrigins,
+		AllowAnyOrigin:   req.AllowAnyOrigin,
+		AllowedBundleIDs: req.AllowedBundleIDs,
+		RateLimit:        req.RateLimitPerMinute,
+		IsActive:         true,
+		CreatedAt:        createdAt,
 	}
 
 	Created(w, response)
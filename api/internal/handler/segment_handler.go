@@ -0,0 +1,410 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// SegmentHandlers contains the customer segment and prioritization HTTP handlers
+type SegmentHandlers struct {
+	repo         repository.SegmentRepository
+	feedbackRepo repository.FeedbackRepository
+	projectRepo  repository.ProjectRepository
+	logger       zerolog.Logger
+}
+
+// NewSegmentHandlers creates a new SegmentHandlers instance
+func NewSegmentHandlers(
+	repo repository.SegmentRepository,
+	feedbackRepo repository.FeedbackRepository,
+	projectRepo repository.ProjectRepository,
+	logger zerolog.Logger,
+) *SegmentHandlers {
+	return &SegmentHandlers{
+		repo:         repo,
+		feedbackRepo: feedbackRepo,
+		projectRepo:  projectRepo,
+		logger:       logger,
+	}
+}
+
+// SegmentRequest represents the request body for creating or replacing a segment
+type SegmentRequest struct {
+	Name        string               `json:"name"`
+	Description *string              `json:"description,omitempty"`
+	Match       domain.SegmentMatch  `json:"match"`
+	Rules       []domain.SegmentRule `json:"rules"`
+}
+
+// List returns the project's segments with their current user counts
+func (h *SegmentHandlers) List(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	segments, err := h.repo.ListByProject(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if segments == nil {
+		segments = []domain.Segment{}
+	}
+
+	counts, err := h.repo.CountUsersBySegment(r.Context(), projectID, segments)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	for i := range segments {
+		count := counts[segments[i].ID]
+		segments[i].UserCount = &count
+	}
+
+	JSON(w, http.StatusOK, segments)
+}
+
+// Create defines a new segment
+func (h *SegmentHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	var req SegmentRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	segment := &domain.Segment{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		Match:       req.Match,
+		Rules:       req.Rules,
+		CreatedBy:   userID,
+	}
+	if err := segment.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), segment); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, segment)
+}
+
+// Get returns a segment with its user count
+func (h *SegmentHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	segment, ok := h.loadSegment(w, r, chi.URLParam(r, "segmentId"))
+	if !ok {
+		return
+	}
+
+	count, err := h.repo.CountUsers(r.Context(), segment)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	segment.UserCount = &count
+
+	JSON(w, http.StatusOK, segment)
+}
+
+// Update replaces a segment's name, description and rules
+func (h *SegmentHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	segment, ok := h.loadSegment(w, r, chi.URLParam(r, "segmentId"))
+	if !ok {
+		return
+	}
+
+	var req SegmentRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	segment.Name = req.Name
+	segment.Description = req.Description
+	segment.Match = req.Match
+	segment.Rules = req.Rules
+	if err := segment.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	if err := h.repo.Update(r.Context(), segment); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, segment)
+}
+
+// Delete removes a segment
+func (h *SegmentHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	segment, ok := h.loadSegment(w, r, chi.URLParam(r, "segmentId"))
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), segment.ID); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	NoContent(w)
+}
+
+// ListFeedback returns feedback submitted or voted on by users in the segment.
+// Accepts the same filters as the creator feedback list plus limit and offset.
+func (h *SegmentHandlers) ListFeedback(w http.ResponseWriter, r *http.Request) {
+	segment, ok := h.loadSegment(w, r, chi.URLParam(r, "segmentId"))
+	if !ok {
+		return
+	}
+
+	viewFilter, fields := ParseFeedbackFilter(r)
+	if fields != nil {
+		ValidationError(w, fields)
+		return
+	}
+
+	limit, offset := 50, 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	filter := repository.FeedbackFilterFromView(viewFilter)
+	filter.Segment = segment
+	filter.Limit = limit
+	filter.Offset = offset
+
+	feedback, total, err := h.feedbackRepo.List(r.Context(), segment.ProjectID, filter)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if feedback == nil {
+		feedback = []domain.Feedback{}
+	}
+
+	page := (offset / limit) + 1
+	totalPages := (total + limit - 1) / limit
+
+	Paginated(w, feedback, total, page, limit, totalPages)
+}
+
+// ListVoters returns an item's voters with their traits and priority weight.
+// An optional segment query parameter restricts the list to that segment.
+func (h *SegmentHandlers) ListVoters(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return
+	}
+
+	var segment *domain.Segment
+	if v := r.URL.Query().Get("segment"); v != "" {
+		s, ok := h.loadSegment(w, r, v)
+		if !ok {
+			return
+		}
+		segment = s
+	}
+
+	feedback, err := h.feedbackRepo.GetByID(r.Context(), feedbackID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if feedback.ProjectID != projectID {
+		HandleError(w, domain.ErrFeedbackNotFound)
+		return
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	voters, err := h.repo.ListVoters(r.Context(), projectID, feedbackID, segment, project.Settings.Prioritization)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, voters)
+}
+
+// Priorities ranks open items by the summed trait weight of their voters and
+// SDK submitter, e.g. total MRR asking for each request. Accepts optional
+// segment and limit query parameters; weight_trait tries another trait
+// without changing the saved settings.
+func (h *SegmentHandlers) Priorities(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed <= 0 || parsed > 200 {
+			ValidationError(w, map[string]string{"limit": "must be between 1 and 200"})
+			return
+		}
+		limit = parsed
+	}
+
+	var segment *domain.Segment
+	if v := r.URL.Query().Get("segment"); v != "" {
+		s, ok := h.loadSegment(w, r, v)
+		if !ok {
+			return
+		}
+		segment = s
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	weights := project.Settings.Prioritization
+	if override := r.URL.Query().Get("weight_trait"); override != "" {
+		weights.WeightTrait = override
+	}
+
+	items, err := h.repo.Priorities(r.Context(), projectID, segment, weights, limit)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"weight_trait":   weights.WeightTrait,
+		"default_weight": weights.DefaultWeight,
+		"items":          items,
+	})
+}
+
+// GetPrioritization returns the project's prioritization settings
+func (h *SegmentHandlers) GetPrioritization(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, project.Settings.Prioritization)
+}
+
+// UpdatePrioritization saves the trait that weights voters, and the weight
+// of voters without it, to the project's settings
+func (h *SegmentHandlers) UpdatePrioritization(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	var req domain.UpdatePrioritizationRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	project, err := h.projectRepo.GetByID(r.Context(), projectID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	req.Apply(&project.Settings.Prioritization)
+	if err := h.projectRepo.Update(r.Context(), project); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, project.Settings.Prioritization)
+}
+
+// loadSegment fetches a segment by ID and checks it belongs to the route's
+// project. Segments in other projects are reported as not found.
+func (h *SegmentHandlers) loadSegment(w http.ResponseWriter, r *http.Request, rawID string) (*domain.Segment, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return nil, false
+	}
+
+	segmentID, err := uuid.Parse(rawID)
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_SEGMENT_ID", "Invalid segment ID")
+		return nil, false
+	}
+
+	segment, err := h.repo.GetByID(r.Context(), segmentID)
+	if err != nil {
+		HandleError(w, err)
+		return nil, false
+	}
+
+	if segment.ProjectID != projectID {
+		HandleError(w, domain.ErrSegmentNotFound)
+		return nil, false
+	}
+
+	return segment, true
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func TestSegmentHandlers_Create(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - creates segment with rules", func(t *testing.T) {
+		mockRepo := repository.NewMockSegmentRepository()
+		h := NewSegmentHandlers(mockRepo, nil, nil, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Segment) bool {
+			return s.ProjectID == projectID && s.CreatedBy == userID && s.Name == "Enterprise" &&
+				s.Match == domain.SegmentMatchAll && len(s.Rules) == 2 && s.Rules[1].Value == float64(500)
+		})).Return(nil)
+
+		body := `{"name":"Enterprise","rules":[{"trait":"plan","op":"eq","value":"enterprise"},{"trait":"mrr","op":"gt","value":500}]}`
+		req := httptest.NewRequest("POST", "/segments", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Create(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error - numeric operator with string value", func(t *testing.T) {
+		mockRepo := repository.NewMockSegmentRepository()
+		h := NewSegmentHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+		body := `{"name":"Big","rules":[{"trait":"mrr","op":"gt","value":"500"}]}`
+		req := httptest.NewRequest("POST", "/segments", bytes.NewBufferString(body))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+		req = withAuthContext(req, uuid.New(), "test@example.com")
+
+		rr := httptest.NewRecorder()
+		h.Create(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSegmentHandlers_Get(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("not found - segment belongs to another project", func(t *testing.T) {
+		mockRepo := repository.NewMockSegmentRepository()
+		h := NewSegmentHandlers(mockRepo, nil, nil, logger)
+
+		segment := &domain.Segment{ID: uuid.New(), ProjectID: uuid.New(), Name: "Other"}
+		mockRepo.On("GetByID", mock.Anything, segment.ID).Return(segment, nil)
+
+		req := httptest.NewRequest("GET", "/segments/"+segment.ID.String(), nil)
+		req = setupTestContext(req, map[string]string{"projectId": uuid.New().String(), "segmentId": segment.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Get(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockRepo.AssertNotCalled(t, "CountUsers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSegmentHandlers_Priorities(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("validation error - limit out of range", func(t *testing.T) {
+		mockRepo := repository.NewMockSegmentRepository()
+		h := NewSegmentHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+		req := httptest.NewRequest("GET", "/priorities?limit=500", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+
+		rr := httptest.NewRecorder()
+		h.Priorities(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSegmentHandlers_List(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - counts users for every segment at once", func(t *testing.T) {
+		mockRepo := repository.NewMockSegmentRepository()
+		h := NewSegmentHandlers(mockRepo, nil, nil, logger)
+
+		projectID := uuid.New()
+		segments := []domain.Segment{
+			{ID: uuid.New(), ProjectID: projectID, Name: "Enterprise"},
+			{ID: uuid.New(), ProjectID: projectID, Name: "Trial"},
+		}
+		mockRepo.On("ListByProject", mock.Anything, projectID).Return(segments, nil)
+		mockRepo.On("CountUsersBySegment", mock.Anything, projectID, segments).
+			Return(map[uuid.UUID]int{segments[0].ID: 12}, nil).Once()
+
+		req := httptest.NewRequest("GET", "/segments", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+
+		rr := httptest.NewRecorder()
+		h.List(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"user_count":12`)
+		assert.Contains(t, rr.Body.String(), `"user_count":0`)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CountUsers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSegmentHandlers_UpdatePrioritization(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - saves the weight trait to project settings", func(t *testing.T) {
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewSegmentHandlers(nil, nil, projectRepo, logger)
+
+		project := testProject(nil)
+		projectRepo.On("GetByID", mock.Anything, project.ID).Return(project, nil)
+		projectRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+			return p.Settings.Prioritization.WeightTrait == "mrr" && p.Settings.Prioritization.DefaultWeight == 1
+		})).Return(nil)
+
+		req := httptest.NewRequest("PATCH", "/prioritization", bytes.NewBufferString(`{"weight_trait":" mrr "}`))
+		req = setupTestContext(req, map[string]string{"projectId": project.ID.String()})
+
+		rr := httptest.NewRecorder()
+		h.UpdatePrioritization(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"weight_trait":"mrr","default_weight":1}`, rr.Body.String())
+		projectRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error - negative default weight", func(t *testing.T) {
+		projectRepo := repository.NewMockProjectRepository()
+		h := NewSegmentHandlers(nil, nil, projectRepo, logger)
+
+		projectID := uuid.New()
+		req := httptest.NewRequest("PATCH", "/prioritization", bytes.NewBufferString(`{"default_weight":-1}`))
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String()})
+
+		rr := httptest.NewRecorder()
+		h.UpdatePrioritization(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		projectRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+// StorageHandlers serves objects from the local storage backend through
+// signed URLs. They are mounted without authentication; the URL signature is
+// the credential.
+type StorageHandlers struct {
+	store  *storage.LocalStorage
+	logger zerolog.Logger
+}
+
+// NewStorageHandlers creates a new StorageHandlers instance
+func NewStorageHandlers(store *storage.LocalStorage, logger zerolog.Logger) *StorageHandlers {
+	return &StorageHandlers{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Download handles GET /storage/*
+func (h *StorageHandlers) Download(w http.ResponseWriter, r *http.Request) {
+	objectPath, ok := objectPathParam(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.store.Verify(http.MethodGet, objectPath, r.URL.Query()); err != nil {
+		SignedURLError(w, err)
+		return
+	}
+
+	f, err := h.store.Open(objectPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			Error(w, http.StatusNotFound, "NOT_FOUND", "Object not found")
+			return
+		}
+		h.logger.Error().Err(err).Str("path", objectPath).Msg("failed to open object")
+		Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to read object")
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		h.logger.Error().Err(err).Str("path", objectPath).Msg("failed to stat object")
+		Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to read object")
+		return
+	}
+
+	// Uploaded files are user content served from the API origin; never let
+	// the browser render them inline
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(objectPath)}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", info.ModTime(), f)
+}
+
+// Upload handles PUT /storage/*, the target of LocalStorage upload URLs
+func (h *StorageHandlers) Upload(w http.ResponseWriter, r *http.Request) {
+	objectPath, ok := objectPathParam(w, r)
+	if !ok {
+		return
+	}
+	grant, err := h.store.Verify(http.MethodPut, objectPath, r.URL.Query())
+	if err != nil {
+		SignedURLError(w, err)
+		return
+	}
+
+	maxBytes := grant.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = domain.MaxAttachmentSize
+	}
+	if !CheckSignedUpload(w, r, grant.ContentType, maxBytes) {
+		return
+	}
+
+	if err := h.store.Upload(r.Context(), objectPath, grant.ContentType, http.MaxBytesReader(w, r.Body, maxBytes)); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			Error(w, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Upload exceeds the signed size limit")
+			return
+		}
+		h.logger.Error().Err(err).Str("path", objectPath).Msg("failed to store object")
+		Error(w, http.StatusInternalServerError, "UPLOAD_FAILED", "Failed to store upload")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// CheckSignedUpload enforces a signed upload's content type and declared
+// size before any of the body is read, writing the error response on failure
+func CheckSignedUpload(w http.ResponseWriter, r *http.Request, contentType string, maxBytes int64) bool {
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != contentType {
+			Error(w, http.StatusUnsupportedMediaType, "INVALID_CONTENT_TYPE", "Content-Type does not match the signed upload")
+			return false
+		}
+	}
+	if maxBytes > 0 && r.ContentLength > maxBytes {
+		Error(w, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Upload exceeds the signed size limit")
+		return false
+	}
+	return true
+}
+
+// SignedURLError writes the response for a failed signed URL check
+func SignedURLError(w http.ResponseWriter, err error) {
+	if errors.Is(err, storage.ErrURLExpired) {
+		Error(w, http.StatusForbidden, "URL_EXPIRED", "This link has expired")
+		return
+	}
+	Error(w, http.StatusForbidden, "INVALID_SIGNATURE", "Invalid signed URL")
+}
+
+// objectPathParam returns the unescaped object path from the route wildcard
+func objectPathParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	objectPath := chi.URLParam(r, "*")
+	if r.URL.RawPath != "" {
+		// chi matches on the escaped path when one is present
+		unescaped, err := url.PathUnescape(objectPath)
+		if err != nil {
+			Error(w, http.StatusBadRequest, "INVALID_PATH", "Invalid object path")
+			return "", false
+		}
+		objectPath = unescaped
+	}
+	if objectPath == "" {
+		Error(w, http.StatusNotFound, "NOT_FOUND", "Object not found")
+		return "", false
+	}
+	return objectPath, true
+}
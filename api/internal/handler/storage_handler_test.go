@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+func newStorageTestServer(t *testing.T) (*storage.LocalStorage, http.Handler) {
+	t.Helper()
+	store, err := storage.NewLocalStorage(t.TempDir(), "http://api.test/storage", storage.NewURLSigner("test-key"))
+	require.NoError(t, err)
+
+	h := NewStorageHandlers(store, zerolog.Nop())
+	r := chi.NewRouter()
+	r.Get("/storage/*", h.Download)
+	r.Put("/storage/*", h.Upload)
+	return store, r
+}
+
+// requestFor turns a signed URL from the store into a request against the test router
+func requestFor(t *testing.T, method, signedURL string, body io.Reader) *http.Request {
+	t.Helper()
+	u, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	return httptest.NewRequest(method, u.RequestURI(), body)
+}
+
+func TestStorageHandlers_UploadAndDownload(t *testing.T) {
+	store, router := newStorageTestServer(t)
+	ctx := context.Background()
+	objectPath := "uploads/abc/my file.png"
+
+	uploadURL, err := store.GenerateUploadURL(ctx, objectPath, "image/png", time.Minute)
+	require.NoError(t, err)
+
+	// Wrong content type is refused before anything is stored
+	req := requestFor(t, http.MethodPut, uploadURL, strings.NewReader("png-bytes"))
+	req.Header.Set("Content-Type", "text/html")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	exists, err := store.Exists(ctx, objectPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	req = requestFor(t, http.MethodPut, uploadURL, strings.NewReader("png-bytes"))
+	req.Header.Set("Content-Type", "image/png")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	downloadURL, err := store.GenerateDownloadURL(ctx, objectPath, time.Minute)
+	require.NoError(t, err)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, requestFor(t, http.MethodGet, downloadURL, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "png-bytes", rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment")
+
+	// The upload URL doesn't grant downloads, and unsigned requests are refused
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, requestFor(t, http.MethodGet, uploadURL, nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/storage/uploads/abc/my%20file.png", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestStorageHandlers_Upload_TooLarge(t *testing.T) {
+	store, router := newStorageTestServer(t)
+	signer := storage.NewURLSigner("test-key")
+	q := signer.Sign(storage.SignedRequest{
+		Method:      http.MethodPut,
+		Resource:    "uploads/big.bin",
+		ContentType: "application/octet-stream",
+		MaxBytes:    4,
+		ExpiresAt:   time.Now().Add(time.Minute),
+	})
+
+	// Declared too large: refused from Content-Length
+	req := httptest.NewRequest(http.MethodPut, "/storage/uploads/big.bin?"+q.Encode(), strings.NewReader("12345"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	// Undeclared length: cut off while streaming
+	req = httptest.NewRequest(http.MethodPut, "/storage/uploads/big.bin?"+q.Encode(), io.MultiReader(strings.NewReader("12345")))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	exists, err := store.Exists(context.Background(), "uploads/big.bin")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// SubscriptionHandlers contains the follow/unfollow HTTP handlers for
+// portal users, SDK-identified users and team members
+type SubscriptionHandlers struct {
+	repo       repository.SubscriptionRepository
+	portalRepo repository.PortalRepository
+	sdkRepo    repository.SDKBoardRepository
+	logger     zerolog.Logger
+}
+
+// NewSubscriptionHandlers creates a new SubscriptionHandlers instance
+func NewSubscriptionHandlers(
+	repo repository.SubscriptionRepository,
+	portalRepo repository.PortalRepository,
+	sdkRepo repository.SDKBoardRepository,
+	logger zerolog.Logger,
+) *SubscriptionHandlers {
+	return &SubscriptionHandlers{
+		repo:       repo,
+		portalRepo: portalRepo,
+		sdkRepo:    sdkRepo,
+		logger:     logger,
+	}
+}
+
+// subscriptionAction is what a subscription request does
+type subscriptionAction int
+
+const (
+	subscriptionGet subscriptionAction = iota
+	subscriptionAdd
+	subscriptionRemove
+)
+
+// subscriptionTarget is the item and follower a request is about
+type subscriptionTarget struct {
+	projectID  uuid.UUID
+	feedbackID uuid.UUID
+	follower   domain.Follower
+}
+
+// PortalGet reports whether the portal user follows an item
+func (h *SubscriptionHandlers) PortalGet(w http.ResponseWriter, r *http.Request) {
+	h.handlePortal(w, r, subscriptionGet)
+}
+
+// PortalSubscribe makes the portal user follow an item they can see
+func (h *SubscriptionHandlers) PortalSubscribe(w http.ResponseWriter, r *http.Request) {
+	h.handlePortal(w, r, subscriptionAdd)
+}
+
+// PortalUnsubscribe stops the portal user following an item
+func (h *SubscriptionHandlers) PortalUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	h.handlePortal(w, r, subscriptionRemove)
+}
+
+// SDKGet reports whether the identified SDK user follows an item
+func (h *SubscriptionHandlers) SDKGet(w http.ResponseWriter, r *http.Request) {
+	h.handleSDK(w, r, subscriptionGet)
+}
+
+// SDKSubscribe makes the identified SDK user follow an item they can see
+func (h *SubscriptionHandlers) SDKSubscribe(w http.ResponseWriter, r *http.Request) {
+	h.handleSDK(w, r, subscriptionAdd)
+}
+
+// SDKUnsubscribe stops the identified SDK user following an item
+func (h *SubscriptionHandlers) SDKUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	h.handleSDK(w, r, subscriptionRemove)
+}
+
+// TeamGet reports whether the team member watches an item
+func (h *SubscriptionHandlers) TeamGet(w http.ResponseWriter, r *http.Request) {
+	h.handleTeam(w, r, subscriptionGet)
+}
+
+// TeamSubscribe makes the team member watch an item
+func (h *SubscriptionHandlers) TeamSubscribe(w http.ResponseWriter, r *http.Request) {
+	h.handleTeam(w, r, subscriptionAdd)
+}
+
+// TeamUnsubscribe stops the team member watching an item
+func (h *SubscriptionHandlers) TeamUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	h.handleTeam(w, r, subscriptionRemove)
+}
+
+// handlePortal resolves the signed-in portal user and hides items they may
+// not see as not found
+func (h *SubscriptionHandlers) handlePortal(w http.ResponseWriter, r *http.Request, action subscriptionAction) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, feedbackID, ok := parseSubscriptionRoute(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.portalRepo.GetFeedbackDetail(r.Context(), projectID, feedbackID, &userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if !detail.IsVisibleToPortal() {
+		HandleError(w, domain.ErrFeedbackNotFound)
+		return
+	}
+
+	h.apply(w, r, subscriptionTarget{projectID, feedbackID, domain.UserFollower(userID)}, action)
+}
+
+// handleSDK resolves the identified SDK user and hides items they may not
+// see as not found
+func (h *SubscriptionHandlers) handleSDK(w http.ResponseWriter, r *http.Request, action subscriptionAction) {
+	projectID, ok := auth.SDKProjectFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "SDK authentication required")
+		return
+	}
+
+	externalID := strings.TrimSpace(r.Header.Get(SDKUserHeader))
+	if externalID == "" {
+		Error(w, http.StatusUnauthorized, "SDK_USER_REQUIRED", "Identify the user and send "+SDKUserHeader)
+		return
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return
+	}
+
+	sdkUserID, err := h.sdkRepo.GetSDKUserID(r.Context(), projectID, externalID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	detail, err := h.sdkRepo.GetFeature(r.Context(), projectID, feedbackID, &sdkUserID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if !detail.IsVisibleToPortal() {
+		HandleError(w, domain.ErrFeedbackNotFound)
+		return
+	}
+
+	h.apply(w, r, subscriptionTarget{projectID, feedbackID, domain.SDKUserFollower(sdkUserID)}, action)
+}
+
+// handleTeam resolves the team member; membership is checked by the route
+// and the item's project by the repository
+func (h *SubscriptionHandlers) handleTeam(w http.ResponseWriter, r *http.Request, action subscriptionAction) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, feedbackID, ok := parseSubscriptionRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.apply(w, r, subscriptionTarget{projectID, feedbackID, domain.UserFollower(userID)}, action)
+}
+
+// apply runs the action and responds with the resulting subscription status
+func (h *SubscriptionHandlers) apply(w http.ResponseWriter, r *http.Request, t subscriptionTarget, action subscriptionAction) {
+	var err error
+	switch action {
+	case subscriptionAdd:
+		err = h.repo.Subscribe(r.Context(), t.projectID, t.feedbackID, t.follower)
+	case subscriptionRemove:
+		err = h.repo.Unsubscribe(r.Context(), t.projectID, t.feedbackID, t.follower)
+	}
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	status, err := h.repo.Get(r.Context(), t.projectID, t.feedbackID, t.follower)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, status)
+}
+
+func parseSubscriptionRoute(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, feedbackID, true
+}
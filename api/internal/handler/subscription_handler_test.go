@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func TestSubscriptionHandlers_Portal(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - follows a community item", func(t *testing.T) {
+		subRepo := repository.NewMockSubscriptionRepository()
+		portalRepo := repository.NewMockPortalRepository()
+		h := NewSubscriptionHandlers(subRepo, portalRepo, nil, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+		follower := domain.UserFollower(userID)
+		manual := domain.SubscriptionManual
+
+		portalRepo.On("GetFeedbackDetail", mock.Anything, projectID, feedbackID, &userID).
+			Return(&domain.PortalFeedbackDetail{Visibility: domain.VisibilityCommunity}, nil)
+		subRepo.On("Subscribe", mock.Anything, projectID, feedbackID, follower).Return(nil)
+		subRepo.On("Get", mock.Anything, projectID, feedbackID, follower).
+			Return(&domain.SubscriptionStatus{FeedbackID: feedbackID, Subscribed: true, Reason: &manual}, nil)
+
+		req := httptest.NewRequest("POST", "/subscription", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "feedbackId": feedbackID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		rr := httptest.NewRecorder()
+		h.PortalSubscribe(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"subscribed":true`)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found - team-only item of someone else", func(t *testing.T) {
+		subRepo := repository.NewMockSubscriptionRepository()
+		portalRepo := repository.NewMockPortalRepository()
+		h := NewSubscriptionHandlers(subRepo, portalRepo, nil, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+
+		portalRepo.On("GetFeedbackDetail", mock.Anything, projectID, feedbackID, &userID).
+			Return(&domain.PortalFeedbackDetail{Visibility: domain.VisibilityTeamOnly}, nil)
+
+		req := httptest.NewRequest("POST", "/subscription", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "feedbackId": feedbackID.String()})
+		req = withAuthContext(req, userID, "test@example.com")
+		rr := httptest.NewRecorder()
+		h.PortalSubscribe(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		subRepo.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unauthorized - no user ID", func(t *testing.T) {
+		h := NewSubscriptionHandlers(nil, nil, nil, logger)
+
+		req := httptest.NewRequest("GET", "/subscription", nil)
+		req = setupTestContext(req, map[string]string{"projectId": uuid.NewString(), "feedbackId": uuid.NewString()})
+		rr := httptest.NewRecorder()
+		h.PortalGet(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestSubscriptionHandlers_SDK(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("success - unfollows", func(t *testing.T) {
+		subRepo := repository.NewMockSubscriptionRepository()
+		sdkRepo := repository.NewMockSDKBoardRepository()
+		h := NewSubscriptionHandlers(subRepo, nil, sdkRepo, logger)
+
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+		sdkUserID := uuid.New()
+		follower := domain.SDKUserFollower(sdkUserID)
+
+		sdkRepo.On("GetSDKUserID", mock.Anything, projectID, "user-42").Return(sdkUserID, nil)
+		sdkRepo.On("GetFeature", mock.Anything, projectID, feedbackID, &sdkUserID).
+			Return(&domain.PortalFeedbackDetail{Visibility: domain.VisibilityCommunity}, nil)
+		subRepo.On("Unsubscribe", mock.Anything, projectID, feedbackID, follower).Return(nil)
+		subRepo.On("Get", mock.Anything, projectID, feedbackID, follower).
+			Return(&domain.SubscriptionStatus{FeedbackID: feedbackID}, nil)
+
+		req := sdkRequest("DELETE", "/sdk/feature-requests/x/subscription", projectID, "user-42",
+			map[string]string{"feedbackId": feedbackID.String()})
+		rr := httptest.NewRecorder()
+		h.SDKUnsubscribe(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"subscribed":false`)
+		subRepo.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized - no SDK user", func(t *testing.T) {
+		h := NewSubscriptionHandlers(nil, nil, nil, logger)
+
+		req := sdkRequest("POST", "/sdk/feature-requests/x/subscription", uuid.New(), "",
+			map[string]string{"feedbackId": uuid.NewString()})
+		rr := httptest.NewRecorder()
+		h.SDKSubscribe(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "SDK_USER_REQUIRED")
+	})
+}
+
+func TestSubscriptionHandlers_Team(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("not found - item in another project", func(t *testing.T) {
+		subRepo := repository.NewMockSubscriptionRepository()
+		h := NewSubscriptionHandlers(subRepo, nil, nil, logger)
+
+		userID := uuid.New()
+		projectID := uuid.New()
+		feedbackID := uuid.New()
+
+		subRepo.On("Subscribe", mock.Anything, projectID, feedbackID, domain.UserFollower(userID)).
+			Return(domain.ErrFeedbackNotFound)
+
+		req := httptest.NewRequest("POST", "/subscription", nil)
+		req = setupTestContext(req, map[string]string{"projectId": projectID.String(), "feedbackId": feedbackID.String()})
+		req = withAuthContext(req, userID, "team@example.com")
+		rr := httptest.NewRecorder()
+		h.TeamSubscribe(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		subRepo.AssertExpectations(t)
+	})
+}
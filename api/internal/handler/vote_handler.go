@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/fulldisclosure/api/internal/auth"
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/service"
+)
+
+// VoteHandlers contains the team-facing vote HTTP handlers
+type VoteHandlers struct {
+	voteSvc service.VoteService
+	logger  zerolog.Logger
+}
+
+// NewVoteHandlers creates a new VoteHandlers instance
+func NewVoteHandlers(voteSvc service.VoteService, logger zerolog.Logger) *VoteHandlers {
+	return &VoteHandlers{
+		voteSvc: voteSvc,
+		logger:  logger,
+	}
+}
+
+// RecordVoteRequest represents the request body for recording a vote on
+// behalf of a customer
+type RecordVoteRequest struct {
+	Customer domain.VoteCustomer `json:"customer"`
+	domain.VoteDetails
+}
+
+// Record adds a vote for a customer, e.g. one taken on a sales call. The
+// customer is an SDK user, created from the external ID or email if needed.
+func (h *VoteHandlers) Record(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_PROJECT_ID", "Invalid project ID")
+		return
+	}
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "feedbackId"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, "INVALID_FEEDBACK_ID", "Invalid feedback ID")
+		return
+	}
+
+	var req RecordVoteRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Customer.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+	req.TrimNote()
+	if err := req.VoteDetails.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	result, err := h.voteSvc.RecordVote(r.Context(), projectID, feedbackID, req.Customer, req.VoteDetails, userID)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	Created(w, VoteResultResponse(result))
+}
+
+// maxVoteBodyBytes bounds the optional body sent with a vote
+const maxVoteBodyBytes = 16 << 10
+
+// DecodeVoteDetails reads the optional importance and note sent with a
+// vote. An empty body means no details.
+func DecodeVoteDetails(r *http.Request) (domain.VoteDetails, error) {
+	var details domain.VoteDetails
+	if r.Body == nil {
+		return details, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxVoteBodyBytes))
+	if err != nil {
+		return details, domain.NewDomainError("INVALID_JSON", "Invalid JSON in request body", 400)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return details, nil
+	}
+
+	if err := json.Unmarshal(body, &details); err != nil {
+		return details, domain.NewDomainError("INVALID_JSON", "Invalid JSON in request body", 400)
+	}
+
+	details.TrimNote()
+	if err := details.Validate(); err != nil {
+		return details, domain.ErrValidation.WithMessage(err.Error())
+	}
+
+	return details, nil
+}
+
+// VoteResultResponse converts a vote service result to its JSON form
+func VoteResultResponse(result *service.VoteResult) domain.VoteResult {
+	return domain.VoteResult{
+		FeedbackID: result.FeedbackID,
+		VoteCount:  result.VoteCount,
+		VoteScore:  result.VoteScore,
+		HasVoted:   result.HasVoted,
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestVoteHandlers_Record(t *testing.T) {
+	logger := zerolog.Nop()
+	params := map[string]string{"projectId": uuid.NewString(), "feedbackId": uuid.NewString()}
+
+	t.Run("unauthorized - no user", func(t *testing.T) {
+		h := NewVoteHandlers(nil, logger)
+
+		req := httptest.NewRequest("POST", "/votes", strings.NewReader(`{"customer":{"email":"a@example.com"}}`))
+		req = setupTestContext(req, params)
+		rr := httptest.NewRecorder()
+		h.Record(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("validation error - no customer", func(t *testing.T) {
+		h := NewVoteHandlers(nil, logger)
+
+		req := httptest.NewRequest("POST", "/votes", strings.NewReader(`{"importance":"critical"}`))
+		req = setupTestContext(req, params)
+		req = withAuthContext(req, uuid.New(), "team@example.com")
+		rr := httptest.NewRecorder()
+		h.Record(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "VALIDATION_ERROR")
+	})
+
+	t.Run("validation error - unknown importance", func(t *testing.T) {
+		h := NewVoteHandlers(nil, logger)
+
+		req := httptest.NewRequest("POST", "/votes", strings.NewReader(`{"customer":{"external_id":"acme-1"},"importance":"urgent"}`))
+		req = setupTestContext(req, params)
+		req = withAuthContext(req, uuid.New(), "team@example.com")
+		rr := httptest.NewRecorder()
+		h.Record(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "importance")
+	})
+}
+
+func TestDecodeVoteDetails(t *testing.T) {
+	t.Run("empty body means no details", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/vote", nil)
+
+		details, err := DecodeVoteDetails(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.VoteDetails{}, details)
+	})
+
+	t.Run("trims the note", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/vote", strings.NewReader(`{"importance":"important","note":"  needed for SSO  "}`))
+
+		details, err := DecodeVoteDetails(req)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportanceImportant, details.Importance)
+		require.NotNil(t, details.Note)
+		assert.Equal(t, "needed for SSO", *details.Note)
+	})
+
+	t.Run("rejects unknown importance", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/vote", strings.NewReader(`{"importance":"urgent"}`))
+
+		_, err := DecodeVoteDetails(req)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
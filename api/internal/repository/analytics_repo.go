@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type analyticsRepository struct {
+	db DBTX
+}
+
+// NewAnalyticsRepository creates a new analytics repository
+func NewAnalyticsRepository(db *pgxpool.Pool) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// analyticsScope builds the shared conditions on the feedback alias f: the
+// project, merged items excluded, and every selected tag present. The
+// returned index is the next free placeholder number.
+func analyticsScope(projectID uuid.UUID, filter domain.AnalyticsFilter) (string, []interface{}, int) {
+	where := "f.project_id = $1 AND f.canonical_id IS NULL"
+	args := []interface{}{projectID}
+	argIndex := 2
+
+	if len(filter.TagIDs) > 0 {
+		tagIDs := uniqueUUIDs(filter.TagIDs)
+		where += fmt.Sprintf(
+			" AND (SELECT COUNT(*) FROM feedback_tags ft WHERE ft.feedback_id = f.id AND ft.tag_id = ANY($%d)) = %d",
+			argIndex, len(tagIDs),
+		)
+		args = append(args, tagIDs)
+		argIndex++
+	}
+
+	return where, args, argIndex
+}
+
+func (r *analyticsRepository) Volume(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.VolumeReport, error) {
+	where, args, n := analyticsScope(projectID, filter)
+
+	query := fmt.Sprintf(`
+		SELECT
+			date_trunc($%d, f.created_at AT TIME ZONE 'UTC') AS bucket,
+			f.type,
+			COALESCE(f.source, 'unknown') AS source,
+			COUNT(*)
+		FROM feedback f
+		WHERE %s AND f.created_at >= $%d AND f.created_at < $%d
+		GROUP BY 1, 2, 3
+	`, n, where, n+1, n+2)
+	args = append(args, string(filter.Interval), filter.From, filter.To)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback volume: %w", err)
+	}
+	defer rows.Close()
+
+	byType := make(map[string]map[time.Time]float64)
+	bySource := make(map[string]map[time.Time]float64)
+	total := 0
+	for rows.Next() {
+		var bucket time.Time
+		var feedbackType, source string
+		var count int
+		if err := rows.Scan(&bucket, &feedbackType, &source, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback volume: %w", err)
+		}
+		bucket = bucket.UTC()
+		addToSeries(byType, feedbackType, bucket, float64(count))
+		addToSeries(bySource, source, bucket, float64(count))
+		total += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feedback volume: %w", err)
+	}
+
+	// Every type gets a series so charts keep a stable legend
+	for _, t := range []domain.FeedbackType{domain.FeedbackTypeBug, domain.FeedbackTypeFeature, domain.FeedbackTypeGeneral} {
+		if _, ok := byType[string(t)]; !ok {
+			byType[string(t)] = map[time.Time]float64{}
+		}
+	}
+
+	buckets := filter.Buckets()
+	return &domain.VolumeReport{
+		Interval: filter.Interval,
+		Total:    total,
+		ByType:   buildSortedSeries(byType, buckets),
+		BySource: buildSortedSeries(bySource, buckets),
+	}, nil
+}
+
+func (r *analyticsRepository) Resolution(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.ResolutionReport, error) {
+	where, args, n := analyticsScope(projectID, filter)
+
+	// Items resolved within the range, grouped per type with a rollup row for all types
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(f.type::text, 'all') AS name,
+			COUNT(*),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM f.resolved_at - f.created_at)) / 3600,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM f.resolved_at - f.created_at)) / 3600
+		FROM feedback f
+		WHERE %s AND f.resolved_at IS NOT NULL AND f.resolved_at >= $%d AND f.resolved_at < $%d
+		GROUP BY ROLLUP (f.type)
+	`, where, n, n+1)
+	args = append(args, filter.From, filter.To)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resolution times: %w", err)
+	}
+	defer rows.Close()
+
+	report := &domain.ResolutionReport{
+		Overall: domain.ResolutionStats{Name: "all"},
+		ByType:  []domain.ResolutionStats{},
+	}
+	for rows.Next() {
+		var stats domain.ResolutionStats
+		if err := rows.Scan(&stats.Name, &stats.Resolved, &stats.MedianHours, &stats.P90Hours); err != nil {
+			return nil, fmt.Errorf("failed to scan resolution times: %w", err)
+		}
+		if stats.Name == "all" {
+			report.Overall = stats
+		} else {
+			report.ByType = append(report.ByType, stats)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read resolution times: %w", err)
+	}
+
+	sort.Slice(report.ByType, func(i, j int) bool { return report.ByType[i].Name < report.ByType[j].Name })
+	return report, nil
+}
+
+func (r *analyticsRepository) Funnel(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.FunnelReport, error) {
+	where, args, n := analyticsScope(projectID, filter)
+
+	query := fmt.Sprintf(`
+		SELECT f.status, COUNT(*)
+		FROM feedback f
+		WHERE %s AND f.created_at >= $%d AND f.created_at < $%d
+		GROUP BY f.status
+	`, where, n, n+1)
+	args = append(args, filter.From, filter.To)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status funnel: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.FeedbackStatus]int)
+	report := &domain.FunnelReport{}
+	for rows.Next() {
+		var status domain.FeedbackStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan status funnel: %w", err)
+		}
+		counts[status] = count
+		report.Total += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read status funnel: %w", err)
+	}
+
+	report.Declined = counts[domain.StatusDeclined]
+	report.Duplicate = counts[domain.StatusDuplicate]
+
+	// Walk the pipeline backwards so each stage includes everything past it
+	report.Stages = make([]domain.FunnelStage, len(domain.FunnelPipeline))
+	reached := 0
+	for i := len(domain.FunnelPipeline) - 1; i >= 0; i-- {
+		status := domain.FunnelPipeline[i]
+		reached += counts[status]
+		report.Stages[i] = domain.FunnelStage{Status: status, Count: counts[status], Reached: reached}
+	}
+
+	return report, nil
+}
+
+func (r *analyticsRepository) TopRequests(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) ([]domain.TopRequest, error) {
+	where, args, n := analyticsScope(projectID, filter)
+
+	query := fmt.Sprintf(`
+		WITH window_votes AS (
+			-- Only the project's votes; $1 is the project in analyticsScope
+			SELECT v.feedback_id, COUNT(*) AS votes
+			FROM votes v
+			JOIN feedback vf ON vf.id = v.feedback_id AND vf.project_id = $1
+			WHERE v.created_at >= $%d AND v.created_at < $%d
+			GROUP BY v.feedback_id
+		)
+		SELECT f.id, f.title, f.type, f.status, f.vote_count, COALESCE(wv.votes, 0), f.created_at
+		FROM feedback f
+		LEFT JOIN window_votes wv ON wv.feedback_id = f.id
+		WHERE %s AND f.status NOT IN ('completed', 'declined', 'duplicate')
+		ORDER BY COALESCE(wv.votes, 0) DESC, f.vote_count DESC, f.created_at DESC
+		LIMIT $%d
+	`, n, n+1, where, n+2)
+	args = append(args, filter.From, filter.To, filter.Limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []domain.TopRequest{}
+	for rows.Next() {
+		var t domain.TopRequest
+		if err := rows.Scan(&t.FeedbackID, &t.Title, &t.Type, &t.Status, &t.VoteCount, &t.VotesInWindow, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan top request: %w", err)
+		}
+		requests = append(requests, t)
+	}
+
+	return requests, rows.Err()
+}
+
+func (r *analyticsRepository) VoteVelocity(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.VoteVelocityReport, error) {
+	where, args, n := analyticsScope(projectID, filter)
+
+	query := fmt.Sprintf(`
+		SELECT date_trunc($%d, v.created_at AT TIME ZONE 'UTC') AS bucket, COUNT(*)
+		FROM votes v
+		JOIN feedback f ON f.id = v.feedback_id
+		WHERE %s AND v.created_at >= $%d AND v.created_at < $%d
+		GROUP BY 1
+	`, n, where, n+1, n+2)
+	args = append(args, string(filter.Interval), filter.From, filter.To)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vote velocity: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[time.Time]float64)
+	total := 0
+	for rows.Next() {
+		var bucket time.Time
+		var count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan vote velocity: %w", err)
+		}
+		values[bucket.UTC()] = float64(count)
+		total += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vote velocity: %w", err)
+	}
+
+	days := filter.To.Sub(filter.From).Hours() / 24
+	return &domain.VoteVelocityReport{
+		Interval:      filter.Interval,
+		Total:         total,
+		AveragePerDay: float64(total) / days,
+		Series:        domain.BuildSeries("votes", filter.Buckets(), values),
+	}, nil
+}
+
+func (r *analyticsRepository) ActiveSDKUsers(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) ([]domain.ActiveSDKUser, error) {
+	where, args, n := analyticsScope(projectID, filter)
+
+	query := fmt.Sprintf(`
+		SELECT su.id, su.external_id, su.email, su.name, COUNT(f.id), MAX(f.created_at)
+		FROM feedback f
+		JOIN sdk_users su ON su.id = f.sdk_user_id
+		WHERE %s AND f.created_at >= $%d AND f.created_at < $%d
+		GROUP BY su.id
+		ORDER BY COUNT(f.id) DESC, MAX(f.created_at) DESC
+		LIMIT $%d
+	`, where, n, n+1, n+2)
+	args = append(args, filter.From, filter.To, filter.Limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active sdk users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []domain.ActiveSDKUser{}
+	for rows.Next() {
+		var u domain.ActiveSDKUser
+		if err := rows.Scan(&u.ID, &u.ExternalID, &u.Email, &u.Name, &u.FeedbackCount, &u.LastSubmitted); err != nil {
+			return nil, fmt.Errorf("failed to scan active sdk user: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+func addToSeries(series map[string]map[time.Time]float64, name string, bucket time.Time, value float64) {
+	if series[name] == nil {
+		series[name] = make(map[time.Time]float64)
+	}
+	series[name][bucket] += value
+}
+
+func buildSortedSeries(series map[string]map[time.Time]float64, buckets []time.Time) []domain.Series {
+	names := make([]string, 0, len(series))
+	for name := range series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]domain.Series, 0, len(names))
+	for _, name := range names {
+		result = append(result, domain.BuildSeries(name, buckets, series[name]))
+	}
+	return result
+}
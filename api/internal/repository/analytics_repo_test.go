@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestAnalyticsRepository_TopRequests(t *testing.T) {
+	projectID := uuid.New()
+	to := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.AnalyticsFilter{From: to.AddDate(0, 0, -30), To: to, Limit: 5}
+	created := to.AddDate(0, -2, 0)
+	hot, steady := uuid.New(), uuid.New()
+
+	db := &fakeDB{results: []fakeResult{{rows: [][]any{
+		{hot, "Dark mode", domain.FeedbackTypeFeature, domain.StatusPlanned, 12, 9, created},
+		{steady, "CSV export", domain.FeedbackTypeFeature, domain.StatusUnderReview, 40, 2, created},
+	}}}}
+
+	got, err := (&analyticsRepository{db: db}).TopRequests(context.Background(), projectID, filter)
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, hot, got[0].FeedbackID)
+	assert.Equal(t, 9, got[0].VotesInWindow)
+	assert.Equal(t, 40, got[1].VoteCount)
+
+	query := db.queries[0]
+	assert.Contains(t, query, "JOIN feedback vf ON vf.id = v.feedback_id AND vf.project_id = $1", "window votes only count the project's votes")
+	assert.Contains(t, query, "v.created_at >= $2 AND v.created_at < $3")
+	assert.Contains(t, query, "ORDER BY COALESCE(wv.votes, 0) DESC, f.vote_count DESC")
+	assert.Equal(t, []any{projectID, filter.From, filter.To, 5}, db.args[0])
+}
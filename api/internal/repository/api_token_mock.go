@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockAPITokenRepository is a mock implementation of APITokenRepository for testing
+type MockAPITokenRepository struct {
+	mock.Mock
+}
+
+// NewMockAPITokenRepository creates a new mock API token repository
+func NewMockAPITokenRepository() *MockAPITokenRepository {
+	return &MockAPITokenRepository{}
+}
+
+func (m *MockAPITokenRepository) CreateToken(ctx context.Context, token *domain.APIToken, tokenHash string) error {
+	args := m.Called(ctx, token, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) ListUserTokens(ctx context.Context, userID uuid.UUID) ([]domain.APIToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) RevokeUserToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	args := m.Called(ctx, userID, tokenID)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) ListServiceAccounts(ctx context.Context, projectID uuid.UUID) ([]domain.ServiceAccount, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) GetServiceAccount(ctx context.Context, projectID, accountID uuid.UUID) (*domain.ServiceAccount, error) {
+	args := m.Called(ctx, projectID, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) DisableServiceAccount(ctx context.Context, projectID, accountID uuid.UUID) error {
+	args := m.Called(ctx, projectID, accountID)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) ListServiceAccountTokens(ctx context.Context, accountID uuid.UUID) ([]domain.APIToken, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) RevokeServiceAccountToken(ctx context.Context, accountID, tokenID uuid.UUID) error {
+	args := m.Called(ctx, accountID, tokenID)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) TouchLastUsed(ctx context.Context, tokenID uuid.UUID) error {
+	args := m.Called(ctx, tokenID)
+	return args.Error(0)
+}
+
+var _ APITokenRepository = (*MockAPITokenRepository)(nil)
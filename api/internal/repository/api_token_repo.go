@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type apiTokenRepository struct {
+	db DBTX
+}
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(db *pgxpool.Pool) APITokenRepository {
+	return &apiTokenRepository{db: db}
+}
+
+const apiTokenColumns = `id, user_id, service_account_id, name, token_prefix, expires_at, last_used_at, created_by, created_at`
+
+func scanAPIToken(row pgx.Row, t *domain.APIToken) error {
+	return row.Scan(
+		&t.ID, &t.UserID, &t.ServiceAccountID, &t.Name, &t.TokenPrefix,
+		&t.ExpiresAt, &t.LastUsedAt, &t.CreatedBy, &t.CreatedAt,
+	)
+}
+
+func (r *apiTokenRepository) CreateToken(ctx context.Context, token *domain.APIToken, tokenHash string) error {
+	query := `
+		INSERT INTO api_tokens (id, user_id, service_account_id, name, token_hash, token_prefix, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		token.ID, token.UserID, token.ServiceAccountID, token.Name,
+		tokenHash, token.TokenPrefix, token.ExpiresAt, token.CreatedBy,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiTokenRepository) ListUserTokens(ctx context.Context, userID uuid.UUID) ([]domain.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	return r.listTokens(ctx, query, userID)
+}
+
+func (r *apiTokenRepository) RevokeUserToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE api_tokens SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, tokenID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+func (r *apiTokenRepository) CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error {
+	query := `
+		INSERT INTO service_accounts (id, project_id, name, role, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		account.ID, account.ProjectID, account.Name, account.Role, account.CreatedBy,
+	).Scan(&account.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiTokenRepository) ListServiceAccounts(ctx context.Context, projectID uuid.UUID) ([]domain.ServiceAccount, error) {
+	query := `
+		SELECT id, project_id, name, role, created_by, created_at, disabled_at
+		FROM service_accounts
+		WHERE project_id = $1 AND disabled_at IS NULL
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []domain.ServiceAccount
+	for rows.Next() {
+		var a domain.ServiceAccount
+		if err := rows.Scan(&a.ID, &a.ProjectID, &a.Name, &a.Role, &a.CreatedBy, &a.CreatedAt, &a.DisabledAt); err != nil {
+			return nil, fmt.Errorf("failed to scan service account: %w", err)
+		}
+		accounts = append(accounts, a)
+	}
+
+	return accounts, rows.Err()
+}
+
+func (r *apiTokenRepository) GetServiceAccount(ctx context.Context, projectID, accountID uuid.UUID) (*domain.ServiceAccount, error) {
+	query := `
+		SELECT id, project_id, name, role, created_by, created_at, disabled_at
+		FROM service_accounts
+		WHERE id = $1 AND project_id = $2 AND disabled_at IS NULL
+	`
+
+	var a domain.ServiceAccount
+	err := r.db.QueryRow(ctx, query, accountID, projectID).Scan(
+		&a.ID, &a.ProjectID, &a.Name, &a.Role, &a.CreatedBy, &a.CreatedAt, &a.DisabledAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return &a, nil
+}
+
+func (r *apiTokenRepository) DisableServiceAccount(ctx context.Context, projectID, accountID uuid.UUID) error {
+	query := `
+		WITH disabled AS (
+			UPDATE service_accounts SET disabled_at = NOW()
+			WHERE id = $1 AND project_id = $2 AND disabled_at IS NULL
+			RETURNING id
+		), revoked AS (
+			UPDATE api_tokens SET revoked_at = NOW()
+			WHERE service_account_id IN (SELECT id FROM disabled) AND revoked_at IS NULL
+		)
+		SELECT COUNT(*) FROM disabled
+	`
+
+	var disabled int
+	if err := r.db.QueryRow(ctx, query, accountID, projectID).Scan(&disabled); err != nil {
+		return fmt.Errorf("failed to disable service account: %w", err)
+	}
+	if disabled == 0 {
+		return domain.ErrServiceAccountNotFound
+	}
+
+	return nil
+}
+
+func (r *apiTokenRepository) ListServiceAccountTokens(ctx context.Context, accountID uuid.UUID) ([]domain.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE service_account_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	return r.listTokens(ctx, query, accountID)
+}
+
+func (r *apiTokenRepository) RevokeServiceAccountToken(ctx context.Context, accountID, tokenID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE api_tokens SET revoked_at = NOW()
+		WHERE id = $1 AND service_account_id = $2 AND revoked_at IS NULL
+	`, tokenID, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+func (r *apiTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	query := `
+		SELECT t.id, t.user_id, t.service_account_id, t.name, t.token_prefix, t.expires_at,
+		       t.last_used_at, t.created_by, t.created_at,
+		       sa.project_id, sa.name, sa.role, sa.created_by, sa.created_at
+		FROM api_tokens t
+		LEFT JOIN service_accounts sa ON sa.id = t.service_account_id
+		WHERE t.token_hash = $1 AND t.revoked_at IS NULL
+		  AND (t.service_account_id IS NULL OR sa.disabled_at IS NULL)
+	`
+
+	var t domain.APIToken
+	var projectID, accountCreatedBy *uuid.UUID
+	var accountName, accountRole *string
+	var accountCreatedAt *time.Time
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.ServiceAccountID, &t.Name, &t.TokenPrefix, &t.ExpiresAt,
+		&t.LastUsedAt, &t.CreatedBy, &t.CreatedAt,
+		&projectID, &accountName, &accountRole, &accountCreatedBy, &accountCreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAPITokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	if t.ServiceAccountID != nil && projectID != nil {
+		t.ServiceAccount = &domain.ServiceAccount{
+			ID:        *t.ServiceAccountID,
+			ProjectID: *projectID,
+			Name:      *accountName,
+			Role:      domain.Role(*accountRole),
+			CreatedBy: *accountCreatedBy,
+		}
+		if accountCreatedAt != nil {
+			t.ServiceAccount.CreatedAt = *accountCreatedAt
+		}
+	}
+
+	return &t, nil
+}
+
+func (r *apiTokenRepository) TouchLastUsed(ctx context.Context, tokenID uuid.UUID) error {
+	// Only write once a minute so busy scripts don't rewrite the row per request
+	_, err := r.db.Exec(ctx, `
+		UPDATE api_tokens SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to update API token last used: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiTokenRepository) listTokens(ctx context.Context, query string, ownerID uuid.UUID) ([]domain.APIToken, error) {
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []domain.APIToken
+	for rows.Next() {
+		var t domain.APIToken
+		if err := scanAPIToken(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, rows.Err()
+}
@@ -21,17 +21,26 @@ func NewCommentRepository(db *pgxpool.Pool) CommentRepository {
 	return &commentRepository{db: db}
 }
 
+// Create inserts the comment and records its mentions in one transaction,
+// so a comment is never left without the notifications its mentions raise
 func (r *commentRepository) Create(ctx context.Context, c *domain.Comment) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin comment transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO comments (id, feedback_id, author_id, parent_id, body, visibility, is_edited, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, false, NOW(), NOW())
+		INSERT INTO comments (id, feedback_id, author_id, sdk_user_id, parent_id, body, visibility, is_edited, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, false, NOW(), NOW())
 		RETURNING created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err = tx.QueryRow(ctx, query,
 		c.ID,
 		c.FeedbackID,
 		c.AuthorID,
+		c.SDKUserID,
 		c.ParentID,
 		c.Body,
 		c.Visibility,
@@ -41,6 +50,22 @@ func (r *commentRepository) Create(ctx context.Context, c *domain.Comment) error
 		return fmt.Errorf("failed to create comment: %w", err)
 	}
 
+	if len(c.Mentions) > 0 {
+		// Each mention records a notification event via trigger
+		_, err = tx.Exec(ctx, `
+			INSERT INTO comment_mentions (comment_id, user_id)
+			SELECT $1, unnest($2::uuid[])
+			ON CONFLICT DO NOTHING
+		`, c.ID, c.Mentions)
+		if err != nil {
+			return fmt.Errorf("failed to add comment mentions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit comment: %w", err)
+	}
+
 	return nil
 }
 
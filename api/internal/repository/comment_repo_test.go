@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestCommentRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("records mentions in the comment's transaction", func(t *testing.T) {
+		jane := uuid.New()
+		c := &domain.Comment{ID: uuid.New(), FeedbackID: uuid.New(), AuthorID: uuid.New(), Body: "@jane", Mentions: []uuid.UUID{jane}}
+		db := &fakeDB{results: []fakeResult{{row: []any{now, now}}, {tag: "INSERT 0 1"}}}
+
+		require.NoError(t, (&commentRepository{db: db}).Create(ctx, c))
+		require.Len(t, db.queries, 2)
+		assert.Contains(t, db.queries[1], "INSERT INTO comment_mentions")
+		assert.Equal(t, []any{c.ID, []uuid.UUID{jane}}, db.args[1])
+		assert.True(t, db.committed)
+		assert.Equal(t, now, c.CreatedAt)
+	})
+
+	t.Run("a failed mention insert rolls the comment back", func(t *testing.T) {
+		c := &domain.Comment{ID: uuid.New(), Mentions: []uuid.UUID{uuid.New()}}
+		db := &fakeDB{results: []fakeResult{{row: []any{now, now}}, {err: errors.New("boom")}}}
+
+		assert.Error(t, (&commentRepository{db: db}).Create(ctx, c))
+		assert.False(t, db.committed)
+		assert.True(t, db.rolledBack)
+	})
+
+	t.Run("comments without mentions skip the mention insert", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{row: []any{now, now}}}}
+
+		require.NoError(t, (&commentRepository{db: db}).Create(ctx, &domain.Comment{ID: uuid.New()}))
+		assert.Len(t, db.queries, 1)
+		assert.True(t, db.committed)
+	})
+}
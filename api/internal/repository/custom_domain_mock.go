@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockCustomDomainRepository is a mock implementation of CustomDomainRepository for testing
+type MockCustomDomainRepository struct {
+	mock.Mock
+}
+
+// NewMockCustomDomainRepository creates a new mock custom domain repository
+func NewMockCustomDomainRepository() *MockCustomDomainRepository {
+	return &MockCustomDomainRepository{}
+}
+
+func (m *MockCustomDomainRepository) Upsert(ctx context.Context, d *domain.CustomDomain) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockCustomDomainRepository) GetByProject(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CustomDomain), args.Error(1)
+}
+
+func (m *MockCustomDomainRepository) MarkVerified(ctx context.Context, d *domain.CustomDomain) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockCustomDomainRepository) MarkChecked(ctx context.Context, d *domain.CustomDomain) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockCustomDomainRepository) Delete(ctx context.Context, projectID uuid.UUID) error {
+	args := m.Called(ctx, projectID)
+	return args.Error(0)
+}
+
+func (m *MockCustomDomainRepository) ListVerified(ctx context.Context) (map[string]uuid.UUID, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]uuid.UUID), args.Error(1)
+}
+
+// Ensure MockCustomDomainRepository implements CustomDomainRepository
+var _ CustomDomainRepository = (*MockCustomDomainRepository)(nil)
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type customDomainRepository struct {
+	db DBTX
+}
+
+// NewCustomDomainRepository creates a new custom domain repository
+func NewCustomDomainRepository(db *pgxpool.Pool) CustomDomainRepository {
+	return &customDomainRepository{db: db}
+}
+
+func (r *customDomainRepository) Upsert(ctx context.Context, d *domain.CustomDomain) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO project_domains (id, project_id, hostname, verification_token)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project_id) DO UPDATE
+		SET hostname = EXCLUDED.hostname, verification_token = EXCLUDED.verification_token,
+			verified_at = NULL, last_checked_at = NULL, created_at = NOW()
+		RETURNING id, created_at
+	`, d.ID, d.ProjectID, d.Hostname, d.VerificationToken).Scan(&d.ID, &d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to register custom domain: %w", err)
+	}
+	d.VerifiedAt = nil
+	d.LastCheckedAt = nil
+	return nil
+}
+
+func (r *customDomainRepository) GetByProject(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error) {
+	var d domain.CustomDomain
+	err := r.db.QueryRow(ctx, `
+		SELECT id, project_id, hostname, verification_token, verified_at, last_checked_at, created_at
+		FROM project_domains
+		WHERE project_id = $1
+	`, projectID).Scan(
+		&d.ID, &d.ProjectID, &d.Hostname, &d.VerificationToken,
+		&d.VerifiedAt, &d.LastCheckedAt, &d.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDomainNotFound
+		}
+		return nil, fmt.Errorf("failed to get custom domain: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *customDomainRepository) MarkVerified(ctx context.Context, d *domain.CustomDomain) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE project_domains
+		SET verified_at = COALESCE(verified_at, NOW()), last_checked_at = NOW()
+		WHERE id = $1
+		RETURNING verified_at, last_checked_at
+	`, d.ID).Scan(&d.VerifiedAt, &d.LastCheckedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrDomainTaken
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrDomainNotFound
+		}
+		return fmt.Errorf("failed to verify custom domain: %w", err)
+	}
+	return nil
+}
+
+func (r *customDomainRepository) MarkChecked(ctx context.Context, d *domain.CustomDomain) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE project_domains SET last_checked_at = NOW()
+		WHERE id = $1
+		RETURNING last_checked_at
+	`, d.ID).Scan(&d.LastCheckedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrDomainNotFound
+		}
+		return fmt.Errorf("failed to update custom domain: %w", err)
+	}
+	return nil
+}
+
+func (r *customDomainRepository) Delete(ctx context.Context, projectID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM project_domains WHERE project_id = $1`, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom domain: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDomainNotFound
+	}
+	return nil
+}
+
+func (r *customDomainRepository) ListVerified(ctx context.Context) (map[string]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT d.hostname, d.project_id
+		FROM project_domains d
+		JOIN projects p ON p.id = d.project_id
+		WHERE d.verified_at IS NOT NULL AND p.archived_at IS NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verified domains: %w", err)
+	}
+	defer rows.Close()
+
+	hosts := make(map[string]uuid.UUID)
+	for rows.Next() {
+		var hostname string
+		var projectID uuid.UUID
+		if err := rows.Scan(&hostname, &projectID); err != nil {
+			return nil, fmt.Errorf("failed to scan verified domain: %w", err)
+		}
+		hosts[hostname] = projectID
+	}
+
+	return hosts, rows.Err()
+}
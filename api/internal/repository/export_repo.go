@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// exportFetchSize is the number of rows pulled from the cursor per round trip
+const exportFetchSize = 1000
+
+type exportRepository struct {
+	db   DBTX
+	pool *pgxpool.Pool
+}
+
+// NewExportRepository creates a new export repository
+func NewExportRepository(db *pgxpool.Pool) ExportRepository {
+	return &exportRepository{db: db, pool: db}
+}
+
+func (r *exportRepository) Count(ctx context.Context, projectID uuid.UUID, filter FeedbackFilter) (int, error) {
+	whereClause, args, _ := buildFeedbackConditions(projectID, filter)
+
+	var total int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM feedback WHERE %s", whereClause)
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count feedback for export: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *exportRepository) Stream(ctx context.Context, projectID uuid.UUID, filter FeedbackFilter, fn func(*domain.ExportRow) error) error {
+	whereClause, args, _ := buildFeedbackConditions(projectID, filter)
+
+	sortBy := "created_at"
+	switch filter.SortBy {
+	case "updated_at", "vote_count", "vote_score":
+		sortBy = filter.SortBy
+	}
+	sortOrder := "DESC"
+	if filter.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+
+	// The filter is applied in a subquery so its unqualified column names
+	// don't clash with the joined sdk_users columns.
+	query := fmt.Sprintf(`
+		SELECT
+			f.id, f.title, f.description, f.type, f.status, f.severity, f.visibility,
+			COALESCE((
+				SELECT array_agg(t.name ORDER BY t.name)
+				FROM feedback_tags ft
+				JOIN tags t ON t.id = ft.tag_id
+				WHERE ft.feedback_id = f.id
+			), '{}') AS tags,
+			f.vote_count, f.comment_count, f.assigned_to, f.source,
+			f.submitter_email, f.submitter_name, f.submitter_identifier,
+			su.id, su.external_id, su.email, su.name, su.traits,
+			f.source_metadata, f.created_at, f.updated_at, f.resolved_at
+		FROM (SELECT * FROM feedback WHERE %s) f
+		LEFT JOIN sdk_users su ON su.id = f.sdk_user_id
+		ORDER BY f.%s %s, f.id
+	`, whereClause, sortBy, sortOrder)
+
+	// Cursors only live inside a transaction
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin export transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DECLARE export_cursor NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM export_cursor", exportFetchSize)
+	for {
+		rows, err := tx.Query(ctx, fetchQuery)
+		if err != nil {
+			return fmt.Errorf("failed to fetch export rows: %w", err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			row, err := scanExportRow(rows)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan export row: %w", err)
+			}
+			if err := fn(row); err != nil {
+				rows.Close()
+				return err
+			}
+			fetched++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read export rows: %w", err)
+		}
+
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *exportRepository) CreateJob(ctx context.Context, job *domain.ExportJob) error {
+	filterJSON, err := json.Marshal(job.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export filter: %w", err)
+	}
+
+	query := `
+		INSERT INTO export_jobs (id, project_id, requested_by, format, filter, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		job.ID,
+		job.ProjectID,
+		job.RequestedBy,
+		job.Format,
+		filterJSON,
+		job.Status,
+	).Scan(&job.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exportRepository) GetJob(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error) {
+	query := `
+		SELECT id, project_id, requested_by, format, filter, status, row_count,
+			storage_path, error, created_at, completed_at
+		FROM export_jobs
+		WHERE id = $1
+	`
+
+	job, err := scanExportJob(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrExportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *exportRepository) ListJobs(ctx context.Context, projectID uuid.UUID, limit int) ([]domain.ExportJob, error) {
+	query := `
+		SELECT id, project_id, requested_by, format, filter, status, row_count,
+			storage_path, error, created_at, completed_at
+		FROM export_jobs
+		WHERE project_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []domain.ExportJob
+	for rows.Next() {
+		job, err := scanExportJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan export job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (r *exportRepository) UpdateJob(ctx context.Context, job *domain.ExportJob) error {
+	query := `
+		UPDATE export_jobs
+		SET status = $2, row_count = $3, storage_path = $4, error = $5, completed_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		job.ID,
+		job.Status,
+		job.RowCount,
+		job.StoragePath,
+		job.Error,
+		job.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update export job: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrExportJobNotFound
+	}
+
+	return nil
+}
+
+func (r *exportRepository) FailStaleJobs(ctx context.Context, createdBefore time.Time, reason string) (int64, error) {
+	query := `
+		UPDATE export_jobs
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE status IN ('pending', 'running') AND created_at < $1
+	`
+
+	result, err := r.db.Exec(ctx, query, createdBefore, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale export jobs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func scanExportRow(row pgx.Row) (*domain.ExportRow, error) {
+	var e domain.ExportRow
+	var sdkTraitsJSON, metadataJSON []byte
+	if err := row.Scan(
+		&e.ID,
+		&e.Title,
+		&e.Description,
+		&e.Type,
+		&e.Status,
+		&e.Severity,
+		&e.Visibility,
+		&e.Tags,
+		&e.VoteCount,
+		&e.CommentCount,
+		&e.AssignedTo,
+		&e.Source,
+		&e.SubmitterEmail,
+		&e.SubmitterName,
+		&e.SubmitterIdentifier,
+		&e.SDKUserID,
+		&e.SDKUserExternalID,
+		&e.SDKUserEmail,
+		&e.SDKUserName,
+		&sdkTraitsJSON,
+		&metadataJSON,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.ResolvedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(sdkTraitsJSON) > 0 {
+		if err := json.Unmarshal(sdkTraitsJSON, &e.SDKUserTraits); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sdk user traits: %w", err)
+		}
+	}
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &e.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal source metadata: %w", err)
+		}
+	}
+
+	return &e, nil
+}
+
+func scanExportJob(row pgx.Row) (*domain.ExportJob, error) {
+	var job domain.ExportJob
+	var filterJSON []byte
+	if err := row.Scan(
+		&job.ID,
+		&job.ProjectID,
+		&job.RequestedBy,
+		&job.Format,
+		&filterJSON,
+		&job.Status,
+		&job.RowCount,
+		&job.StoragePath,
+		&job.Error,
+		&job.CreatedAt,
+		&job.CompletedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(filterJSON) > 0 {
+		if err := json.Unmarshal(filterJSON, &job.Filter); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal export filter: %w", err)
+		}
+	}
+
+	return &job, nil
+}
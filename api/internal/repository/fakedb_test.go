@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeDB is a DBTX that answers statements from a script, in order, and
+// records what was run
+type fakeDB struct {
+	results []fakeResult
+	queries []string
+	args    [][]any
+
+	committed  bool
+	rolledBack bool
+}
+
+// fakeResult answers one statement: Exec returns tag, QueryRow scans row,
+// Query returns rows (or each fails with err)
+type fakeResult struct {
+	tag  string
+	row  []any
+	rows [][]any
+	err  error
+}
+
+func (db *fakeDB) next(sql string, args []any) fakeResult {
+	db.queries = append(db.queries, sql)
+	db.args = append(db.args, args)
+	if len(db.results) == 0 {
+		return fakeResult{err: fmt.Errorf("unexpected statement: %s", sql)}
+	}
+	res := db.results[0]
+	db.results = db.results[1:]
+	return res
+}
+
+func (db *fakeDB) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	res := db.next(sql, args)
+	return pgconn.NewCommandTag(res.tag), res.err
+}
+
+func (db *fakeDB) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	res := db.next(sql, args)
+	if res.err != nil {
+		return nil, res.err
+	}
+	return &fakeRows{rows: res.rows}, nil
+}
+
+func (db *fakeDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow(db.next(sql, args))
+}
+
+type fakeRow fakeResult
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanFakeValues(r.row, dest)
+}
+
+// fakeRows iterates over scripted rows
+type fakeRows struct {
+	pgx.Rows
+	rows [][]any
+	cur  []any
+}
+
+func (r *fakeRows) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	r.cur, r.rows = r.rows[0], r.rows[1:]
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error { return scanFakeValues(r.cur, dest) }
+func (r *fakeRows) Err() error             { return nil }
+func (r *fakeRows) Close()                 {}
+
+func scanFakeValues(values, dest []any) error {
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+// Begin starts a fakeTx that answers from the same script
+func (db *fakeDB) Begin(context.Context) (pgx.Tx, error) {
+	return &fakeTx{db: db}, nil
+}
+
+// fakeTx runs statements against its fakeDB and records how it ended
+type fakeTx struct {
+	pgx.Tx
+	db   *fakeDB
+	done bool
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	if !tx.done {
+		tx.done, tx.db.committed = true, true
+	}
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	if !tx.done {
+		tx.done, tx.db.rolledBack = true, true
+	}
+	return nil
+}
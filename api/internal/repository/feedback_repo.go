@@ -61,7 +61,7 @@ func (r *feedbackRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	query := `
 		SELECT
 			id, project_id, author_id, assigned_to, canonical_id, title, description,
-			type, status, severity, visibility, vote_count, comment_count,
+			type, status, severity, visibility, vote_count, vote_score, comment_count,
 			submitter_email, submitter_name, source, source_metadata,
 			created_at, updated_at, resolved_at
 		FROM feedback
@@ -82,6 +82,7 @@ func (r *feedbackRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 		&f.Severity,
 		&f.Visibility,
 		&f.VoteCount,
+		&f.VoteScore,
 		&f.CommentCount,
 		&f.SubmitterEmail,
 		&f.SubmitterName,
@@ -103,45 +104,7 @@ func (r *feedbackRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 }
 
 func (r *feedbackRepository) List(ctx context.Context, projectID uuid.UUID, filter FeedbackFilter) ([]domain.Feedback, int, error) {
-	// Build WHERE clause
-	conditions := []string{"project_id = $1", "canonical_id IS NULL"} // Exclude merged items
-	args := []interface{}{projectID}
-	argIndex := 2
-
-	if filter.Type != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", argIndex))
-		args = append(args, *filter.Type)
-		argIndex++
-	}
-
-	if filter.Status != nil {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, *filter.Status)
-		argIndex++
-	}
-
-	if filter.Visibility != nil {
-		conditions = append(conditions, fmt.Sprintf("visibility = $%d", argIndex))
-		args = append(args, *filter.Visibility)
-		argIndex++
-	}
-
-	if filter.AssignedTo != nil {
-		conditions = append(conditions, fmt.Sprintf("assigned_to = $%d", argIndex))
-		args = append(args, *filter.AssignedTo)
-		argIndex++
-	}
-
-	if filter.Search != nil && *filter.Search != "" {
-		conditions = append(conditions, fmt.Sprintf(
-			"(title ILIKE $%d OR description ILIKE $%d)",
-			argIndex, argIndex,
-		))
-		args = append(args, "%"+*filter.Search+"%")
-		argIndex++
-	}
-
-	whereClause := strings.Join(conditions, " AND ")
+	whereClause, args, argIndex := buildFeedbackConditions(projectID, filter)
 
 	// Count query
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM feedback WHERE %s", whereClause)
@@ -154,7 +117,7 @@ func (r *feedbackRepository) List(ctx context.Context, projectID uuid.UUID, filt
 	sortBy := "created_at"
 	if filter.SortBy != "" {
 		switch filter.SortBy {
-		case "updated_at", "vote_count", "created_at":
+		case "updated_at", "vote_count", "vote_score", "created_at":
 			sortBy = filter.SortBy
 		}
 	}
@@ -178,7 +141,7 @@ func (r *feedbackRepository) List(ctx context.Context, projectID uuid.UUID, filt
 	listQuery := fmt.Sprintf(`
 		SELECT
 			id, project_id, author_id, assigned_to, canonical_id, title, description,
-			type, status, severity, visibility, vote_count, comment_count,
+			type, status, severity, visibility, vote_count, vote_score, comment_count,
 			submitter_email, submitter_name, source, source_metadata,
 			created_at, updated_at, resolved_at
 		FROM feedback
@@ -211,6 +174,7 @@ func (r *feedbackRepository) List(ctx context.Context, projectID uuid.UUID, filt
 			&f.Severity,
 			&f.Visibility,
 			&f.VoteCount,
+			&f.VoteScore,
 			&f.CommentCount,
 			&f.SubmitterEmail,
 			&f.SubmitterName,
@@ -228,6 +192,108 @@ func (r *feedbackRepository) List(ctx context.Context, projectID uuid.UUID, filt
 	return feedbacks, total, nil
 }
 
+// buildFeedbackConditions builds the WHERE clause and positional args for a
+// feedback filter. The returned index is the next free placeholder number.
+func buildFeedbackConditions(projectID uuid.UUID, filter FeedbackFilter) (string, []interface{}, int) {
+	return appendFeedbackConditions(projectID, filter, nil)
+}
+
+// FeedbackConditions exposes buildFeedbackConditions to handlers that query
+// feedback directly. Its column names are unqualified, so callers apply it
+// to feedback alone, e.g. FROM (SELECT * FROM feedback WHERE ...) f.
+func FeedbackConditions(projectID uuid.UUID, filter FeedbackFilter) (string, []interface{}, int) {
+	return buildFeedbackConditions(projectID, filter)
+}
+
+// appendFeedbackConditions builds the filter conditions with placeholders
+// numbered after the existing args, so several filters can share a query
+func appendFeedbackConditions(projectID uuid.UUID, filter FeedbackFilter, args []interface{}) (string, []interface{}, int) {
+	argIndex := len(args) + 1
+	conditions := []string{fmt.Sprintf("project_id = $%d", argIndex), "canonical_id IS NULL"} // Exclude merged items
+	args = append(args, projectID)
+	argIndex++
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("type = $%d", argIndex))
+		args = append(args, *filter.Type)
+		argIndex++
+	}
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+
+	if filter.Visibility != nil {
+		conditions = append(conditions, fmt.Sprintf("visibility = $%d", argIndex))
+		args = append(args, *filter.Visibility)
+		argIndex++
+	}
+
+	if filter.AssignedTo != nil {
+		conditions = append(conditions, fmt.Sprintf("assigned_to = $%d", argIndex))
+		args = append(args, *filter.AssignedTo)
+		argIndex++
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"(title ILIKE $%d OR description ILIKE $%d)",
+			argIndex, argIndex,
+		))
+		args = append(args, "%"+*filter.Search+"%")
+		argIndex++
+	}
+
+	if len(filter.TagIDs) > 0 {
+		// Feedback must carry every selected tag
+		tagIDs := uniqueUUIDs(filter.TagIDs)
+		conditions = append(conditions, fmt.Sprintf(
+			"(SELECT COUNT(*) FROM feedback_tags ft WHERE ft.feedback_id = feedback.id AND ft.tag_id = ANY($%d)) = %d",
+			argIndex, len(tagIDs),
+		))
+		args = append(args, tagIDs)
+		argIndex++
+	}
+
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at > $%d", argIndex))
+		args = append(args, *filter.CreatedAfter)
+		argIndex++
+	}
+
+	if filter.Segment != nil {
+		// Matches if the SDK submitter or any voter's SDK identity is in the segment
+		var submitter, voter string
+		submitter, args, argIndex = segmentCondition("su", filter.Segment, args, argIndex)
+		voter, args, argIndex = segmentCondition("su", filter.Segment, args, argIndex)
+		conditions = append(conditions, fmt.Sprintf(`(EXISTS (
+			SELECT 1 FROM sdk_users su WHERE su.id = feedback.sdk_user_id AND %s
+		) OR EXISTS (
+			SELECT 1
+			FROM votes v
+			JOIN sdk_users su ON su.project_id = feedback.project_id
+				AND (su.id = v.sdk_user_id OR su.linked_user_id = v.user_id)
+			WHERE v.feedback_id = feedback.id AND %s
+		))`, submitter, voter))
+	}
+
+	return strings.Join(conditions, " AND "), args, argIndex
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
 func (r *feedbackRepository) Update(ctx context.Context, f *domain.Feedback) error {
 	query := `
 		UPDATE feedback
@@ -293,7 +359,12 @@ func (r *feedbackRepository) Merge(ctx context.Context, sourceID, canonicalID uu
 			UPDATE votes
 			SET feedback_id = $2
 			WHERE feedback_id = $1 AND NOT EXISTS (
-				SELECT 1 FROM votes WHERE feedback_id = $2 AND user_id = votes.user_id
+				SELECT 1 FROM votes v
+				WHERE v.feedback_id = $2 AND (
+					v.user_id = votes.user_id
+					OR v.sdk_user_id = votes.sdk_user_id
+					OR v.anonymous_fingerprint = votes.anonymous_fingerprint
+				)
 			)
 		)
 		SELECT id FROM updated
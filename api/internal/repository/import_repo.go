@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type importRepository struct {
+	db   DBTX
+	pool *pgxpool.Pool
+}
+
+// NewImportRepository creates a new import repository
+func NewImportRepository(db *pgxpool.Pool) ImportRepository {
+	return &importRepository{db: db, pool: db}
+}
+
+func (r *importRepository) FindImported(ctx context.Context, projectID uuid.UUID, source string, sourceIDs []string) (map[string]uuid.UUID, error) {
+	result := make(map[string]uuid.UUID)
+	if len(sourceIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT import_source_id, id
+		FROM feedback
+		WHERE project_id = $1 AND import_source = $2 AND import_source_id = ANY($3)
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID, source, sourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find imported feedback: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sourceID string
+		var id uuid.UUID
+		if err := rows.Scan(&sourceID, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan imported feedback: %w", err)
+		}
+		result[sourceID] = id
+	}
+
+	return result, rows.Err()
+}
+
+func (r *importRepository) ImportItem(ctx context.Context, projectID, importerID uuid.UUID, source string, item *domain.ImportItem) (uuid.UUID, bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to begin import transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var sdkUserID *uuid.UUID
+	if item.Submitter != nil {
+		id, err := upsertImportSubmitter(ctx, tx, projectID, item.Submitter)
+		if err != nil {
+			return uuid.Nil, false, err
+		}
+		sdkUserID = &id
+	}
+
+	var metadataJSON []byte
+	if len(item.Metadata) > 0 {
+		if metadataJSON, err = json.Marshal(item.Metadata); err != nil {
+			return uuid.Nil, false, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+	}
+
+	// Imported rows without a submitter still need something to satisfy
+	// chk_feedback_author_or_identifier
+	var submitterEmail, submitterName, submitterIdentifier *string
+	if item.Submitter != nil {
+		submitterEmail = nullIfEmpty(item.Submitter.Email)
+		submitterName = nullIfEmpty(item.Submitter.Name)
+	} else {
+		identifier := source + ":" + item.SourceID
+		submitterIdentifier = &identifier
+	}
+
+	query := `
+		INSERT INTO feedback (
+			id, project_id, title, description, type, status, visibility,
+			vote_count, submitter_email, submitter_name, submitter_identifier, sdk_user_id,
+			source, source_metadata, import_source, import_source_id,
+			created_at, updated_at, resolved_at
+		)
+		VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+			'import', $13, $14, $15,
+			COALESCE($16, NOW()), COALESCE($16, NOW()),
+			CASE WHEN $17 THEN COALESCE($16, NOW()) END
+		)
+		ON CONFLICT (project_id, import_source, import_source_id) WHERE import_source_id IS NOT NULL
+		DO NOTHING
+		RETURNING id
+	`
+
+	feedbackID := uuid.New()
+	err = tx.QueryRow(ctx, query,
+		feedbackID,
+		projectID,
+		item.Title,
+		item.Description,
+		item.Type,
+		item.Status,
+		item.Visibility,
+		item.VoteCount,
+		submitterEmail,
+		submitterName,
+		submitterIdentifier,
+		sdkUserID,
+		metadataJSON,
+		source,
+		item.SourceID,
+		item.CreatedAt,
+		item.Status.IsResolved(),
+	).Scan(&feedbackID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Imported by an earlier run
+			existing, err := r.FindImported(ctx, projectID, source, []string{item.SourceID})
+			if err != nil {
+				return uuid.Nil, false, err
+			}
+			return existing[item.SourceID], false, nil
+		}
+		return uuid.Nil, false, fmt.Errorf("failed to insert imported feedback: %w", err)
+	}
+
+	for _, name := range item.Tags {
+		var tagID uuid.UUID
+		err := tx.QueryRow(ctx, `
+			INSERT INTO tags (project_id, name, slug)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (project_id, slug) DO UPDATE SET slug = EXCLUDED.slug
+			RETURNING id
+		`, projectID, name, domain.GenerateSlug(name)).Scan(&tagID)
+		if err != nil {
+			return uuid.Nil, false, fmt.Errorf("failed to upsert tag %q: %w", name, err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO feedback_tags (feedback_id, tag_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, feedbackID, tagID); err != nil {
+			return uuid.Nil, false, fmt.Errorf("failed to tag imported feedback: %w", err)
+		}
+	}
+
+	for _, c := range item.Comments {
+		visibility := c.Visibility
+		if visibility == "" {
+			visibility = domain.VisibilityCommunity
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO comments (
+				feedback_id, author_id, body, visibility,
+				import_source_id, external_author_name, created_at, updated_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, NOW()), COALESCE($7, NOW()))
+		`,
+			feedbackID,
+			importerID,
+			c.Body,
+			visibility,
+			nullIfEmpty(c.SourceID),
+			nullIfEmpty(c.AuthorName),
+			c.CreatedAt,
+		); err != nil {
+			return uuid.Nil, false, fmt.Errorf("failed to insert imported comment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return feedbackID, true, nil
+}
+
+// upsertImportSubmitter finds the sdk_user for a submitter by external ID,
+// then by email, creating one if neither matches.
+func upsertImportSubmitter(ctx context.Context, tx pgx.Tx, projectID uuid.UUID, s *domain.ImportSubmitter) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	if s.ExternalID != "" {
+		err := tx.QueryRow(ctx, `
+			SELECT id FROM sdk_users WHERE project_id = $1 AND external_id = $2
+		`, projectID, s.ExternalID).Scan(&id)
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("failed to look up sdk user: %w", err)
+		}
+	}
+
+	if s.Email != "" {
+		err := tx.QueryRow(ctx, `
+			SELECT id FROM sdk_users
+			WHERE project_id = $1 AND LOWER(email) = $2
+			ORDER BY created_at
+			LIMIT 1
+		`, projectID, s.Email).Scan(&id)
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("failed to look up sdk user by email: %w", err)
+		}
+	}
+
+	// Email-only submitters use their email as the external ID
+	externalID := s.ExternalID
+	if externalID == "" {
+		externalID = s.Email
+	}
+
+	err := tx.QueryRow(ctx, `
+		INSERT INTO sdk_users (project_id, external_id, email, name)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project_id, external_id) DO UPDATE SET external_id = EXCLUDED.external_id
+		RETURNING id
+	`, projectID, externalID, nullIfEmpty(s.Email), nullIfEmpty(s.Name)).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create sdk user: %w", err)
+	}
+
+	return id, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockInboxRepository is a mock implementation of InboxRepository for testing
+type MockInboxRepository struct {
+	mock.Mock
+}
+
+// NewMockInboxRepository creates a new mock inbox repository
+func NewMockInboxRepository() *MockInboxRepository {
+	return &MockInboxRepository{}
+}
+
+func (m *MockInboxRepository) List(ctx context.Context, projectID, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]domain.Notification, int, error) {
+	args := m.Called(ctx, projectID, userID, unreadOnly, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Notification), args.Int(1), args.Error(2)
+}
+
+func (m *MockInboxRepository) UnreadCount(ctx context.Context, projectID, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, projectID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInboxRepository) MarkRead(ctx context.Context, projectID, userID, notificationID uuid.UUID) error {
+	args := m.Called(ctx, projectID, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockInboxRepository) MarkAllRead(ctx context.Context, projectID, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, projectID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockInboxRepository) GetPreferences(ctx context.Context, projectID, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	args := m.Called(ctx, projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockInboxRepository) UpdatePreferences(ctx context.Context, projectID, userID uuid.UUID, prefs domain.NotificationPreferences) error {
+	args := m.Called(ctx, projectID, userID, prefs)
+	return args.Error(0)
+}
+
+var _ InboxRepository = (*MockInboxRepository)(nil)
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type inboxRepository struct {
+	db DBTX
+}
+
+// NewInboxRepository creates a new inbox repository
+func NewInboxRepository(db *pgxpool.Pool) InboxRepository {
+	return &inboxRepository{db: db}
+}
+
+func (r *inboxRepository) List(ctx context.Context, projectID, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]domain.Notification, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications
+		WHERE project_id = $1 AND user_id = $2 AND (NOT $3 OR read_at IS NULL)
+	`, projectID, userID, unreadOnly).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, project_id, feedback_id, event_id, notification_type, payload, read_at, created_at
+		FROM notifications
+		WHERE project_id = $1 AND user_id = $2 AND (NOT $3 OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []domain.Notification
+	for rows.Next() {
+		var n domain.Notification
+		var payloadJSON []byte
+		if err := rows.Scan(
+			&n.ID, &n.UserID, &n.ProjectID, &n.FeedbackID, &n.EventID,
+			&n.NotificationType, &payloadJSON, &n.ReadAt, &n.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		if err := json.Unmarshal(payloadJSON, &n.Payload); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal notification payload: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, total, rows.Err()
+}
+
+func (r *inboxRepository) UnreadCount(ctx context.Context, projectID, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications
+		WHERE project_id = $1 AND user_id = $2 AND read_at IS NULL
+	`, projectID, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *inboxRepository) MarkRead(ctx context.Context, projectID, userID, notificationID uuid.UUID) error {
+	// Marking a read notification again keeps its original read time
+	result, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $3 AND project_id = $1 AND user_id = $2
+	`, projectID, userID, notificationID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *inboxRepository) MarkAllRead(ctx context.Context, projectID, userID uuid.UUID) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE notifications
+		SET read_at = NOW()
+		WHERE project_id = $1 AND user_id = $2 AND read_at IS NULL
+	`, projectID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *inboxRepository) GetPreferences(ctx context.Context, projectID, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	query := `
+		SELECT ` + memberPreferencesColumn + `
+		FROM effective_memberships m
+		JOIN projects p ON p.id = m.project_id
+		WHERE m.project_id = $1 AND m.user_id = $2
+	`
+
+	var prefsJSON []byte
+	if err := r.db.QueryRow(ctx, query, projectID, userID).Scan(&prefsJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	prefs, err := memberPreferences(prefsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+func (r *inboxRepository) UpdatePreferences(ctx context.Context, projectID, userID uuid.UUID, prefs domain.NotificationPreferences) error {
+	prefsJSON, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification preferences: %w", err)
+	}
+
+	result, err := r.db.Exec(ctx, `
+		UPDATE memberships
+		SET notification_preferences = $3, updated_at = NOW()
+		WHERE project_id = $1 AND user_id = $2
+	`, projectID, userID, prefsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Members who inherit their role from the organization have no
+	// memberships row to hold their preferences
+	result, err = r.db.Exec(ctx, `
+		INSERT INTO inherited_member_preferences (project_id, user_id, notification_preferences)
+		SELECT $1, $2, $3
+		WHERE EXISTS (SELECT 1 FROM effective_memberships WHERE project_id = $1 AND user_id = $2)
+		ON CONFLICT (project_id, user_id) DO UPDATE
+			SET notification_preferences = EXCLUDED.notification_preferences
+	`, projectID, userID, prefsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestInboxRepository_Preferences(t *testing.T) {
+	ctx := context.Background()
+	projectID, userID := uuid.New(), uuid.New()
+	prefs := domain.DefaultNotificationPreferences()
+
+	t.Run("organization members without a membership row read preferences", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{row: []any{[]byte(nil)}}}}
+		got, err := (&inboxRepository{db: db}).GetPreferences(ctx, projectID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, prefs, *got)
+		assert.Contains(t, db.queries[0], "FROM effective_memberships m")
+	})
+
+	t.Run("organization members without a membership row save preferences", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{tag: "UPDATE 0"}, {tag: "INSERT 0 1"}}}
+		require.NoError(t, (&inboxRepository{db: db}).UpdatePreferences(ctx, projectID, userID, prefs))
+		require.Len(t, db.queries, 2)
+		assert.Contains(t, db.queries[1], "INSERT INTO inherited_member_preferences")
+		assert.Contains(t, db.queries[1], "effective_memberships")
+	})
+
+	t.Run("project members keep preferences on their membership", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{tag: "UPDATE 1"}}}
+		require.NoError(t, (&inboxRepository{db: db}).UpdatePreferences(ctx, projectID, userID, prefs))
+		assert.Len(t, db.queries, 1)
+	})
+
+	t.Run("non-members are not found", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{tag: "UPDATE 0"}, {tag: "INSERT 0 0"}}}
+		err := (&inboxRepository{db: db}).UpdatePreferences(ctx, projectID, userID, prefs)
+		assert.ErrorIs(t, err, domain.ErrMemberNotFound)
+	})
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -10,16 +11,18 @@ import (
 
 // FeedbackFilter defines filter options for listing feedback
 type FeedbackFilter struct {
-	Type       *domain.FeedbackType
-	Status     *domain.FeedbackStatus
-	Visibility *domain.Visibility
-	TagIDs     []uuid.UUID
-	AssignedTo *uuid.UUID
-	Search     *string
-	SortBy     string // "created_at", "updated_at", "vote_count"
-	SortOrder  string // "asc", "desc"
-	Limit      int
-	Offset     int
+	Type         *domain.FeedbackType
+	Status       *domain.FeedbackStatus
+	Visibility   *domain.Visibility
+	TagIDs       []uuid.UUID
+	AssignedTo   *uuid.UUID
+	Search       *string
+	CreatedAfter *time.Time
+	Segment      *domain.Segment // Submitter or any voter matches the segment
+	SortBy       string          // "created_at", "updated_at", "vote_count", "vote_score"
+	SortOrder    string          // "asc", "desc"
+	Limit        int
+	Offset       int
 }
 
 // FeedbackRepository defines the data access interface for feedback
@@ -37,14 +40,23 @@ type FeedbackRepository interface {
 // VoteRepository defines the data access interface for votes
 type VoteRepository interface {
 	Create(ctx context.Context, v *domain.Vote) error
-	Delete(ctx context.Context, feedbackID, userID uuid.UUID) error
-	Exists(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error)
+	// CreateWithinBudget is Create, but fails with ErrVoteBudgetExhausted
+	// when a new vote would exceed the voter's budget in the project
+	CreateWithinBudget(ctx context.Context, projectID uuid.UUID, v *domain.Vote) error
+	Delete(ctx context.Context, feedbackID uuid.UUID, voter domain.Voter) error
+	Exists(ctx context.Context, feedbackID uuid.UUID, voter domain.Voter) (bool, error)
 	CountByFeedback(ctx context.Context, feedbackID uuid.UUID) (int, error)
-	ListByUser(ctx context.Context, userID uuid.UUID, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	ListByVoter(ctx context.Context, voter domain.Voter, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	// ResolveCustomer returns the SDK user a team member is recording a vote
+	// for, creating one from the external ID or email if needed
+	ResolveCustomer(ctx context.Context, projectID uuid.UUID, c domain.VoteCustomer) (uuid.UUID, error)
+	// Budget returns the voter's active votes against the project's budget
+	Budget(ctx context.Context, projectID uuid.UUID, voter domain.Voter) (*domain.VoteBudgetUsage, error)
 }
 
 // CommentRepository defines the data access interface for comments
 type CommentRepository interface {
+	// Create inserts the comment along with its Mentions
 	Create(ctx context.Context, c *domain.Comment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error)
 	ListByFeedback(ctx context.Context, feedbackID uuid.UUID, includeTeamOnly bool) ([]domain.Comment, error)
@@ -130,14 +142,272 @@ type PortalRepository interface {
 
 	// SDK user linking
 	LinkSDKUsersByEmail(ctx context.Context, userID, projectID uuid.UUID, email string) (int64, error)
+	// LinkSDKUser returns the ID and name of the SDK user a portal user acts
+	// as in the project, creating one if they have never used the app
+	LinkSDKUser(ctx context.Context, projectID, userID uuid.UUID) (uuid.UUID, *string, error)
 
 	// Feedback operations
 	GetLinkedFeedback(ctx context.Context, userID, projectID uuid.UUID) ([]domain.PortalFeedbackSummary, error)
 	ListPublicFeatures(ctx context.Context, projectID uuid.UUID, userID *uuid.UUID, limit, offset int) ([]domain.PortalFeedbackSummary, int, error)
+	// CreateFeedback inserts portal feedback; when userID is set it is tied
+	// to the user's linked SDK user, creating one if needed
+	CreateFeedback(ctx context.Context, f *domain.Feedback, userID *uuid.UUID) error
+	GetFeedbackDetail(ctx context.Context, projectID, feedbackID uuid.UUID, userID *uuid.UUID) (*domain.PortalFeedbackDetail, error)
+	ListAttachments(ctx context.Context, feedbackID uuid.UUID) ([]domain.PortalAttachment, error)
+	ListStatusHistory(ctx context.Context, feedbackID uuid.UUID) ([]domain.StatusChange, error)
+
+	// Comment operations
+	ListComments(ctx context.Context, feedbackID uuid.UUID, userID *uuid.UUID) ([]domain.PortalComment, error)
 
 	// Voting operations
-	CreateVote(ctx context.Context, feedbackID, userID, projectID uuid.UUID) error
+	// CreateVote records the user's vote with its importance and note, or
+	// updates them on a repeat vote
+	CreateVote(ctx context.Context, feedbackID, userID, projectID uuid.UUID, details domain.VoteDetails) error
 	DeleteVote(ctx context.Context, feedbackID, userID uuid.UUID) error
 	HasVoted(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error)
 	GetVotedFeedbackIDs(ctx context.Context, userID uuid.UUID, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	GetVoteBudget(ctx context.Context, projectID, userID uuid.UUID) (*domain.VoteBudgetUsage, error)
+}
+
+// SavedViewRepository defines the data access interface for saved feedback views
+type SavedViewRepository interface {
+	Create(ctx context.Context, v *domain.SavedView) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedView, error)
+	ListVisible(ctx context.Context, projectID, userID uuid.UUID) ([]domain.SavedView, error)
+	Update(ctx context.Context, v *domain.SavedView) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	MarkSeen(ctx context.Context, viewID, userID uuid.UUID) error
+	Counts(ctx context.Context, projectID, userID uuid.UUID) ([]domain.SavedViewCount, error)
+}
+
+// ExportRepository defines the data access interface for feedback exports
+type ExportRepository interface {
+	Count(ctx context.Context, projectID uuid.UUID, filter FeedbackFilter) (int, error)
+	// Stream calls fn for every matching row, reading through a server-side cursor
+	Stream(ctx context.Context, projectID uuid.UUID, filter FeedbackFilter, fn func(*domain.ExportRow) error) error
+
+	CreateJob(ctx context.Context, job *domain.ExportJob) error
+	GetJob(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error)
+	ListJobs(ctx context.Context, projectID uuid.UUID, limit int) ([]domain.ExportJob, error)
+	UpdateJob(ctx context.Context, job *domain.ExportJob) error
+	// FailStaleJobs fails pending and running jobs created before the cutoff
+	// and returns how many it failed
+	FailStaleJobs(ctx context.Context, createdBefore time.Time, reason string) (int64, error)
+}
+
+// ImportRepository defines the data access interface for bulk feedback imports
+type ImportRepository interface {
+	// FindImported maps already-imported source IDs to their feedback IDs
+	FindImported(ctx context.Context, projectID uuid.UUID, source string, sourceIDs []string) (map[string]uuid.UUID, error)
+	// ImportItem writes one item with its submitter, tags and comments in a
+	// single transaction. It returns false if the item was already imported.
+	ImportItem(ctx context.Context, projectID, importerID uuid.UUID, source string, item *domain.ImportItem) (uuid.UUID, bool, error)
+}
+
+// AnalyticsRepository defines the data access interface for project analytics
+type AnalyticsRepository interface {
+	Volume(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.VolumeReport, error)
+	Resolution(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.ResolutionReport, error)
+	Funnel(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.FunnelReport, error)
+	TopRequests(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) ([]domain.TopRequest, error)
+	VoteVelocity(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) (*domain.VoteVelocityReport, error)
+	ActiveSDKUsers(ctx context.Context, projectID uuid.UUID, filter domain.AnalyticsFilter) ([]domain.ActiveSDKUser, error)
+}
+
+// SegmentRepository defines the data access interface for customer segments
+// and trait-weighted prioritization
+type SegmentRepository interface {
+	Create(ctx context.Context, s *domain.Segment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Segment, error)
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]domain.Segment, error)
+	Update(ctx context.Context, s *domain.Segment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CountUsers(ctx context.Context, s *domain.Segment) (int, error)
+	// CountUsersBySegment counts the project's SDK users in each segment in
+	// a single pass, keyed by segment ID
+	CountUsersBySegment(ctx context.Context, projectID uuid.UUID, segments []domain.Segment) (map[uuid.UUID]int, error)
+	// ListVoters returns an item's voters with their SDK traits and weight,
+	// optionally restricted to a segment
+	ListVoters(ctx context.Context, projectID, feedbackID uuid.UUID, segment *domain.Segment, weights domain.PrioritizationSettings) ([]domain.SegmentVoter, error)
+	// Priorities ranks open items by the summed weight of their supporters
+	Priorities(ctx context.Context, projectID uuid.UUID, segment *domain.Segment, weights domain.PrioritizationSettings, limit int) ([]domain.PriorityItem, error)
+}
+
+// SDKBoardRepository defines the data access interface for the SDK voting
+// board. Items are returned in portal form; sdkUserID drives has_voted and
+// is_mine when set.
+type SDKBoardRepository interface {
+	// GetSDKUserID resolves an identified SDK user by external ID
+	GetSDKUserID(ctx context.Context, projectID uuid.UUID, externalID string) (uuid.UUID, error)
+	ListFeatures(ctx context.Context, projectID uuid.UUID, sdkUserID *uuid.UUID, limit, offset int) ([]domain.PortalFeedbackSummary, int, error)
+	GetFeature(ctx context.Context, projectID, feedbackID uuid.UUID, sdkUserID *uuid.UUID) (*domain.PortalFeedbackDetail, error)
+	ListComments(ctx context.Context, feedbackID uuid.UUID, sdkUserID *uuid.UUID) ([]domain.PortalComment, error)
+	ListMyFeedback(ctx context.Context, projectID, sdkUserID uuid.UUID) ([]domain.PortalFeedbackSummary, error)
+}
+
+// SubscriptionRepository defines the data access interface for feedback
+// subscriptions. Followers match subscriptions held through their linked
+// identities, like voters.
+type SubscriptionRepository interface {
+	Get(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) (*domain.SubscriptionStatus, error)
+	Subscribe(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) error
+	// Unsubscribe mutes the follower's subscriptions so that voting or
+	// commenting later doesn't subscribe them again
+	Unsubscribe(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) error
+}
+
+// NotificationRepository defines the data access interface for the
+// notification worker
+type NotificationRepository interface {
+	// ClaimEvents leases up to limit pending events for fan-out
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]domain.NotificationEvent, error)
+	ListSubscribers(ctx context.Context, event *domain.NotificationEvent) ([]domain.Subscriber, error)
+	// GetMemberRecipient resolves a member an event names, such as the
+	// member mentioned or assigned
+	GetMemberRecipient(ctx context.Context, projectID, userID uuid.UUID) (*domain.MemberRecipient, error)
+	// ListViewWatchers returns the owners of notifying saved views that a
+	// new_feedback event's item matches
+	ListViewWatchers(ctx context.Context, event *domain.NotificationEvent) ([]domain.ViewWatcher, error)
+	// CompleteEvent stores the event's in-app notifications, queues its
+	// emails and marks it processed
+	CompleteEvent(ctx context.Context, eventID uuid.UUID, inbox []domain.Notification, emails []domain.NotificationQueueEntry) error
+}
+
+// InboxRepository defines the data access interface for a user's in-app
+// notifications in a project
+type InboxRepository interface {
+	List(ctx context.Context, projectID, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]domain.Notification, int, error)
+	UnreadCount(ctx context.Context, projectID, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, projectID, userID, notificationID uuid.UUID) error
+	// MarkAllRead marks every unread notification read and returns how many
+	MarkAllRead(ctx context.Context, projectID, userID uuid.UUID) (int, error)
+	// GetPreferences returns the member's preferences, or the project's if
+	// they haven't set their own
+	GetPreferences(ctx context.Context, projectID, userID uuid.UUID) (*domain.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, projectID, userID uuid.UUID, prefs domain.NotificationPreferences) error
+}
+
+// RealtimeRepository defines the data access interface for the live event
+// stream
+type RealtimeRepository interface {
+	// Listen calls handle for every event announced until ctx is cancelled
+	// or the connection fails
+	Listen(ctx context.Context, handle func(domain.RealtimeEvent)) error
+	// ListSince returns a project's events after afterID, and false if
+	// events since then can no longer be replayed in full
+	ListSince(ctx context.Context, projectID uuid.UUID, afterID int64, limit int) ([]domain.RealtimeEvent, bool, error)
+	// Prune deletes events recorded before the given time
+	Prune(ctx context.Context, before time.Time) (int64, error)
+}
+
+// APITokenRepository defines the data access interface for personal access
+// tokens and service accounts
+type APITokenRepository interface {
+	// CreateToken stores a personal or service account token by its hash
+	CreateToken(ctx context.Context, token *domain.APIToken, tokenHash string) error
+	// ListUserTokens returns the user's unrevoked personal access tokens
+	ListUserTokens(ctx context.Context, userID uuid.UUID) ([]domain.APIToken, error)
+	RevokeUserToken(ctx context.Context, userID, tokenID uuid.UUID) error
+
+	CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error
+	// ListServiceAccounts returns the project's enabled service accounts
+	ListServiceAccounts(ctx context.Context, projectID uuid.UUID) ([]domain.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, projectID, accountID uuid.UUID) (*domain.ServiceAccount, error)
+	// DisableServiceAccount disables the account and revokes its tokens
+	DisableServiceAccount(ctx context.Context, projectID, accountID uuid.UUID) error
+	ListServiceAccountTokens(ctx context.Context, accountID uuid.UUID) ([]domain.APIToken, error)
+	RevokeServiceAccountToken(ctx context.Context, accountID, tokenID uuid.UUID) error
+
+	// GetByHash returns an unrevoked token, with its service account if it
+	// has one; tokens of disabled service accounts are not found
+	GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error)
+	TouchLastUsed(ctx context.Context, tokenID uuid.UUID) error
+}
+
+// ProjectLifecycleRepository defines the data access interface for
+// archiving, deleting and transferring projects
+type ProjectLifecycleRepository interface {
+	// IsArchived reports whether the project is archived; unknown projects are not
+	IsArchived(ctx context.Context, projectID string) (bool, error)
+	// Archive archives the project and cancels any pending transfer;
+	// archiving an archived project is a no-op
+	Archive(ctx context.Context, projectID uuid.UUID) (*domain.Project, error)
+	// Restore unarchives the project and cancels any scheduled deletion.
+	// Projects already due for purging are not found.
+	Restore(ctx context.Context, projectID uuid.UUID) (*domain.Project, error)
+	// ScheduleDeletion archives the project and schedules its purge; an
+	// earlier scheduled time is kept
+	ScheduleDeletion(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.Project, error)
+	// ListDueForDeletion returns projects whose scheduled deletion has passed
+	ListDueForDeletion(ctx context.Context, limit int) ([]uuid.UUID, error)
+	// ListStoragePaths returns the object storage paths of the project's
+	// attachments, exports and logo
+	ListStoragePaths(ctx context.Context, projectID uuid.UUID) ([]string, error)
+	// Purge deletes the project if its deletion is still due
+	Purge(ctx context.Context, projectID uuid.UUID) error
+
+	// CreateTransfer stores a transfer, replacing any pending one
+	CreateTransfer(ctx context.Context, t *domain.ProjectTransfer) error
+	GetPendingTransfer(ctx context.Context, projectID uuid.UUID) (*domain.ProjectTransfer, error)
+	CancelTransfer(ctx context.Context, projectID uuid.UUID) error
+	// AcceptTransfer makes the transfer's recipient the owner and the
+	// previous owner an admin
+	AcceptTransfer(ctx context.Context, t *domain.ProjectTransfer) error
+}
+
+// CustomDomainRepository defines the data access interface for project
+// custom domains
+type CustomDomainRepository interface {
+	// Upsert registers the project's domain, replacing any previous one
+	Upsert(ctx context.Context, d *domain.CustomDomain) error
+	GetByProject(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error)
+	// MarkVerified records a successful check; it fails with ErrDomainTaken
+	// if another project verified the hostname first
+	MarkVerified(ctx context.Context, d *domain.CustomDomain) error
+	// MarkChecked records a failed check
+	MarkChecked(ctx context.Context, d *domain.CustomDomain) error
+	Delete(ctx context.Context, projectID uuid.UUID) error
+	// ListVerified maps verified hostnames of active projects to their project
+	ListVerified(ctx context.Context) (map[string]uuid.UUID, error)
+}
+
+// OrganizationRepository defines the data access interface for
+// organizations and their members
+type OrganizationRepository interface {
+	// Create stores the organization with ownerID as its first owner
+	Create(ctx context.Context, o *domain.Organization, ownerID uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+	Update(ctx context.Context, o *domain.Organization) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.OrganizationWithRole, error)
+	Usage(ctx context.Context, id uuid.UUID) (*domain.OrganizationUsage, error)
+	ListProjects(ctx context.Context, id uuid.UUID) ([]domain.Project, error)
+
+	// GetMember returns nil without an error if the user is not a member
+	GetMember(ctx context.Context, organizationID, userID string) (*domain.OrganizationMember, error)
+	// GetMemberForProject returns the user's membership in the organization
+	// that owns the project, or nil
+	GetMemberForProject(ctx context.Context, projectID, userID string) (*domain.OrganizationMember, error)
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]domain.OrganizationMember, error)
+	// AddMember fails with ErrOrganizationLimit when the member limit is reached
+	AddMember(ctx context.Context, m *domain.OrganizationMember) error
+	UpdateMemberRole(ctx context.Context, m *domain.OrganizationMember) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+
+	// SearchFeedback searches feedback across the organization's active projects
+	SearchFeedback(ctx context.Context, organizationID uuid.UUID, query string, limit int) ([]domain.OrganizationSearchResult, error)
+}
+
+// ProjectRoleRepository defines the data access interface for custom
+// project roles
+type ProjectRoleRepository interface {
+	List(ctx context.Context, projectID uuid.UUID) ([]domain.ProjectRole, error)
+	GetByID(ctx context.Context, projectID, id uuid.UUID) (*domain.ProjectRole, error)
+	Create(ctx context.Context, r *domain.ProjectRole) error
+	Update(ctx context.Context, r *domain.ProjectRole) error
+	// Delete fails with ErrRoleInUse while members have the role
+	Delete(ctx context.Context, projectID, id uuid.UUID) error
+	// Assign sets or clears (roleID nil) the custom role of a membership
+	Assign(ctx context.Context, projectID, membershipID uuid.UUID, roleID *uuid.UUID) error
+	// HolderRoles returns the built-in roles of the members holding a role
+	HolderRoles(ctx context.Context, projectID, id uuid.UUID) ([]domain.Role, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -21,6 +22,61 @@ func NewMembershipRepository(db *pgxpool.Pool) MembershipRepository {
 	return &membershipRepository{db: db}
 }
 
+// membershipWithRoleQuery selects memberships together with their custom
+// role, if any
+const membershipWithRoleQuery = `
+	SELECT m.id, m.project_id, m.user_id, m.role, m.display_name, m.created_at, m.updated_at,
+		pr.id, pr.project_id, pr.name, pr.description, pr.permissions, pr.created_at, pr.updated_at
+	FROM memberships m
+	LEFT JOIN project_roles pr ON pr.id = m.custom_role_id
+`
+
+func scanMembershipWithRole(row pgx.Row) (*domain.Membership, error) {
+	var m domain.Membership
+	var role struct {
+		ID          *uuid.UUID
+		ProjectID   *uuid.UUID
+		Name        *string
+		Description *string
+		Permissions []domain.Permission
+		CreatedAt   *time.Time
+		UpdatedAt   *time.Time
+	}
+	err := row.Scan(
+		&m.ID,
+		&m.ProjectID,
+		&m.UserID,
+		&m.Role,
+		&m.DisplayName,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&role.ID,
+		&role.ProjectID,
+		&role.Name,
+		&role.Description,
+		&role.Permissions,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.ID != nil {
+		m.CustomRoleID = role.ID
+		m.CustomRole = &domain.ProjectRole{
+			ID:          *role.ID,
+			ProjectID:   *role.ProjectID,
+			Name:        *role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+			CreatedAt:   *role.CreatedAt,
+			UpdatedAt:   *role.UpdatedAt,
+		}
+	}
+	return &m, nil
+}
+
 func (r *membershipRepository) Create(ctx context.Context, m *domain.Membership) error {
 	query := `
 		INSERT INTO memberships (id, project_id, user_id, role, display_name, created_at, updated_at)
@@ -44,23 +100,9 @@ func (r *membershipRepository) Create(ctx context.Context, m *domain.Membership)
 }
 
 func (r *membershipRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Membership, error) {
-	query := `
-		SELECT id, project_id, user_id, role, display_name, created_at, updated_at
-		FROM memberships
-		WHERE id = $1
-	`
-
-	var m domain.Membership
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&m.ID,
-		&m.ProjectID,
-		&m.UserID,
-		&m.Role,
-		&m.DisplayName,
-		&m.CreatedAt,
-		&m.UpdatedAt,
-	)
+	query := membershipWithRoleQuery + `WHERE m.id = $1`
 
+	m, err := scanMembershipWithRole(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -68,27 +110,13 @@ func (r *membershipRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 		return nil, fmt.Errorf("failed to get membership: %w", err)
 	}
 
-	return &m, nil
+	return m, nil
 }
 
 func (r *membershipRepository) GetByProjectAndUser(ctx context.Context, projectID, userID string) (*domain.Membership, error) {
-	query := `
-		SELECT id, project_id, user_id, role, display_name, created_at, updated_at
-		FROM memberships
-		WHERE project_id = $1 AND user_id = $2
-	`
-
-	var m domain.Membership
-	err := r.db.QueryRow(ctx, query, projectID, userID).Scan(
-		&m.ID,
-		&m.ProjectID,
-		&m.UserID,
-		&m.Role,
-		&m.DisplayName,
-		&m.CreatedAt,
-		&m.UpdatedAt,
-	)
+	query := membershipWithRoleQuery + `WHERE m.project_id = $1 AND m.user_id = $2`
 
+	m, err := scanMembershipWithRole(r.db.QueryRow(ctx, query, projectID, userID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Not found, but not an error - user just isn't a member
@@ -96,7 +124,7 @@ func (r *membershipRepository) GetByProjectAndUser(ctx context.Context, projectI
 		return nil, fmt.Errorf("failed to get membership: %w", err)
 	}
 
-	return &m, nil
+	return m, nil
 }
 
 func (r *membershipRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]domain.Membership, error) {
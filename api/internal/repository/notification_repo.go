@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// maxNotificationAttempts is how often an event is claimed before the
+// worker gives up on it
+const maxNotificationAttempts = 5
+
+type notificationRepository struct {
+	db   DBTX
+	pool *pgxpool.Pool
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepository{db: db, pool: db}
+}
+
+func (r *notificationRepository) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]domain.NotificationEvent, error) {
+	// Claimed events are leased rather than deleted, so events a crashed
+	// worker was fanning out are picked up again when the lease runs out
+	query := `
+		UPDATE notification_events e
+		SET locked_until = NOW() + make_interval(secs => $2), attempts = e.attempts + 1
+		FROM feedback f
+		WHERE f.id = e.feedback_id
+			AND e.id IN (
+				SELECT id
+				FROM notification_events
+				WHERE processed_at IS NULL
+					AND attempts < $3
+					AND (locked_until IS NULL OR locked_until < NOW())
+				ORDER BY created_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING e.id, e.project_id, e.feedback_id, f.title, f.visibility, e.event_type,
+			e.actor_user_id, e.visibility, e.payload, e.attempts, e.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, lease.Seconds(), maxNotificationAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notification events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.NotificationEvent
+	for rows.Next() {
+		var e domain.NotificationEvent
+		var payloadJSON []byte
+		if err := rows.Scan(
+			&e.ID, &e.ProjectID, &e.FeedbackID, &e.FeedbackTitle, &e.FeedbackVisibility, &e.EventType,
+			&e.ActorUserID, &e.Visibility, &payloadJSON, &e.Attempts, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification event: %w", err)
+		}
+		if err := json.Unmarshal(payloadJSON, &e.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification event payload: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *notificationRepository) ListSubscribers(ctx context.Context, event *domain.NotificationEvent) ([]domain.Subscriber, error) {
+	// SDK users linked to a Supabase account are notified as that account
+	query := `
+		SELECT
+			COALESCE(s.user_id, su.linked_user_id) AS recipient_id,
+			CASE WHEN s.user_id IS NULL AND su.linked_user_id IS NULL THEN s.sdk_user_id END,
+			s.reason,
+			s.muted,
+			COALESCE(m.role <> 'community', false),
+			(f.author_id IS NOT NULL AND f.author_id = COALESCE(s.user_id, su.linked_user_id))
+				OR (f.sdk_user_id IS NOT NULL AND f.sdk_user_id = s.sdk_user_id),
+			pup.notification_preferences,
+			` + memberPreferencesColumn + `
+		FROM feedback_subscriptions s
+		JOIN feedback f ON f.id = s.feedback_id
+		JOIN projects p ON p.id = f.project_id
+		LEFT JOIN sdk_users su ON su.id = s.sdk_user_id
+		LEFT JOIN effective_memberships m
+			ON m.user_id = COALESCE(s.user_id, su.linked_user_id) AND m.project_id = $2
+		LEFT JOIN portal_user_profiles pup
+			ON pup.user_id = COALESCE(s.user_id, su.linked_user_id) AND pup.project_id = $2
+		WHERE s.feedback_id = $1
+	`
+
+	rows, err := r.db.Query(ctx, query, event.FeedbackID, event.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	var subscribers []domain.Subscriber
+	for rows.Next() {
+		var s domain.Subscriber
+		var prefsJSON, teamPrefsJSON []byte
+		if err := rows.Scan(&s.UserID, &s.SDKUserID, &s.Reason, &s.Muted, &s.IsTeam, &s.IsSubmitter, &prefsJSON, &teamPrefsJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan subscriber: %w", err)
+		}
+		s.Preferences = domain.DefaultPortalNotificationPreferences()
+		if len(prefsJSON) > 0 {
+			if err := json.Unmarshal(prefsJSON, &s.Preferences); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal notification preferences: %w", err)
+			}
+		}
+		teamPrefs, err := memberPreferences(teamPrefsJSON)
+		if err != nil {
+			return nil, err
+		}
+		s.TeamPreferences = teamPrefs
+		subscribers = append(subscribers, s)
+	}
+
+	return subscribers, rows.Err()
+}
+
+func (r *notificationRepository) GetMemberRecipient(ctx context.Context, projectID, userID uuid.UUID) (*domain.MemberRecipient, error) {
+	// A member who has left the project has no role
+	query := `
+		SELECT m.role, ` + memberPreferencesColumn + `
+		FROM projects p
+		LEFT JOIN effective_memberships m ON m.project_id = p.id AND m.user_id = $2
+		WHERE p.id = $1
+	`
+
+	recipient := &domain.MemberRecipient{UserID: userID}
+	var prefsJSON []byte
+	if err := r.db.QueryRow(ctx, query, projectID, userID).Scan(&recipient.Role, &prefsJSON); err != nil {
+		return nil, fmt.Errorf("failed to get notification recipient: %w", err)
+	}
+
+	prefs, err := memberPreferences(prefsJSON)
+	if err != nil {
+		return nil, err
+	}
+	recipient.Preferences = prefs
+
+	return recipient, nil
+}
+
+func (r *notificationRepository) ListViewWatchers(ctx context.Context, event *domain.NotificationEvent) ([]domain.ViewWatcher, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT sv.id, sv.name, sv.filter, sv.owner_id, m.role, `+memberPreferencesColumn+`
+		FROM saved_views sv
+		JOIN projects p ON p.id = sv.project_id
+		JOIN effective_memberships m ON m.project_id = sv.project_id AND m.user_id = sv.owner_id
+		WHERE sv.project_id = $1 AND sv.notify = true
+		ORDER BY sv.created_at
+	`, event.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list view watchers: %w", err)
+	}
+
+	var candidates []domain.ViewWatcher
+	var filters []domain.SavedViewFilter
+	for rows.Next() {
+		var w domain.ViewWatcher
+		var role domain.Role
+		var filterJSON, prefsJSON []byte
+		if err := rows.Scan(&w.ViewID, &w.ViewName, &filterJSON, &w.UserID, &role, &prefsJSON); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan view watcher: %w", err)
+		}
+		w.Role = &role
+
+		var filter domain.SavedViewFilter
+		if len(filterJSON) > 0 {
+			if err := json.Unmarshal(filterJSON, &filter); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to unmarshal view filter: %w", err)
+			}
+		}
+		if w.Preferences, err = memberPreferences(prefsJSON); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		candidates = append(candidates, w)
+		filters = append(filters, filter)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list view watchers: %w", err)
+	}
+
+	// Match the new item against each view's filter with the same
+	// conditions the feedback list uses
+	var watchers []domain.ViewWatcher
+	for i, w := range candidates {
+		whereClause, args, argIndex := buildFeedbackConditions(event.ProjectID, FeedbackFilterFromView(filters[i]))
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM feedback WHERE %s AND id = $%d)`, whereClause, argIndex)
+		args = append(args, event.FeedbackID)
+
+		var matches bool
+		if err := r.db.QueryRow(ctx, query, args...).Scan(&matches); err != nil {
+			return nil, fmt.Errorf("failed to match saved view: %w", err)
+		}
+		if matches {
+			watchers = append(watchers, w)
+		}
+	}
+
+	return watchers, nil
+}
+
+// memberPreferencesColumn selects a member's notification preferences,
+// falling back to the project's; it expects effective_memberships as m and
+// projects as p
+const memberPreferencesColumn = `COALESCE(m.notification_preferences, p.settings->'notification_preferences')`
+
+// memberPreferences decodes stored preferences over the defaults, so that
+// preferences saved before a type existed keep its default
+func memberPreferences(prefsJSON []byte) (domain.NotificationPreferences, error) {
+	prefs := domain.DefaultNotificationPreferences()
+	if len(prefsJSON) > 0 {
+		if err := json.Unmarshal(prefsJSON, &prefs); err != nil {
+			return prefs, fmt.Errorf("failed to unmarshal notification preferences: %w", err)
+		}
+	}
+	return prefs, nil
+}
+
+func (r *notificationRepository) CompleteEvent(ctx context.Context, eventID uuid.UUID, inbox []domain.Notification, emails []domain.NotificationQueueEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin notification transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, n := range inbox {
+		payloadJSON, err := json.Marshal(n.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification payload: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO notifications (user_id, project_id, feedback_id, event_id, notification_type, payload)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, n.UserID, n.ProjectID, n.FeedbackID, eventID, n.NotificationType, payloadJSON)
+		if err != nil {
+			return fmt.Errorf("failed to store notification: %w", err)
+		}
+	}
+
+	for _, n := range emails {
+		payloadJSON, err := json.Marshal(n.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification payload: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO notification_queue (user_id, sdk_user_id, project_id, event_id, notification_type, payload)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, n.UserID, n.SDKUserID, n.ProjectID, eventID, n.NotificationType, payloadJSON)
+		if err != nil {
+			return fmt.Errorf("failed to queue notification: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE notification_events SET processed_at = NOW(), locked_until = NULL WHERE id = $1
+	`, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to complete notification event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit notification transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestNotificationRepository_GetMemberRecipient(t *testing.T) {
+	ctx := context.Background()
+	projectID, userID := uuid.New(), uuid.New()
+
+	t.Run("organization admins without a membership row keep their role", func(t *testing.T) {
+		admin := domain.RoleAdmin
+		db := &fakeDB{results: []fakeResult{{row: []any{&admin, []byte(nil)}}}}
+
+		recipient, err := (&notificationRepository{db: db}).GetMemberRecipient(ctx, projectID, userID)
+		require.NoError(t, err)
+		require.NotNil(t, recipient.Role)
+		assert.Equal(t, domain.RoleAdmin, *recipient.Role)
+		assert.Contains(t, db.queries[0], "LEFT JOIN effective_memberships m")
+		assert.True(t, recipient.CanSee(&domain.NotificationEvent{
+			Visibility:         domain.VisibilityTeamOnly,
+			FeedbackVisibility: domain.VisibilityTeamOnly,
+		}))
+	})
+
+	t.Run("former members have no role", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{row: []any{nil, []byte(nil)}}}}
+
+		recipient, err := (&notificationRepository{db: db}).GetMemberRecipient(ctx, projectID, userID)
+		require.NoError(t, err)
+		assert.Nil(t, recipient.Role)
+	})
+}
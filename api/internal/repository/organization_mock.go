@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockOrganizationRepository is a mock implementation of OrganizationRepository for testing
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+// NewMockOrganizationRepository creates a new mock organization repository
+func NewMockOrganizationRepository() *MockOrganizationRepository {
+	return &MockOrganizationRepository{}
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, o *domain.Organization, ownerID uuid.UUID) error {
+	args := m.Called(ctx, o, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) Update(ctx context.Context, o *domain.Organization) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.OrganizationWithRole, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationWithRole), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) Usage(ctx context.Context, id uuid.UUID) (*domain.OrganizationUsage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationUsage), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListProjects(ctx context.Context, id uuid.UUID) ([]domain.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMember(ctx context.Context, organizationID, userID string) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, organizationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMemberForProject(ctx context.Context, projectID, userID string) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]domain.OrganizationMember, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) UpdateMemberRole(ctx context.Context, member *domain.OrganizationMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	args := m.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) SearchFeedback(ctx context.Context, organizationID uuid.UUID, query string, limit int) ([]domain.OrganizationSearchResult, error) {
+	args := m.Called(ctx, organizationID, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationSearchResult), args.Error(1)
+}
+
+// Ensure MockOrganizationRepository implements OrganizationRepository
+var _ OrganizationRepository = (*MockOrganizationRepository)(nil)
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type organizationRepository struct {
+	db   DBTX
+	pool *pgxpool.Pool
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *pgxpool.Pool) OrganizationRepository {
+	return &organizationRepository{db: db, pool: db}
+}
+
+const organizationColumns = `id, name, slug, max_projects, max_members, created_at, updated_at`
+
+const organizationMemberColumns = `id, organization_id, user_id, role, created_at, updated_at`
+
+func scanOrganizationMember(row pgx.Row) (*domain.OrganizationMember, error) {
+	var m domain.OrganizationMember
+	err := row.Scan(&m.ID, &m.OrganizationID, &m.UserID, &m.Role, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *organizationRepository) Create(ctx context.Context, o *domain.Organization, ownerID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin organization transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO organizations (id, name, slug, max_projects, max_members)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at
+	`, o.ID, o.Name, o.Slug, o.MaxProjects, o.MaxMembers).Scan(&o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, 'owner')
+	`, o.ID, ownerID); err != nil {
+		return fmt.Errorf("failed to create organization owner: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit organization: %w", err)
+	}
+
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var o domain.Organization
+	err := r.db.QueryRow(ctx, `
+		SELECT `+organizationColumns+`
+		FROM organizations
+		WHERE id = $1
+	`, id).Scan(&o.ID, &o.Name, &o.Slug, &o.MaxProjects, &o.MaxMembers, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &o, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, o *domain.Organization) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE organizations SET name = $2
+		WHERE id = $1
+		RETURNING updated_at
+	`, o.ID, o.Name).Scan(&o.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrOrganizationNotFound
+		}
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.OrganizationWithRole, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT o.id, o.name, o.slug, o.max_projects, o.max_members, o.created_at, o.updated_at, m.role
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := []domain.OrganizationWithRole{}
+	for rows.Next() {
+		var o domain.OrganizationWithRole
+		if err := rows.Scan(&o.ID, &o.Name, &o.Slug, &o.MaxProjects, &o.MaxMembers, &o.CreatedAt, &o.UpdatedAt, &o.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, o)
+	}
+
+	return orgs, rows.Err()
+}
+
+func (r *organizationRepository) Usage(ctx context.Context, id uuid.UUID) (*domain.OrganizationUsage, error) {
+	var u domain.OrganizationUsage
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM projects WHERE organization_id = $1 AND deletion_scheduled_at IS NULL),
+			(SELECT COUNT(*) FROM organization_members WHERE organization_id = $1)
+	`, id).Scan(&u.Projects, &u.Members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count organization usage: %w", err)
+	}
+	return &u, nil
+}
+
+func (r *organizationRepository) ListProjects(ctx context.Context, id uuid.UUID) ([]domain.Project, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+projectColumns+`
+		FROM projects
+		WHERE organization_id = $1
+		ORDER BY name
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []domain.Project{}
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, *p)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, organizationID, userID string) (*domain.OrganizationMember, error) {
+	m, err := scanOrganizationMember(r.db.QueryRow(ctx, `
+		SELECT `+organizationMemberColumns+`
+		FROM organization_members
+		WHERE organization_id = $1 AND user_id = $2
+	`, organizationID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return m, nil
+}
+
+func (r *organizationRepository) GetMemberForProject(ctx context.Context, projectID, userID string) (*domain.OrganizationMember, error) {
+	m, err := scanOrganizationMember(r.db.QueryRow(ctx, `
+		SELECT m.id, m.organization_id, m.user_id, m.role, m.created_at, m.updated_at
+		FROM organization_members m
+		JOIN projects p ON p.organization_id = m.organization_id
+		WHERE p.id = $1 AND m.user_id = $2
+	`, projectID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return m, nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]domain.OrganizationMember, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+organizationMemberColumns+`
+		FROM organization_members
+		WHERE organization_id = $1
+		ORDER BY created_at
+	`, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []domain.OrganizationMember{}
+	for rows.Next() {
+		m, err := scanOrganizationMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, *m)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, m *domain.OrganizationMember) error {
+	// The limit check and insert are one statement; locking the organization
+	// row keeps concurrent additions from both passing the check
+	err := r.db.QueryRow(ctx, `
+		WITH org AS (
+			SELECT id, max_members FROM organizations WHERE id = $2 FOR UPDATE
+		)
+		INSERT INTO organization_members (id, organization_id, user_id, role)
+		SELECT $1, org.id, $3, $4
+		FROM org
+		WHERE org.max_members IS NULL
+			OR (SELECT COUNT(*) FROM organization_members WHERE organization_id = org.id) < org.max_members
+		RETURNING created_at, updated_at
+	`, m.ID, m.OrganizationID, m.UserID, m.Role).Scan(&m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAlreadyMember.WithMessage("user is already a member of this organization")
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrOrganizationLimit.WithMessage("the organization has reached its member limit")
+		}
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) UpdateMemberRole(ctx context.Context, m *domain.OrganizationMember) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE organization_members SET role = $3, updated_at = NOW()
+		WHERE organization_id = $1 AND user_id = $2
+		RETURNING id, created_at, updated_at
+	`, m.OrganizationID, m.UserID, m.Role).Scan(&m.ID, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrMemberNotFound
+		}
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2
+	`, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *organizationRepository) SearchFeedback(ctx context.Context, organizationID uuid.UUID, query string, limit int) ([]domain.OrganizationSearchResult, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT f.id, p.id, p.name, f.title, f.type, f.status, f.vote_count, f.created_at
+		FROM feedback f
+		JOIN projects p ON p.id = f.project_id
+		WHERE p.organization_id = $1 AND p.archived_at IS NULL
+			AND (f.title ILIKE $2 OR f.description ILIKE $2)
+		ORDER BY f.vote_count DESC, f.created_at DESC
+		LIMIT $3
+	`, organizationID, "%"+escapeLike(query)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search organization feedback: %w", err)
+	}
+	defer rows.Close()
+
+	results := []domain.OrganizationSearchResult{}
+	for rows.Next() {
+		var res domain.OrganizationSearchResult
+		if err := rows.Scan(&res.FeedbackID, &res.ProjectID, &res.ProjectName, &res.Title,
+			&res.Type, &res.Status, &res.VoteCount, &res.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
@@ -42,6 +42,12 @@ func (m *MockPortalRepository) LinkSDKUsersByEmail(ctx context.Context, userID,
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockPortalRepository) LinkSDKUser(ctx context.Context, projectID, userID uuid.UUID) (uuid.UUID, *string, error) {
+	args := m.Called(ctx, projectID, userID)
+	name, _ := args.Get(1).(*string)
+	return args.Get(0).(uuid.UUID), name, args.Error(2)
+}
+
 func (m *MockPortalRepository) GetLinkedFeedback(ctx context.Context, userID, projectID uuid.UUID) ([]domain.PortalFeedbackSummary, error) {
 	args := m.Called(ctx, userID, projectID)
 	if args.Get(0) == nil {
@@ -58,8 +64,8 @@ func (m *MockPortalRepository) ListPublicFeatures(ctx context.Context, projectID
 	return args.Get(0).([]domain.PortalFeedbackSummary), args.Int(1), args.Error(2)
 }
 
-func (m *MockPortalRepository) CreateVote(ctx context.Context, feedbackID, userID, projectID uuid.UUID) error {
-	args := m.Called(ctx, feedbackID, userID, projectID)
+func (m *MockPortalRepository) CreateVote(ctx context.Context, feedbackID, userID, projectID uuid.UUID, details domain.VoteDetails) error {
+	args := m.Called(ctx, feedbackID, userID, projectID, details)
 	return args.Error(0)
 }
 
@@ -68,6 +74,14 @@ func (m *MockPortalRepository) DeleteVote(ctx context.Context, feedbackID, userI
 	return args.Error(0)
 }
 
+func (m *MockPortalRepository) GetVoteBudget(ctx context.Context, projectID, userID uuid.UUID) (*domain.VoteBudgetUsage, error) {
+	args := m.Called(ctx, projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VoteBudgetUsage), args.Error(1)
+}
+
 func (m *MockPortalRepository) HasVoted(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error) {
 	args := m.Called(ctx, feedbackID, userID)
 	return args.Bool(0), args.Error(1)
@@ -81,5 +95,42 @@ func (m *MockPortalRepository) GetVotedFeedbackIDs(ctx context.Context, userID u
 	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
 }
 
+func (m *MockPortalRepository) CreateFeedback(ctx context.Context, f *domain.Feedback, userID *uuid.UUID) error {
+	args := m.Called(ctx, f, userID)
+	return args.Error(0)
+}
+
+func (m *MockPortalRepository) GetFeedbackDetail(ctx context.Context, projectID, feedbackID uuid.UUID, userID *uuid.UUID) (*domain.PortalFeedbackDetail, error) {
+	args := m.Called(ctx, projectID, feedbackID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PortalFeedbackDetail), args.Error(1)
+}
+
+func (m *MockPortalRepository) ListAttachments(ctx context.Context, feedbackID uuid.UUID) ([]domain.PortalAttachment, error) {
+	args := m.Called(ctx, feedbackID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PortalAttachment), args.Error(1)
+}
+
+func (m *MockPortalRepository) ListStatusHistory(ctx context.Context, feedbackID uuid.UUID) ([]domain.StatusChange, error) {
+	args := m.Called(ctx, feedbackID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.StatusChange), args.Error(1)
+}
+
+func (m *MockPortalRepository) ListComments(ctx context.Context, feedbackID uuid.UUID, userID *uuid.UUID) ([]domain.PortalComment, error) {
+	args := m.Called(ctx, feedbackID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PortalComment), args.Error(1)
+}
+
 // Ensure MockPortalRepository implements PortalRepository
 var _ PortalRepository = (*MockPortalRepository)(nil)
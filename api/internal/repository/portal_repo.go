@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -119,11 +118,12 @@ func (r *portalRepository) LinkSDKUsersByEmail(ctx context.Context, userID, proj
 }
 
 func (r *portalRepository) GetLinkedFeedback(ctx context.Context, userID, projectID uuid.UUID) ([]domain.PortalFeedbackSummary, error) {
+	voted, _ := voterCondition("v", domain.UserVoter(userID), 1)
 	query := `
 		SELECT
 			f.id, f.title, f.description, f.type, f.status,
 			f.vote_count, f.comment_count, f.created_at, f.updated_at,
-			EXISTS(SELECT 1 FROM portal_votes pv WHERE pv.feedback_id = f.id AND pv.user_id = $1) as has_voted
+			EXISTS(SELECT 1 FROM votes v WHERE v.feedback_id = f.id AND ` + voted + `) as has_voted
 		FROM feedback f
 		WHERE f.project_id = $2
 		AND f.canonical_id IS NULL
@@ -165,8 +165,9 @@ func (r *portalRepository) ListPublicFeatures(ctx context.Context, projectID uui
 	argIndex++
 
 	if userID != nil {
-		hasVotedExpr = fmt.Sprintf("EXISTS(SELECT 1 FROM portal_votes pv WHERE pv.feedback_id = f.id AND pv.user_id = $%d)", argIndex)
-		args = append(args, *userID)
+		voted, arg := voterCondition("v", domain.UserVoter(*userID), argIndex)
+		hasVotedExpr = "EXISTS(SELECT 1 FROM votes v WHERE v.feedback_id = f.id AND " + voted + ")"
+		args = append(args, arg)
 		argIndex++
 	} else {
 		hasVotedExpr = "false"
@@ -179,7 +180,7 @@ func (r *portalRepository) ListPublicFeatures(ctx context.Context, projectID uui
 		WHERE f.project_id = $1
 		AND f.canonical_id IS NULL
 		AND f.type = 'feature'
-		AND f.visibility = 'public'
+		AND f.visibility = 'COMMUNITY'
 	`
 
 	var total int
@@ -197,7 +198,7 @@ func (r *portalRepository) ListPublicFeatures(ctx context.Context, projectID uui
 		WHERE f.project_id = $1
 		AND f.canonical_id IS NULL
 		AND f.type = 'feature'
-		AND f.visibility = 'public'
+		AND f.visibility = 'COMMUNITY'
 		ORDER BY f.vote_count DESC, f.created_at DESC
 		LIMIT $%d OFFSET $%d
 	`, hasVotedExpr, argIndex, argIndex+1)
@@ -226,104 +227,276 @@ func (r *portalRepository) ListPublicFeatures(ctx context.Context, projectID uui
 	return feedback, total, nil
 }
 
-func (r *portalRepository) CreateVote(ctx context.Context, feedbackID, userID, projectID uuid.UUID) error {
-	query := `
-		INSERT INTO portal_votes (id, feedback_id, user_id, project_id)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (feedback_id, user_id) DO NOTHING
-	`
-
-	_, err := r.db.Exec(ctx, query, uuid.New(), feedbackID, userID, projectID)
+func (r *portalRepository) CreateVote(ctx context.Context, feedbackID, userID, projectID uuid.UUID, details domain.VoteDetails) error {
+	// Team-only items are hidden from everyone but their submitter, so
+	// they can't be voted on by ID either
+	var status domain.FeedbackStatus
+	var visible, votingEnabled bool
+	err := r.db.QueryRow(ctx, `
+		SELECT f.status,
+			f.visibility = 'COMMUNITY' OR COALESCE(f.author_id = $3 OR f.sdk_user_id IN (
+				SELECT id FROM sdk_users WHERE project_id = f.project_id AND linked_user_id = $3
+			), false),
+			COALESCE((p.settings->>'voting_enabled')::boolean, true)
+		FROM feedback f
+		JOIN projects p ON p.id = f.project_id
+		WHERE f.id = $1 AND f.project_id = $2
+	`, feedbackID, projectID, userID).Scan(&status, &visible, &votingEnabled)
 	if err != nil {
-		if strings.Contains(err.Error(), "fk_portal_votes_feedback") {
-			return domain.ErrNotFound
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrFeedbackNotFound
+		}
+		return fmt.Errorf("failed to check feedback: %w", err)
+	}
+	if !visible {
+		return domain.ErrFeedbackNotFound
+	}
+	if !votingEnabled {
+		return domain.ErrVotingDisabled
+	}
+
+	vote := &domain.Vote{
+		FeedbackID:  feedbackID,
+		Voter:       domain.UserVoter(userID),
+		VoteDetails: details,
+	}
+
+	// Votes on resolved items don't use the budget
+	if status.IsResolved() {
+		return insertVote(ctx, r.db, vote)
+	}
+	return insertVoteWithinBudget(ctx, r.db, projectID, vote)
+}
+
+func (r *portalRepository) DeleteVote(ctx context.Context, feedbackID, userID uuid.UUID) error {
+	return deleteVote(ctx, r.db, feedbackID, domain.UserVoter(userID))
+}
+
+func (r *portalRepository) HasVoted(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error) {
+	return voteExists(ctx, r.db, feedbackID, domain.UserVoter(userID))
+}
+
+func (r *portalRepository) GetVotedFeedbackIDs(ctx context.Context, userID uuid.UUID, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	return votedFeedbackIDs(ctx, r.db, domain.UserVoter(userID), feedbackIDs)
+}
+
+func (r *portalRepository) GetVoteBudget(ctx context.Context, projectID, userID uuid.UUID) (*domain.VoteBudgetUsage, error) {
+	return voteBudget(ctx, r.db, projectID, domain.UserVoter(userID))
+}
+
+func (r *portalRepository) CreateFeedback(ctx context.Context, f *domain.Feedback, userID *uuid.UUID) error {
+	// Signed-in submissions are tied to the user's linked SDK user so they
+	// show up in "my feedback" alongside items sent from the app
+	var sdkUserID *uuid.UUID
+	if userID != nil {
+		id, err := r.linkedSDKUser(ctx, f.ProjectID, *userID, f.SubmitterEmail)
+		if err != nil {
+			return err
 		}
-		return fmt.Errorf("failed to create vote: %w", err)
+		sdkUserID = &id
 	}
 
-	// Update vote count on feedback
-	updateQuery := `
-		UPDATE feedback
-		SET vote_count = (
-			SELECT COUNT(*) FROM portal_votes WHERE feedback_id = $1
-		) + (
-			SELECT COUNT(*) FROM votes WHERE feedback_id = $1
+	query := `
+		INSERT INTO feedback (
+			id, project_id, author_id, sdk_user_id, title, description, type, status, visibility,
+			submitter_email, submitter_name, submitter_identifier, source
 		)
-		WHERE id = $1
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at, updated_at
 	`
 
-	_, err = r.db.Exec(ctx, updateQuery, feedbackID)
+	err := r.db.QueryRow(ctx, query,
+		f.ID,
+		f.ProjectID,
+		f.AuthorID,
+		sdkUserID,
+		f.Title,
+		f.Description,
+		f.Type,
+		f.Status,
+		f.Visibility,
+		f.SubmitterEmail,
+		f.SubmitterName,
+		f.SubmitterIdentifier,
+		f.Source,
+	).Scan(&f.CreatedAt, &f.UpdatedAt)
+
 	if err != nil {
-		return fmt.Errorf("failed to update vote count: %w", err)
+		return fmt.Errorf("failed to create feedback: %w", err)
 	}
 
 	return nil
 }
 
-func (r *portalRepository) DeleteVote(ctx context.Context, feedbackID, userID uuid.UUID) error {
-	query := `DELETE FROM portal_votes WHERE feedback_id = $1 AND user_id = $2`
-
-	result, err := r.db.Exec(ctx, query, feedbackID, userID)
+func (r *portalRepository) LinkSDKUser(ctx context.Context, projectID, userID uuid.UUID) (uuid.UUID, *string, error) {
+	id, err := r.linkedSDKUser(ctx, projectID, userID, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete vote: %w", err)
+		return uuid.Nil, nil, err
 	}
 
-	if result.RowsAffected() == 0 {
-		return domain.ErrNotFound
+	var name *string
+	if err := r.db.QueryRow(ctx, `SELECT name FROM sdk_users WHERE id = $1`, id).Scan(&name); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("failed to get linked SDK user: %w", err)
 	}
 
-	// Update vote count on feedback
-	updateQuery := `
-		UPDATE feedback
-		SET vote_count = (
-			SELECT COUNT(*) FROM portal_votes WHERE feedback_id = $1
-		) + (
-			SELECT COUNT(*) FROM votes WHERE feedback_id = $1
-		)
-		WHERE id = $1
+	return id, name, nil
+}
+
+// linkedSDKUser returns the SDK user linked to a portal user, creating one
+// keyed by the portal user's ID if they have never used the app
+func (r *portalRepository) linkedSDKUser(ctx context.Context, projectID, userID uuid.UUID, email *string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, `
+		SELECT id FROM sdk_users
+		WHERE project_id = $1 AND linked_user_id = $2
+		ORDER BY last_seen_at DESC
+		LIMIT 1
+	`, projectID, userID).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("failed to find linked SDK user: %w", err)
+	}
+
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO sdk_users (project_id, external_id, email, linked_user_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project_id, external_id) DO UPDATE SET last_seen_at = NOW()
+		RETURNING id
+	`, projectID, "portal:"+userID.String(), email, userID).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create linked SDK user: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *portalRepository) GetFeedbackDetail(ctx context.Context, projectID, feedbackID uuid.UUID, userID *uuid.UUID) (*domain.PortalFeedbackDetail, error) {
+	query := `
+		SELECT
+			f.id, f.title, f.description, f.type, f.status,
+			f.vote_count, f.comment_count, f.created_at, f.updated_at,
+			f.visibility, f.canonical_id,
+			COALESCE(EXISTS(
+				SELECT 1 FROM votes v
+				WHERE v.feedback_id = f.id
+				AND (v.user_id = $3 OR v.sdk_user_id IN (SELECT id FROM sdk_users WHERE linked_user_id = $3))
+			), false),
+			COALESCE(f.author_id = $3 OR f.sdk_user_id IN (
+				SELECT id FROM sdk_users WHERE project_id = f.project_id AND linked_user_id = $3
+			), false)
+		FROM feedback f
+		WHERE f.id = $1 AND f.project_id = $2
 	`
 
-	_, err = r.db.Exec(ctx, updateQuery, feedbackID)
+	var d domain.PortalFeedbackDetail
+	err := r.db.QueryRow(ctx, query, feedbackID, projectID, userID).Scan(
+		&d.ID, &d.Title, &d.Description, &d.Type, &d.Status,
+		&d.VoteCount, &d.CommentCount, &d.CreatedAt, &d.UpdatedAt,
+		&d.Visibility, &d.MergedInto,
+		&d.HasVoted, &d.IsMine,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to update vote count: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrFeedbackNotFound
+		}
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
 	}
 
-	return nil
+	return &d, nil
 }
 
-func (r *portalRepository) HasVoted(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM portal_votes WHERE feedback_id = $1 AND user_id = $2)`
+func (r *portalRepository) ListComments(ctx context.Context, feedbackID uuid.UUID, userID *uuid.UUID) ([]domain.PortalComment, error) {
+	// Team members show under their display name; portal users under their
+	// linked SDK user's name
+	query := `
+		SELECT
+			c.id, c.body, c.is_edited, c.created_at,
+			COALESCE(m.display_name, su.name, c.external_author_name),
+			COALESCE(m.role IN ('viewer', 'member', 'admin', 'owner'), false),
+			COALESCE(c.author_id = $2, false)
+		FROM comments c
+		JOIN feedback f ON f.id = c.feedback_id
+		LEFT JOIN memberships m ON m.project_id = f.project_id AND m.user_id = c.author_id
+		LEFT JOIN sdk_users su ON su.id = c.sdk_user_id
+		WHERE c.feedback_id = $1
+		AND c.visibility = 'COMMUNITY'
+		AND c.deleted_at IS NULL
+		ORDER BY c.created_at ASC
+	`
 
-	var exists bool
-	err := r.db.QueryRow(ctx, query, feedbackID, userID).Scan(&exists)
+	rows, err := r.db.Query(ctx, query, feedbackID, userID)
 	if err != nil {
-		return false, fmt.Errorf("failed to check vote: %w", err)
+		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
+	defer rows.Close()
 
-	return exists, nil
+	comments := []domain.PortalComment{}
+	for rows.Next() {
+		var c domain.PortalComment
+		if err := rows.Scan(&c.ID, &c.Body, &c.IsEdited, &c.CreatedAt, &c.AuthorName, &c.IsTeam, &c.IsMine); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
 }
 
-func (r *portalRepository) GetVotedFeedbackIDs(ctx context.Context, userID uuid.UUID, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
-	if len(feedbackIDs) == 0 {
-		return make(map[uuid.UUID]bool), nil
+func (r *portalRepository) ListAttachments(ctx context.Context, feedbackID uuid.UUID) ([]domain.PortalAttachment, error) {
+	query := `
+		SELECT id, filename, content_type, size_bytes, gcs_path, created_at
+		FROM attachments
+		WHERE feedback_id = $1 AND status = 'uploaded'
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, feedbackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
 	}
+	defer rows.Close()
 
-	query := `SELECT feedback_id FROM portal_votes WHERE user_id = $1 AND feedback_id = ANY($2)`
+	attachments := []domain.PortalAttachment{}
+	for rows.Next() {
+		var a domain.PortalAttachment
+		if err := rows.Scan(&a.ID, &a.Filename, &a.ContentType, &a.SizeBytes, &a.StoragePath, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	return attachments, rows.Err()
+}
 
-	rows, err := r.db.Query(ctx, query, userID, feedbackIDs)
+func (r *portalRepository) ListStatusHistory(ctx context.Context, feedbackID uuid.UUID) ([]domain.StatusChange, error) {
+	query := `
+		SELECT changes->>'from', changes->>'to', created_at
+		FROM activity_log
+		WHERE feedback_id = $1 AND action = 'status_changed'
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, feedbackID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get voted feedback IDs: %w", err)
+		return nil, fmt.Errorf("failed to list status history: %w", err)
 	}
 	defer rows.Close()
 
-	result := make(map[uuid.UUID]bool)
+	history := []domain.StatusChange{}
 	for rows.Next() {
-		var feedbackID uuid.UUID
-		if err := rows.Scan(&feedbackID); err != nil {
-			return nil, fmt.Errorf("failed to scan feedback ID: %w", err)
+		var s domain.StatusChange
+		var to *domain.FeedbackStatus
+		if err := rows.Scan(&s.From, &to, &s.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		if to == nil {
+			continue
 		}
-		result[feedbackID] = true
+		s.To = *to
+		history = append(history, s)
 	}
 
-	return result, nil
+	return history, rows.Err()
 }
@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/fulldisclosure/api/internal/domain"
 )
@@ -137,13 +139,55 @@ func TestMockPortalRepository_CreateVote(t *testing.T) {
 	userID := uuid.New()
 	projectID := uuid.New()
 
-	mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID).Return(nil)
+	mockRepo.On("CreateVote", mock.Anything, feedbackID, userID, projectID, domain.VoteDetails{}).Return(nil)
 
-	err := mockRepo.CreateVote(context.Background(), feedbackID, userID, projectID)
+	err := mockRepo.CreateVote(context.Background(), feedbackID, userID, projectID, domain.VoteDetails{})
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestPortalRepository_CreateVote(t *testing.T) {
+	ctx := context.Background()
+	feedbackID, userID, projectID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("team-only items hidden from the user are not found", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{row: []any{domain.StatusNew, false, true}}}}
+
+		err := (&portalRepository{db: db}).CreateVote(ctx, feedbackID, userID, projectID, domain.VoteDetails{})
+		assert.ErrorIs(t, err, domain.ErrFeedbackNotFound)
+		assert.Len(t, db.queries, 1, "no vote is written")
+		assert.Contains(t, db.queries[0], "f.visibility = 'COMMUNITY' OR")
+		assert.Equal(t, []any{feedbackID, projectID, userID}, db.args[0])
+	})
+
+	t.Run("voting disabled for the project", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{row: []any{domain.StatusNew, true, false}}}}
+
+		err := (&portalRepository{db: db}).CreateVote(ctx, feedbackID, userID, projectID, domain.VoteDetails{})
+		assert.ErrorIs(t, err, domain.ErrVotingDisabled)
+		assert.Len(t, db.queries, 1)
+	})
+
+	t.Run("importance and note are written with the vote", func(t *testing.T) {
+		note := "Blocks our rollout"
+		db := &fakeDB{results: []fakeResult{
+			{row: []any{domain.StatusCompleted, true, true}},
+			{row: []any{domain.ImportanceCritical, time.Now()}},
+		}}
+
+		err := (&portalRepository{db: db}).CreateVote(ctx, feedbackID, userID, projectID, domain.VoteDetails{
+			Importance: domain.ImportanceCritical,
+			Note:       &note,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, db.queries, 2, "the vote and its details are one insert")
+		assert.Contains(t, db.queries[1], "INSERT INTO votes")
+		assert.Equal(t, "critical", db.args[1][5])
+		assert.Equal(t, &note, db.args[1][6])
+	})
+}
+
 func TestMockPortalRepository_DeleteVote(t *testing.T) {
 	mockRepo := NewMockPortalRepository()
 	feedbackID := uuid.New()
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockProjectLifecycleRepository is a mock implementation of ProjectLifecycleRepository for testing
+type MockProjectLifecycleRepository struct {
+	mock.Mock
+}
+
+// NewMockProjectLifecycleRepository creates a new mock project lifecycle repository
+func NewMockProjectLifecycleRepository() *MockProjectLifecycleRepository {
+	return &MockProjectLifecycleRepository{}
+}
+
+func (m *MockProjectLifecycleRepository) IsArchived(ctx context.Context, projectID string) (bool, error) {
+	args := m.Called(ctx, projectID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) Archive(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) Restore(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) ScheduleDeletion(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.Project, error) {
+	args := m.Called(ctx, projectID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) ListDueForDeletion(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) ListStoragePaths(ctx context.Context, projectID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) Purge(ctx context.Context, projectID uuid.UUID) error {
+	args := m.Called(ctx, projectID)
+	return args.Error(0)
+}
+
+func (m *MockProjectLifecycleRepository) CreateTransfer(ctx context.Context, t *domain.ProjectTransfer) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockProjectLifecycleRepository) GetPendingTransfer(ctx context.Context, projectID uuid.UUID) (*domain.ProjectTransfer, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProjectTransfer), args.Error(1)
+}
+
+func (m *MockProjectLifecycleRepository) CancelTransfer(ctx context.Context, projectID uuid.UUID) error {
+	args := m.Called(ctx, projectID)
+	return args.Error(0)
+}
+
+func (m *MockProjectLifecycleRepository) AcceptTransfer(ctx context.Context, t *domain.ProjectTransfer) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+// Ensure MockProjectLifecycleRepository implements ProjectLifecycleRepository
+var _ ProjectLifecycleRepository = (*MockProjectLifecycleRepository)(nil)
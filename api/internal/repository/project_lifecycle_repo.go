@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type projectLifecycleRepository struct {
+	db   DBTX
+	pool *pgxpool.Pool
+}
+
+// NewProjectLifecycleRepository creates a new project lifecycle repository
+func NewProjectLifecycleRepository(db *pgxpool.Pool) ProjectLifecycleRepository {
+	return &projectLifecycleRepository{db: db, pool: db}
+}
+
+const projectTransferColumns = `id, project_id, from_user_id, to_user_id, created_at, expires_at, accepted_at, cancelled_at`
+
+func (r *projectLifecycleRepository) IsArchived(ctx context.Context, projectID string) (bool, error) {
+	var archived bool
+	err := r.db.QueryRow(ctx, `
+		SELECT archived_at IS NOT NULL FROM projects WHERE id = $1
+	`, projectID).Scan(&archived)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check project archive state: %w", err)
+	}
+	return archived, nil
+}
+
+func (r *projectLifecycleRepository) Archive(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	return r.updateProject(ctx, `
+		WITH cancelled AS (
+			UPDATE project_transfers SET cancelled_at = NOW()
+			WHERE project_id = $1 AND accepted_at IS NULL AND cancelled_at IS NULL
+		)
+		UPDATE projects SET archived_at = COALESCE(archived_at, NOW()), updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+projectColumns, projectID)
+}
+
+func (r *projectLifecycleRepository) Restore(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	return r.updateProject(ctx, `
+		UPDATE projects SET archived_at = NULL, deletion_scheduled_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND (deletion_scheduled_at IS NULL OR deletion_scheduled_at > NOW())
+		RETURNING `+projectColumns, projectID)
+}
+
+func (r *projectLifecycleRepository) ScheduleDeletion(ctx context.Context, projectID uuid.UUID, at time.Time) (*domain.Project, error) {
+	return r.updateProject(ctx, `
+		WITH cancelled AS (
+			UPDATE project_transfers SET cancelled_at = NOW()
+			WHERE project_id = $1 AND accepted_at IS NULL AND cancelled_at IS NULL
+		)
+		UPDATE projects
+		SET archived_at = COALESCE(archived_at, NOW()),
+			deletion_scheduled_at = LEAST(COALESCE(deletion_scheduled_at, $2), $2),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+projectColumns, projectID, at)
+}
+
+func (r *projectLifecycleRepository) updateProject(ctx context.Context, query string, args ...any) (*domain.Project, error) {
+	p, err := scanProject(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to update project lifecycle: %w", err)
+	}
+	return p, nil
+}
+
+func (r *projectLifecycleRepository) ListDueForDeletion(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id FROM projects
+		WHERE deletion_scheduled_at <= NOW()
+		ORDER BY deletion_scheduled_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects due for deletion: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan project id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *projectLifecycleRepository) ListStoragePaths(ctx context.Context, projectID uuid.UUID) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.gcs_path
+		FROM attachments a
+		JOIN feedback f ON f.id = a.feedback_id
+		WHERE f.project_id = $1
+		UNION
+		SELECT storage_path
+		FROM export_jobs
+		WHERE project_id = $1 AND storage_path IS NOT NULL
+		UNION
+		SELECT settings->'branding'->>'logo_path'
+		FROM projects
+		WHERE id = $1 AND COALESCE(settings->'branding'->>'logo_path', '') <> ''
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project storage paths: %w", err)
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan storage path: %w", err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, rows.Err()
+}
+
+func (r *projectLifecycleRepository) Purge(ctx context.Context, projectID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM projects WHERE id = $1 AND deletion_scheduled_at <= NOW()
+	`, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to purge project: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *projectLifecycleRepository) CreateTransfer(ctx context.Context, t *domain.ProjectTransfer) error {
+	// Unique indexes are checked against the table as it was before the
+	// statement, so the pending transfer is cancelled in its own statement
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transfer transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE project_transfers SET cancelled_at = NOW()
+		WHERE project_id = $1 AND accepted_at IS NULL AND cancelled_at IS NULL
+	`, t.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel pending transfer: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO project_transfers (id, project_id, from_user_id, to_user_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, t.ID, t.ProjectID, t.FromUserID, t.ToUserID, t.ExpiresAt).Scan(&t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create project transfer: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit project transfer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *projectLifecycleRepository) GetPendingTransfer(ctx context.Context, projectID uuid.UUID) (*domain.ProjectTransfer, error) {
+	var t domain.ProjectTransfer
+	err := r.db.QueryRow(ctx, `
+		SELECT `+projectTransferColumns+`
+		FROM project_transfers
+		WHERE project_id = $1 AND accepted_at IS NULL AND cancelled_at IS NULL
+	`, projectID).Scan(
+		&t.ID, &t.ProjectID, &t.FromUserID, &t.ToUserID,
+		&t.CreatedAt, &t.ExpiresAt, &t.AcceptedAt, &t.CancelledAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get project transfer: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *projectLifecycleRepository) CancelTransfer(ctx context.Context, projectID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE project_transfers SET cancelled_at = NOW()
+		WHERE project_id = $1 AND accepted_at IS NULL AND cancelled_at IS NULL
+	`, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel project transfer: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTransferNotFound
+	}
+
+	return nil
+}
+
+func (r *projectLifecycleRepository) AcceptTransfer(ctx context.Context, t *domain.ProjectTransfer) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transfer transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE project_transfers SET accepted_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND cancelled_at IS NULL AND expires_at > NOW()
+		RETURNING accepted_at
+	`, t.ID).Scan(&t.AcceptedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTransferNotFound
+		}
+		return fmt.Errorf("failed to accept project transfer: %w", err)
+	}
+
+	// The previous owner stays on as an admin
+	_, err = tx.Exec(ctx, `
+		UPDATE memberships SET role = 'admin', updated_at = NOW()
+		WHERE project_id = $1 AND user_id = $2 AND role = 'owner'
+	`, t.ProjectID, t.FromUserID)
+	if err != nil {
+		return fmt.Errorf("failed to demote previous owner: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE memberships SET role = 'owner', updated_at = NOW()
+		WHERE project_id = $1 AND user_id = $2
+	`, t.ProjectID, t.ToUserID)
+	if err != nil {
+		return fmt.Errorf("failed to promote new owner: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit project transfer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockProjectRepository is a mock implementation of ProjectRepository for testing
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+// NewMockProjectRepository creates a new mock project repository
+func NewMockProjectRepository() *MockProjectRepository {
+	return &MockProjectRepository{}
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, p *domain.Project) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetBySlug(ctx context.Context, slug string) (*domain.Project, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetByProjectKey(ctx context.Context, key string) (*domain.Project, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, p *domain.Project) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Ensure MockProjectRepository implements ProjectRepository
+var _ ProjectRepository = (*MockProjectRepository)(nil)
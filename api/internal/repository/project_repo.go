@@ -12,6 +12,32 @@ import (
 	"github.com/fulldisclosure/api/internal/domain"
 )
 
+// projectColumns are the columns scanned by scanProject
+const projectColumns = `id, name, slug, project_key, settings, logo_url, primary_color,
+	created_at, updated_at, archived_at, deletion_scheduled_at, organization_id`
+
+func scanProject(row pgx.Row) (*domain.Project, error) {
+	var p domain.Project
+	err := row.Scan(
+		&p.ID,
+		&p.Name,
+		&p.Slug,
+		&p.ProjectKey,
+		&p.Settings,
+		&p.LogoURL,
+		&p.PrimaryColor,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.ArchivedAt,
+		&p.DeletionScheduledAt,
+		&p.OrganizationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 type projectRepository struct {
 	db DBTX
 }
@@ -47,24 +73,12 @@ func (r *projectRepository) Create(ctx context.Context, p *domain.Project) error
 
 func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
 	query := `
-		SELECT id, name, slug, project_key, settings, logo_url, primary_color, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
 		WHERE id = $1
 	`
 
-	var p domain.Project
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&p.ID,
-		&p.Name,
-		&p.Slug,
-		&p.ProjectKey,
-		&p.Settings,
-		&p.LogoURL,
-		&p.PrimaryColor,
-		&p.CreatedAt,
-		&p.UpdatedAt,
-	)
-
+	p, err := scanProject(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -72,29 +86,17 @@ func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	return &p, nil
+	return p, nil
 }
 
 func (r *projectRepository) GetBySlug(ctx context.Context, slug string) (*domain.Project, error) {
 	query := `
-		SELECT id, name, slug, project_key, settings, logo_url, primary_color, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
 		WHERE slug = $1
 	`
 
-	var p domain.Project
-	err := r.db.QueryRow(ctx, query, slug).Scan(
-		&p.ID,
-		&p.Name,
-		&p.Slug,
-		&p.ProjectKey,
-		&p.Settings,
-		&p.LogoURL,
-		&p.PrimaryColor,
-		&p.CreatedAt,
-		&p.UpdatedAt,
-	)
-
+	p, err := scanProject(r.db.QueryRow(ctx, query, slug))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -102,29 +104,17 @@ func (r *projectRepository) GetBySlug(ctx context.Context, slug string) (*domain
 		return nil, fmt.Errorf("failed to get project by slug: %w", err)
 	}
 
-	return &p, nil
+	return p, nil
 }
 
 func (r *projectRepository) GetByProjectKey(ctx context.Context, key string) (*domain.Project, error) {
 	query := `
-		SELECT id, name, slug, project_key, settings, logo_url, primary_color, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
 		WHERE project_key = $1
 	`
 
-	var p domain.Project
-	err := r.db.QueryRow(ctx, query, key).Scan(
-		&p.ID,
-		&p.Name,
-		&p.Slug,
-		&p.ProjectKey,
-		&p.Settings,
-		&p.LogoURL,
-		&p.PrimaryColor,
-		&p.CreatedAt,
-		&p.UpdatedAt,
-	)
-
+	p, err := scanProject(r.db.QueryRow(ctx, query, key))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -132,7 +122,7 @@ func (r *projectRepository) GetByProjectKey(ctx context.Context, key string) (*d
 		return nil, fmt.Errorf("failed to get project by key: %w", err)
 	}
 
-	return &p, nil
+	return p, nil
 }
 
 func (r *projectRepository) Update(ctx context.Context, p *domain.Project) error {
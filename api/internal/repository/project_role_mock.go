@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockProjectRoleRepository is a mock implementation of ProjectRoleRepository for testing
+type MockProjectRoleRepository struct {
+	mock.Mock
+}
+
+// NewMockProjectRoleRepository creates a new mock project role repository
+func NewMockProjectRoleRepository() *MockProjectRoleRepository {
+	return &MockProjectRoleRepository{}
+}
+
+func (m *MockProjectRoleRepository) List(ctx context.Context, projectID uuid.UUID) ([]domain.ProjectRole, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProjectRole), args.Error(1)
+}
+
+func (m *MockProjectRoleRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*domain.ProjectRole, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProjectRole), args.Error(1)
+}
+
+func (m *MockProjectRoleRepository) Create(ctx context.Context, r *domain.ProjectRole) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockProjectRoleRepository) Update(ctx context.Context, r *domain.ProjectRole) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockProjectRoleRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	args := m.Called(ctx, projectID, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRoleRepository) Assign(ctx context.Context, projectID, membershipID uuid.UUID, roleID *uuid.UUID) error {
+	args := m.Called(ctx, projectID, membershipID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockProjectRoleRepository) HolderRoles(ctx context.Context, projectID, id uuid.UUID) ([]domain.Role, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Role), args.Error(1)
+}
+
+// Ensure MockProjectRoleRepository implements ProjectRoleRepository
+var _ ProjectRoleRepository = (*MockProjectRoleRepository)(nil)
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type projectRoleRepository struct {
+	db DBTX
+}
+
+// NewProjectRoleRepository creates a new custom project role repository
+func NewProjectRoleRepository(db *pgxpool.Pool) ProjectRoleRepository {
+	return &projectRoleRepository{db: db}
+}
+
+const projectRoleColumns = `id, project_id, name, description, permissions, created_at, updated_at`
+
+func scanProjectRole(row pgx.Row) (*domain.ProjectRole, error) {
+	var r domain.ProjectRole
+	err := row.Scan(&r.ID, &r.ProjectID, &r.Name, &r.Description, &r.Permissions, &r.CreatedAt, &r.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *projectRoleRepository) List(ctx context.Context, projectID uuid.UUID) ([]domain.ProjectRole, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+projectRoleColumns+`
+		FROM project_roles
+		WHERE project_id = $1
+		ORDER BY name
+	`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []domain.ProjectRole{}
+	for rows.Next() {
+		role, err := scanProjectRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, *role)
+	}
+
+	return roles, rows.Err()
+}
+
+func (r *projectRoleRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*domain.ProjectRole, error) {
+	role, err := scanProjectRole(r.db.QueryRow(ctx, `
+		SELECT `+projectRoleColumns+`
+		FROM project_roles
+		WHERE id = $1 AND project_id = $2
+	`, id, projectID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProjectRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *projectRoleRepository) Create(ctx context.Context, role *domain.ProjectRole) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO project_roles (id, project_id, name, description, permissions)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at
+	`, role.ID, role.ProjectID, role.Name, role.Description, role.Permissions).Scan(&role.CreatedAt, &role.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrRoleNameTaken
+		}
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRoleRepository) Update(ctx context.Context, role *domain.ProjectRole) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE project_roles SET name = $3, description = $4, permissions = $5
+		WHERE id = $1 AND project_id = $2
+		RETURNING updated_at
+	`, role.ID, role.ProjectID, role.Name, role.Description, role.Permissions).Scan(&role.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrProjectRoleNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrRoleNameTaken
+		}
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	return nil
+}
+
+func (r *projectRoleRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM project_roles WHERE id = $1 AND project_id = $2
+	`, id, projectID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrRoleInUse
+		}
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrProjectRoleNotFound
+	}
+	return nil
+}
+
+func (r *projectRoleRepository) Assign(ctx context.Context, projectID, membershipID uuid.UUID, roleID *uuid.UUID) error {
+	// The role must belong to the same project as the membership
+	result, err := r.db.Exec(ctx, `
+		UPDATE memberships SET custom_role_id = $3, updated_at = NOW()
+		WHERE id = $1 AND project_id = $2
+			AND ($3::uuid IS NULL OR EXISTS (SELECT 1 FROM project_roles WHERE id = $3 AND project_id = $2))
+	`, membershipID, projectID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *projectRoleRepository) HolderRoles(ctx context.Context, projectID, id uuid.UUID) ([]domain.Role, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT role
+		FROM memberships
+		WHERE project_id = $1 AND custom_role_id = $2
+	`, projectID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role holders: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+	for rows.Next() {
+		var role domain.Role
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("failed to scan role holder: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockRealtimeRepository is a mock implementation of RealtimeRepository for testing
+type MockRealtimeRepository struct {
+	mock.Mock
+}
+
+// NewMockRealtimeRepository creates a new mock realtime repository
+func NewMockRealtimeRepository() *MockRealtimeRepository {
+	return &MockRealtimeRepository{}
+}
+
+func (m *MockRealtimeRepository) Listen(ctx context.Context, handle func(domain.RealtimeEvent)) error {
+	args := m.Called(ctx, handle)
+	return args.Error(0)
+}
+
+func (m *MockRealtimeRepository) ListSince(ctx context.Context, projectID uuid.UUID, afterID int64, limit int) ([]domain.RealtimeEvent, bool, error) {
+	args := m.Called(ctx, projectID, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.RealtimeEvent), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRealtimeRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+var _ RealtimeRepository = (*MockRealtimeRepository)(nil)
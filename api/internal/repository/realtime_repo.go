@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// realtimeChannel is the NOTIFY channel realtime events are announced on
+const realtimeChannel = "realtime_events"
+
+type realtimeRepository struct {
+	db   DBTX
+	pool *pgxpool.Pool
+}
+
+// NewRealtimeRepository creates a new realtime repository
+func NewRealtimeRepository(db *pgxpool.Pool) RealtimeRepository {
+	return &realtimeRepository{db: db, pool: db}
+}
+
+func (r *realtimeRepository) Listen(ctx context.Context, handle func(domain.RealtimeEvent)) error {
+	// LISTEN holds a connection for as long as the listener runs
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire listener connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+realtimeChannel); err != nil {
+		return fmt.Errorf("failed to listen for realtime events: %w", err)
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for realtime events: %w", err)
+		}
+
+		var event domain.RealtimeEvent
+		if err := json.Unmarshal([]byte(n.Payload), &event); err != nil {
+			return fmt.Errorf("failed to unmarshal realtime event: %w", err)
+		}
+		handle(event)
+	}
+}
+
+func (r *realtimeRepository) ListSince(ctx context.Context, projectID uuid.UUID, afterID int64, limit int) ([]domain.RealtimeEvent, bool, error) {
+	// Events up to afterID may have been pruned; the caller can only resume
+	// if the oldest retained event follows them
+	var oldest int64
+	if err := r.db.QueryRow(ctx, `SELECT COALESCE(MIN(id), 0) FROM realtime_events`).Scan(&oldest); err != nil {
+		return nil, false, fmt.Errorf("failed to find oldest realtime event: %w", err)
+	}
+	if oldest > afterID+1 {
+		return nil, false, nil
+	}
+
+	query := `
+		SELECT id, project_id, feedback_id, event_type, visibility, payload, created_at
+		FROM realtime_events
+		WHERE project_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID, afterID, limit)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list realtime events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.RealtimeEvent
+	for rows.Next() {
+		var e domain.RealtimeEvent
+		var payloadJSON []byte
+		if err := rows.Scan(&e.ID, &e.ProjectID, &e.FeedbackID, &e.EventType, &e.Visibility, &payloadJSON, &e.CreatedAt); err != nil {
+			return nil, false, fmt.Errorf("failed to scan realtime event: %w", err)
+		}
+		if err := json.Unmarshal(payloadJSON, &e.Payload); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal realtime event payload: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to list realtime events: %w", err)
+	}
+
+	// A full page means the client is too far behind to catch up by replay
+	return events, len(events) < limit, nil
+}
+
+func (r *realtimeRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM realtime_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune realtime events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockSavedViewRepository is a mock implementation of SavedViewRepository for testing
+type MockSavedViewRepository struct {
+	mock.Mock
+}
+
+// NewMockSavedViewRepository creates a new mock saved view repository
+func NewMockSavedViewRepository() *MockSavedViewRepository {
+	return &MockSavedViewRepository{}
+}
+
+func (m *MockSavedViewRepository) Create(ctx context.Context, v *domain.SavedView) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SavedView), args.Error(1)
+}
+
+func (m *MockSavedViewRepository) ListVisible(ctx context.Context, projectID, userID uuid.UUID) ([]domain.SavedView, error) {
+	args := m.Called(ctx, projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SavedView), args.Error(1)
+}
+
+func (m *MockSavedViewRepository) Update(ctx context.Context, v *domain.SavedView) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) MarkSeen(ctx context.Context, viewID, userID uuid.UUID) error {
+	args := m.Called(ctx, viewID, userID)
+	return args.Error(0)
+}
+
+func (m *MockSavedViewRepository) Counts(ctx context.Context, projectID, userID uuid.UUID) ([]domain.SavedViewCount, error) {
+	args := m.Called(ctx, projectID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SavedViewCount), args.Error(1)
+}
+
+// Ensure MockSavedViewRepository implements SavedViewRepository
+var _ SavedViewRepository = (*MockSavedViewRepository)(nil)
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type savedViewRepository struct {
+	db DBTX
+}
+
+// NewSavedViewRepository creates a new saved view repository
+func NewSavedViewRepository(db *pgxpool.Pool) SavedViewRepository {
+	return &savedViewRepository{db: db}
+}
+
+// FeedbackFilterFromView converts a persisted view filter into a list filter
+func FeedbackFilterFromView(f domain.SavedViewFilter) FeedbackFilter {
+	return FeedbackFilter{
+		Type:       f.Type,
+		Status:     f.Status,
+		TagIDs:     f.TagIDs,
+		AssignedTo: f.AssignedTo,
+		Search:     f.Search,
+		SortBy:     f.SortBy,
+		SortOrder:  f.SortOrder,
+	}
+}
+
+func (r *savedViewRepository) Create(ctx context.Context, v *domain.SavedView) error {
+	filterJSON, err := json.Marshal(v.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal view filter: %w", err)
+	}
+
+	// New views go to the bottom of the owner's list
+	query := `
+		INSERT INTO saved_views (id, project_id, owner_id, name, shared, notify, filter, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (
+			SELECT COALESCE(MAX(position) + 1, 0)
+			FROM saved_views
+			WHERE project_id = $2 AND owner_id = $3
+		))
+		RETURNING position, created_at, updated_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		v.ID,
+		v.ProjectID,
+		v.OwnerID,
+		v.Name,
+		v.Shared,
+		v.Notify,
+		filterJSON,
+	).Scan(&v.Position, &v.CreatedAt, &v.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create saved view: %w", err)
+	}
+
+	return nil
+}
+
+func (r *savedViewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	query := `
+		SELECT id, project_id, owner_id, name, shared, notify, filter, position, created_at, updated_at
+		FROM saved_views
+		WHERE id = $1
+	`
+
+	v, err := scanSavedView(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSavedViewNotFound
+		}
+		return nil, fmt.Errorf("failed to get saved view: %w", err)
+	}
+
+	return v, nil
+}
+
+func (r *savedViewRepository) ListVisible(ctx context.Context, projectID, userID uuid.UUID) ([]domain.SavedView, error) {
+	// The user's own views first, then views shared by teammates
+	query := `
+		SELECT id, project_id, owner_id, name, shared, notify, filter, position, created_at, updated_at
+		FROM saved_views
+		WHERE project_id = $1 AND (owner_id = $2 OR shared = true)
+		ORDER BY (owner_id = $2) DESC, position ASC, created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []domain.SavedView
+	for rows.Next() {
+		v, err := scanSavedView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved view: %w", err)
+		}
+		views = append(views, *v)
+	}
+
+	return views, rows.Err()
+}
+
+func (r *savedViewRepository) Update(ctx context.Context, v *domain.SavedView) error {
+	filterJSON, err := json.Marshal(v.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal view filter: %w", err)
+	}
+
+	query := `
+		UPDATE saved_views
+		SET name = $2, shared = $3, notify = $4, filter = $5, position = $6
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		v.ID,
+		v.Name,
+		v.Shared,
+		v.Notify,
+		filterJSON,
+		v.Position,
+	).Scan(&v.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrSavedViewNotFound
+		}
+		return fmt.Errorf("failed to update saved view: %w", err)
+	}
+
+	return nil
+}
+
+func (r *savedViewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM saved_views WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved view: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSavedViewNotFound
+	}
+
+	return nil
+}
+
+func (r *savedViewRepository) MarkSeen(ctx context.Context, viewID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO saved_view_visits (view_id, user_id, last_viewed_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (view_id, user_id) DO UPDATE SET last_viewed_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, viewID, userID); err != nil {
+		return fmt.Errorf("failed to mark saved view as seen: %w", err)
+	}
+
+	return nil
+}
+
+func (r *savedViewRepository) Counts(ctx context.Context, projectID, userID uuid.UUID) ([]domain.SavedViewCount, error) {
+	views, err := r.ListVisible(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load last visit times in one round trip
+	lastSeen := make(map[uuid.UUID]time.Time)
+	rows, err := r.db.Query(ctx, `
+		SELECT svv.view_id, svv.last_viewed_at
+		FROM saved_view_visits svv
+		JOIN saved_views sv ON sv.id = svv.view_id
+		WHERE sv.project_id = $1 AND svv.user_id = $2
+	`, projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved view visits: %w", err)
+	}
+	for rows.Next() {
+		var viewID uuid.UUID
+		var seenAt time.Time
+		if err := rows.Scan(&viewID, &seenAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan saved view visit: %w", err)
+		}
+		lastSeen[viewID] = seenAt
+	}
+	rows.Close()
+
+	if len(views) == 0 {
+		return []domain.SavedViewCount{}, nil
+	}
+
+	// Count every view in one scan of the project's feedback
+	args := []interface{}{projectID}
+	columns := make([]string, 0, 2*len(views))
+	for _, v := range views {
+		// Views never opened count everything created since the view existed
+		since, ok := lastSeen[v.ID]
+		if !ok {
+			since = v.CreatedAt
+		}
+
+		var whereClause string
+		var argIndex int
+		whereClause, args, argIndex = appendFeedbackConditions(projectID, FeedbackFilterFromView(v.Filter), args)
+		args = append(args, since)
+		columns = append(columns,
+			fmt.Sprintf("COUNT(*) FILTER (WHERE %s)", whereClause),
+			fmt.Sprintf("COUNT(*) FILTER (WHERE %s AND created_at > $%d)", whereClause, argIndex),
+		)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM feedback
+		WHERE project_id = $1
+	`, strings.Join(columns, ", "))
+
+	counts := make([]domain.SavedViewCount, len(views))
+	dest := make([]interface{}, 0, 2*len(views))
+	for i, v := range views {
+		counts[i].ViewID = v.ID
+		dest = append(dest, &counts[i].Total, &counts[i].New)
+	}
+	if err := r.db.QueryRow(ctx, query, args...).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to count saved views: %w", err)
+	}
+
+	return counts, nil
+}
+
+func scanSavedView(row pgx.Row) (*domain.SavedView, error) {
+	var v domain.SavedView
+	var filterJSON []byte
+	if err := row.Scan(
+		&v.ID,
+		&v.ProjectID,
+		&v.OwnerID,
+		&v.Name,
+		&v.Shared,
+		&v.Notify,
+		&filterJSON,
+		&v.Position,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(filterJSON) > 0 {
+		if err := json.Unmarshal(filterJSON, &v.Filter); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal view filter: %w", err)
+		}
+	}
+
+	return &v, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockSDKBoardRepository is a mock implementation of SDKBoardRepository for testing
+type MockSDKBoardRepository struct {
+	mock.Mock
+}
+
+// NewMockSDKBoardRepository creates a new mock SDK board repository
+func NewMockSDKBoardRepository() *MockSDKBoardRepository {
+	return &MockSDKBoardRepository{}
+}
+
+func (m *MockSDKBoardRepository) GetSDKUserID(ctx context.Context, projectID uuid.UUID, externalID string) (uuid.UUID, error) {
+	args := m.Called(ctx, projectID, externalID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockSDKBoardRepository) ListFeatures(ctx context.Context, projectID uuid.UUID, sdkUserID *uuid.UUID, limit, offset int) ([]domain.PortalFeedbackSummary, int, error) {
+	args := m.Called(ctx, projectID, sdkUserID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.PortalFeedbackSummary), args.Int(1), args.Error(2)
+}
+
+func (m *MockSDKBoardRepository) GetFeature(ctx context.Context, projectID, feedbackID uuid.UUID, sdkUserID *uuid.UUID) (*domain.PortalFeedbackDetail, error) {
+	args := m.Called(ctx, projectID, feedbackID, sdkUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PortalFeedbackDetail), args.Error(1)
+}
+
+func (m *MockSDKBoardRepository) ListComments(ctx context.Context, feedbackID uuid.UUID, sdkUserID *uuid.UUID) ([]domain.PortalComment, error) {
+	args := m.Called(ctx, feedbackID, sdkUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PortalComment), args.Error(1)
+}
+
+func (m *MockSDKBoardRepository) ListMyFeedback(ctx context.Context, projectID, sdkUserID uuid.UUID) ([]domain.PortalFeedbackSummary, error) {
+	args := m.Called(ctx, projectID, sdkUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PortalFeedbackSummary), args.Error(1)
+}
+
+var _ SDKBoardRepository = (*MockSDKBoardRepository)(nil)
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type sdkBoardRepository struct {
+	db DBTX
+}
+
+// NewSDKBoardRepository creates a new SDK board repository
+func NewSDKBoardRepository(db *pgxpool.Pool) SDKBoardRepository {
+	return &sdkBoardRepository{db: db}
+}
+
+func (r *sdkBoardRepository) GetSDKUserID(ctx context.Context, projectID uuid.UUID, externalID string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, `
+		UPDATE sdk_users SET last_seen_at = NOW()
+		WHERE project_id = $1 AND external_id = $2
+		RETURNING id
+	`, projectID, externalID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, domain.ErrSDKUserNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get SDK user: %w", err)
+	}
+
+	return id, nil
+}
+
+// sdkHasVotedExpr returns the has_voted column for an optional SDK user
+// bound to $argIndex
+func sdkHasVotedExpr(sdkUserID *uuid.UUID, args []interface{}, argIndex int) (string, []interface{}, int) {
+	if sdkUserID == nil {
+		return "false", args, argIndex
+	}
+	voted, arg := voterCondition("v", domain.SDKUserVoter(*sdkUserID), argIndex)
+	return "EXISTS(SELECT 1 FROM votes v WHERE v.feedback_id = f.id AND " + voted + ")", append(args, arg), argIndex + 1
+}
+
+func (r *sdkBoardRepository) ListFeatures(ctx context.Context, projectID uuid.UUID, sdkUserID *uuid.UUID, limit, offset int) ([]domain.PortalFeedbackSummary, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM feedback f
+		WHERE f.project_id = $1
+		AND f.canonical_id IS NULL
+		AND f.type = 'feature'
+		AND f.visibility = 'COMMUNITY'
+	`
+
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, projectID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count features: %w", err)
+	}
+
+	hasVoted, args, argIndex := sdkHasVotedExpr(sdkUserID, []interface{}{projectID}, 2)
+	query := fmt.Sprintf(`
+		SELECT
+			f.id, f.title, f.description, f.type, f.status,
+			f.vote_count, f.comment_count, f.created_at, f.updated_at,
+			%s as has_voted
+		FROM feedback f
+		WHERE f.project_id = $1
+		AND f.canonical_id IS NULL
+		AND f.type = 'feature'
+		AND f.visibility = 'COMMUNITY'
+		ORDER BY f.vote_count DESC, f.created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, hasVoted, argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list features: %w", err)
+	}
+	defer rows.Close()
+
+	feedback, err := scanPortalSummaries(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return feedback, total, nil
+}
+
+func (r *sdkBoardRepository) GetFeature(ctx context.Context, projectID, feedbackID uuid.UUID, sdkUserID *uuid.UUID) (*domain.PortalFeedbackDetail, error) {
+	hasVoted, args, _ := sdkHasVotedExpr(sdkUserID, []interface{}{feedbackID, projectID, sdkUserID}, 4)
+	query := fmt.Sprintf(`
+		SELECT
+			f.id, f.title, f.description, f.type, f.status,
+			f.vote_count, f.comment_count, f.created_at, f.updated_at,
+			f.visibility, f.canonical_id,
+			%s,
+			COALESCE(f.sdk_user_id = $3, false)
+		FROM feedback f
+		WHERE f.id = $1 AND f.project_id = $2
+	`, hasVoted)
+
+	var d domain.PortalFeedbackDetail
+	err := r.db.QueryRow(ctx, query, args...).Scan(
+		&d.ID, &d.Title, &d.Description, &d.Type, &d.Status,
+		&d.VoteCount, &d.CommentCount, &d.CreatedAt, &d.UpdatedAt,
+		&d.Visibility, &d.MergedInto,
+		&d.HasVoted, &d.IsMine,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrFeedbackNotFound
+		}
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
+	}
+
+	return &d, nil
+}
+
+func (r *sdkBoardRepository) ListComments(ctx context.Context, feedbackID uuid.UUID, sdkUserID *uuid.UUID) ([]domain.PortalComment, error) {
+	query := `
+		SELECT
+			c.id, c.body, c.is_edited, c.created_at,
+			COALESCE(m.display_name, su.name, c.external_author_name),
+			COALESCE(m.role IN ('viewer', 'member', 'admin', 'owner'), false),
+			COALESCE(c.sdk_user_id = $2, false)
+		FROM comments c
+		JOIN feedback f ON f.id = c.feedback_id
+		LEFT JOIN memberships m ON m.project_id = f.project_id AND m.user_id = c.author_id
+		LEFT JOIN sdk_users su ON su.id = c.sdk_user_id
+		WHERE c.feedback_id = $1
+		AND c.visibility = 'COMMUNITY'
+		AND c.deleted_at IS NULL
+		ORDER BY c.created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, feedbackID, sdkUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []domain.PortalComment{}
+	for rows.Next() {
+		var c domain.PortalComment
+		if err := rows.Scan(&c.ID, &c.Body, &c.IsEdited, &c.CreatedAt, &c.AuthorName, &c.IsTeam, &c.IsMine); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
+
+func (r *sdkBoardRepository) ListMyFeedback(ctx context.Context, projectID, sdkUserID uuid.UUID) ([]domain.PortalFeedbackSummary, error) {
+	hasVoted, args, _ := sdkHasVotedExpr(&sdkUserID, []interface{}{projectID, sdkUserID}, 3)
+	query := fmt.Sprintf(`
+		SELECT
+			f.id, f.title, f.description, f.type, f.status,
+			f.vote_count, f.comment_count, f.created_at, f.updated_at,
+			%s as has_voted
+		FROM feedback f
+		WHERE f.project_id = $1
+		AND f.sdk_user_id = $2
+		AND f.canonical_id IS NULL
+		ORDER BY f.created_at DESC
+	`, hasVoted)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SDK user feedback: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPortalSummaries(rows)
+}
+
+func scanPortalSummaries(rows pgx.Rows) ([]domain.PortalFeedbackSummary, error) {
+	feedback := []domain.PortalFeedbackSummary{}
+	for rows.Next() {
+		var f domain.PortalFeedbackSummary
+		if err := rows.Scan(
+			&f.ID, &f.Title, &f.Description, &f.Type, &f.Status,
+			&f.VoteCount, &f.CommentCount, &f.CreatedAt, &f.UpdatedAt,
+			&f.HasVoted,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback: %w", err)
+		}
+		feedback = append(feedback, f)
+	}
+
+	return feedback, rows.Err()
+}
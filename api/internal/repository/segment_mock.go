@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockSegmentRepository is a mock implementation of SegmentRepository for testing
+type MockSegmentRepository struct {
+	mock.Mock
+}
+
+// NewMockSegmentRepository creates a new mock segment repository
+func NewMockSegmentRepository() *MockSegmentRepository {
+	return &MockSegmentRepository{}
+}
+
+func (m *MockSegmentRepository) Create(ctx context.Context, s *domain.Segment) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockSegmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Segment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Segment), args.Error(1)
+}
+
+func (m *MockSegmentRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]domain.Segment, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Segment), args.Error(1)
+}
+
+func (m *MockSegmentRepository) Update(ctx context.Context, s *domain.Segment) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockSegmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSegmentRepository) CountUsers(ctx context.Context, s *domain.Segment) (int, error) {
+	args := m.Called(ctx, s)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSegmentRepository) CountUsersBySegment(ctx context.Context, projectID uuid.UUID, segments []domain.Segment) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, projectID, segments)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+func (m *MockSegmentRepository) ListVoters(ctx context.Context, projectID, feedbackID uuid.UUID, segment *domain.Segment, weights domain.PrioritizationSettings) ([]domain.SegmentVoter, error) {
+	args := m.Called(ctx, projectID, feedbackID, segment, weights)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SegmentVoter), args.Error(1)
+}
+
+func (m *MockSegmentRepository) Priorities(ctx context.Context, projectID uuid.UUID, segment *domain.Segment, weights domain.PrioritizationSettings, limit int) ([]domain.PriorityItem, error) {
+	args := m.Called(ctx, projectID, segment, weights, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PriorityItem), args.Error(1)
+}
+
+// Ensure MockSegmentRepository implements SegmentRepository
+var _ SegmentRepository = (*MockSegmentRepository)(nil)
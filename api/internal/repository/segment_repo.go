@@ -0,0 +1,430 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type segmentRepository struct {
+	db DBTX
+}
+
+// NewSegmentRepository creates a new segment repository
+func NewSegmentRepository(db *pgxpool.Pool) SegmentRepository {
+	return &segmentRepository{db: db}
+}
+
+func (r *segmentRepository) Create(ctx context.Context, s *domain.Segment) error {
+	rulesJSON, err := json.Marshal(s.Rules)
+	if err != nil {
+		return fmt.Errorf("failed to marshal segment rules: %w", err)
+	}
+
+	query := `
+		INSERT INTO segments (id, project_id, name, description, match, rules, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		s.ID,
+		s.ProjectID,
+		s.Name,
+		s.Description,
+		s.Match,
+		rulesJSON,
+		s.CreatedBy,
+	).Scan(&s.CreatedAt, &s.UpdatedAt)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrSegmentNameTaken
+		}
+		return fmt.Errorf("failed to create segment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *segmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Segment, error) {
+	query := `
+		SELECT id, project_id, name, description, match, rules, created_by, created_at, updated_at
+		FROM segments
+		WHERE id = $1
+	`
+
+	s, err := scanSegment(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSegmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get segment: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *segmentRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]domain.Segment, error) {
+	query := `
+		SELECT id, project_id, name, description, match, rules, created_by, created_at, updated_at
+		FROM segments
+		WHERE project_id = $1
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	defer rows.Close()
+
+	var segments []domain.Segment
+	for rows.Next() {
+		s, err := scanSegment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan segment: %w", err)
+		}
+		segments = append(segments, *s)
+	}
+
+	return segments, rows.Err()
+}
+
+func (r *segmentRepository) Update(ctx context.Context, s *domain.Segment) error {
+	rulesJSON, err := json.Marshal(s.Rules)
+	if err != nil {
+		return fmt.Errorf("failed to marshal segment rules: %w", err)
+	}
+
+	query := `
+		UPDATE segments
+		SET name = $2, description = $3, match = $4, rules = $5
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err = r.db.QueryRow(ctx, query, s.ID, s.Name, s.Description, s.Match, rulesJSON).Scan(&s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrSegmentNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrSegmentNameTaken
+		}
+		return fmt.Errorf("failed to update segment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *segmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM segments WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete segment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSegmentNotFound
+	}
+
+	return nil
+}
+
+func (r *segmentRepository) CountUsers(ctx context.Context, s *domain.Segment) (int, error) {
+	cond, args, _ := segmentCondition("su", s, []interface{}{s.ProjectID}, 2)
+
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM sdk_users su WHERE su.project_id = $1 AND %s", cond)
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count segment users: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *segmentRepository) CountUsersBySegment(ctx context.Context, projectID uuid.UUID, segments []domain.Segment) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(segments))
+	if len(segments) == 0 {
+		return counts, nil
+	}
+
+	args := []interface{}{projectID}
+	argIndex := 2
+	columns := make([]string, len(segments))
+	for i := range segments {
+		var cond string
+		cond, args, argIndex = segmentCondition("su", &segments[i], args, argIndex)
+		columns[i] = fmt.Sprintf("COUNT(*) FILTER (WHERE %s)", cond)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM sdk_users su WHERE su.project_id = $1", strings.Join(columns, ", "))
+
+	values := make([]int, len(segments))
+	dest := make([]interface{}, len(segments))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.db.QueryRow(ctx, query, args...).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to count segment users: %w", err)
+	}
+
+	for i, s := range segments {
+		counts[s.ID] = values[i]
+	}
+	return counts, nil
+}
+
+func (r *segmentRepository) ListVoters(ctx context.Context, projectID, feedbackID uuid.UUID, segment *domain.Segment, weights domain.PrioritizationSettings) ([]domain.SegmentVoter, error) {
+	args := []interface{}{projectID, feedbackID}
+	weight, args, argIndex := voterWeightExpr("su", weights, args, 3)
+
+	segmentFilter := ""
+	if segment != nil {
+		var cond string
+		cond, args, _ = segmentCondition("su", segment, args, argIndex)
+		segmentFilter = "WHERE " + cond
+	}
+
+	// SDK voters carry their own traits; Supabase users take them from the
+	// SDK user they are linked to, if any
+	query := fmt.Sprintf(`
+		WITH voters AS (
+			SELECT user_id, sdk_user_id, importance, note, recorded_by, created_at AS voted_at
+			FROM votes
+			WHERE feedback_id = $2 AND anonymous_fingerprint IS NULL
+		)
+		SELECT vo.user_id, su.id, su.external_id, su.email, su.name, su.traits, %s AS weight,
+			vo.importance, vo.note, vo.recorded_by, vo.voted_at
+		FROM voters vo
+		LEFT JOIN LATERAL (
+			SELECT id, external_id, email, name, traits
+			FROM sdk_users
+			WHERE project_id = $1
+				AND (id = vo.sdk_user_id OR linked_user_id = vo.user_id)
+			ORDER BY last_seen_at DESC
+			LIMIT 1
+		) su ON true
+		%s
+		ORDER BY weight DESC, vo.voted_at ASC
+	`, weight, segmentFilter)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list voters: %w", err)
+	}
+	defer rows.Close()
+
+	voters := []domain.SegmentVoter{}
+	for rows.Next() {
+		var v domain.SegmentVoter
+		var traitsJSON []byte
+		if err := rows.Scan(&v.UserID, &v.SDKUserID, &v.ExternalID, &v.Email, &v.Name, &traitsJSON, &v.Weight, &v.Importance, &v.Note, &v.RecordedBy, &v.VotedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan voter: %w", err)
+		}
+		if len(traitsJSON) > 0 {
+			if err := json.Unmarshal(traitsJSON, &v.Traits); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal voter traits: %w", err)
+			}
+		}
+		voters = append(voters, v)
+	}
+
+	return voters, rows.Err()
+}
+
+func (r *segmentRepository) Priorities(ctx context.Context, projectID uuid.UUID, segment *domain.Segment, weights domain.PrioritizationSettings, limit int) ([]domain.PriorityItem, error) {
+	args := []interface{}{projectID}
+	weight, args, argIndex := voterWeightExpr("s", weights, args, 2)
+
+	joinFilter, having := "", ""
+	if segment != nil {
+		var cond string
+		cond, args, argIndex = segmentCondition("s", segment, args, argIndex)
+		joinFilter = "AND " + cond
+		having = "HAVING COUNT(s.feedback_id) > 0"
+	}
+	args = append(args, limit)
+
+	// Supporters are the item's voters plus its SDK submitter, each counted once
+	query := fmt.Sprintf(`
+		WITH voters AS (
+			SELECT v.feedback_id, v.user_id, v.sdk_user_id
+			FROM votes v
+			JOIN feedback f ON f.id = v.feedback_id
+			WHERE f.project_id = $1
+		),
+		supporters AS (
+			SELECT vo.feedback_id, su.traits
+			FROM voters vo
+			LEFT JOIN LATERAL (
+				SELECT traits
+				FROM sdk_users
+				WHERE project_id = $1
+					AND (id = vo.sdk_user_id OR linked_user_id = vo.user_id)
+				ORDER BY last_seen_at DESC
+				LIMIT 1
+			) su ON true
+			UNION ALL
+			SELECT f.id, su.traits
+			FROM feedback f
+			JOIN sdk_users su ON su.id = f.sdk_user_id
+			WHERE f.project_id = $1
+				AND NOT EXISTS (
+					SELECT 1 FROM voters vo
+					WHERE vo.feedback_id = f.id
+						AND (vo.sdk_user_id = su.id OR vo.user_id = su.linked_user_id)
+				)
+		)
+		SELECT f.id, f.title, f.type, f.status, f.vote_count,
+			COUNT(s.feedback_id), COALESCE(SUM(%s), 0)::float8 AS score
+		FROM feedback f
+		LEFT JOIN supporters s ON s.feedback_id = f.id %s
+		WHERE f.project_id = $1 AND f.canonical_id IS NULL
+			AND f.status NOT IN ('completed', 'declined', 'duplicate')
+		GROUP BY f.id
+		%s
+		ORDER BY score DESC, f.vote_count DESC, f.created_at DESC
+		LIMIT $%d
+	`, weight, joinFilter, having, argIndex)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute priorities: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.PriorityItem{}
+	for rows.Next() {
+		var p domain.PriorityItem
+		if err := rows.Scan(&p.FeedbackID, &p.Title, &p.Type, &p.Status, &p.VoteCount, &p.SegmentVoters, &p.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan priority: %w", err)
+		}
+		items = append(items, p)
+	}
+
+	return items, rows.Err()
+}
+
+// voterWeightExpr returns the SQL expression for a supporter's weight given
+// their traits column on alias. Without a weight trait every supporter
+// counts as one.
+func voterWeightExpr(alias string, weights domain.PrioritizationSettings, args []interface{}, argIndex int) (string, []interface{}, int) {
+	if weights.WeightTrait == "" {
+		return "1", args, argIndex
+	}
+
+	expr := fmt.Sprintf(
+		"COALESCE(CASE WHEN jsonb_typeof(%[1]s.traits->$%[2]d) = 'number' THEN (%[1]s.traits->>$%[2]d)::numeric END, $%[3]d::numeric)",
+		alias, argIndex, argIndex+1,
+	)
+	args = append(args, weights.WeightTrait, weights.DefaultWeight)
+	return expr, args, argIndex + 2
+}
+
+// segmentCondition compiles segment rules into a SQL condition over the
+// traits column of alias. Trait names and values are always bound as
+// parameters. Rows with NULL traits never match.
+func segmentCondition(alias string, s *domain.Segment, args []interface{}, argIndex int) (string, []interface{}, int) {
+	traits := alias + ".traits"
+	var parts []string
+
+	for _, rule := range s.Rules {
+		trait := argIndex
+		args = append(args, rule.Trait)
+		argIndex++
+
+		var part string
+		switch rule.Op {
+		case domain.OpExists:
+			part = fmt.Sprintf("(%s ? $%d)", traits, trait)
+		case domain.OpEquals, domain.OpNotEquals:
+			valueJSON, _ := json.Marshal(rule.Value)
+			args = append(args, string(valueJSON))
+			if rule.Op == domain.OpEquals {
+				part = fmt.Sprintf("(%s->$%d = $%d::jsonb)", traits, trait, argIndex)
+			} else {
+				part = fmt.Sprintf("(%s IS NOT NULL AND %s->$%d IS DISTINCT FROM $%d::jsonb)", traits, traits, trait, argIndex)
+			}
+			argIndex++
+		case domain.OpGreaterThan, domain.OpGreaterOrEq, domain.OpLessThan, domain.OpLessOrEq:
+			ops := map[domain.SegmentOperator]string{
+				domain.OpGreaterThan: ">",
+				domain.OpGreaterOrEq: ">=",
+				domain.OpLessThan:    "<",
+				domain.OpLessOrEq:    "<=",
+			}
+			args = append(args, rule.Value)
+			part = fmt.Sprintf(
+				"(CASE WHEN jsonb_typeof(%s->$%d) = 'number' THEN (%s->>$%d)::numeric END %s $%d::numeric)",
+				traits, trait, traits, trait, ops[rule.Op], argIndex,
+			)
+			argIndex++
+		case domain.OpContains:
+			value, _ := rule.Value.(string)
+			args = append(args, "%"+escapeLike(value)+"%")
+			part = fmt.Sprintf("(%s->>$%d ILIKE $%d)", traits, trait, argIndex)
+			argIndex++
+		case domain.OpIn:
+			values, _ := rule.Value.([]interface{})
+			jsonValues := make([]string, 0, len(values))
+			for _, v := range values {
+				b, _ := json.Marshal(v)
+				jsonValues = append(jsonValues, string(b))
+			}
+			args = append(args, jsonValues)
+			part = fmt.Sprintf("(%s->$%d = ANY($%d::jsonb[]))", traits, trait, argIndex)
+			argIndex++
+		default:
+			// Unknown operators never match; Validate rejects them on save
+			part = "false"
+		}
+		parts = append(parts, part)
+	}
+
+	if len(parts) == 0 {
+		return "true", args, argIndex
+	}
+
+	joiner := " AND "
+	if s.Match == domain.SegmentMatchAny {
+		joiner = " OR "
+	}
+	return "(" + strings.Join(parts, joiner) + ")", args, argIndex
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func scanSegment(row pgx.Row) (*domain.Segment, error) {
+	var s domain.Segment
+	var rulesJSON []byte
+	if err := row.Scan(
+		&s.ID,
+		&s.ProjectID,
+		&s.Name,
+		&s.Description,
+		&s.Match,
+		&rulesJSON,
+		&s.CreatedBy,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rulesJSON, &s.Rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal segment rules: %w", err)
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestSegmentCondition(t *testing.T) {
+	t.Run("all rules are joined with AND and bind traits as parameters", func(t *testing.T) {
+		segment := &domain.Segment{
+			Match: domain.SegmentMatchAll,
+			Rules: []domain.SegmentRule{
+				{Trait: "plan", Op: domain.OpEquals, Value: "enterprise"},
+				{Trait: "mrr", Op: domain.OpGreaterThan, Value: float64(500)},
+			},
+		}
+
+		cond, args, next := segmentCondition("su", segment, []interface{}{"project"}, 2)
+
+		assert.Equal(t, "((su.traits->$2 = $3::jsonb) AND "+
+			"(CASE WHEN jsonb_typeof(su.traits->$4) = 'number' THEN (su.traits->>$4)::numeric END > $5::numeric))", cond)
+		assert.Equal(t, []interface{}{"project", "plan", `"enterprise"`, "mrr", float64(500)}, args)
+		assert.Equal(t, 6, next)
+	})
+
+	t.Run("any rules are joined with OR", func(t *testing.T) {
+		segment := &domain.Segment{
+			Match: domain.SegmentMatchAny,
+			Rules: []domain.SegmentRule{
+				{Trait: "company", Op: domain.OpExists},
+				{Trait: "company", Op: domain.OpContains, Value: "50%_off"},
+			},
+		}
+
+		cond, args, _ := segmentCondition("s", segment, nil, 1)
+
+		assert.Equal(t, "((s.traits ? $1) OR (s.traits->>$2 ILIKE $3))", cond)
+		assert.Equal(t, `%50\%\_off%`, args[2])
+	})
+
+	t.Run("in binds values as a jsonb array", func(t *testing.T) {
+		segment := &domain.Segment{
+			Rules: []domain.SegmentRule{
+				{Trait: "plan", Op: domain.OpIn, Value: []interface{}{"pro", "enterprise"}},
+			},
+		}
+
+		cond, args, _ := segmentCondition("su", segment, nil, 1)
+
+		assert.Equal(t, "((su.traits->$1 = ANY($2::jsonb[])))", cond)
+		assert.Equal(t, []string{`"pro"`, `"enterprise"`}, args[1])
+	})
+}
+
+func TestVoterWeightExpr(t *testing.T) {
+	expr, args, next := voterWeightExpr("s", domain.PrioritizationSettings{}, nil, 2)
+	assert.Equal(t, "1", expr)
+	assert.Empty(t, args)
+	assert.Equal(t, 2, next)
+
+	expr, args, next = voterWeightExpr("s", domain.PrioritizationSettings{WeightTrait: "mrr", DefaultWeight: 10}, nil, 2)
+	assert.Equal(t, "COALESCE(CASE WHEN jsonb_typeof(s.traits->$2) = 'number' THEN (s.traits->>$2)::numeric END, $3::numeric)", expr)
+	assert.Equal(t, []interface{}{"mrr", float64(10)}, args)
+	assert.Equal(t, 4, next)
+}
+
+func TestSegmentRepository_CountUsersBySegment(t *testing.T) {
+	projectID := uuid.New()
+	enterprise := domain.Segment{ID: uuid.New(), Rules: []domain.SegmentRule{{Trait: "plan", Op: domain.OpEquals, Value: "enterprise"}}}
+	trial := domain.Segment{ID: uuid.New(), Rules: []domain.SegmentRule{{Trait: "trial", Op: domain.OpExists}}}
+
+	t.Run("counts every segment in one query", func(t *testing.T) {
+		db := &fakeDB{results: []fakeResult{{row: []any{7, 3}}}}
+
+		counts, err := (&segmentRepository{db: db}).CountUsersBySegment(context.Background(), projectID, []domain.Segment{enterprise, trial})
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int{enterprise.ID: 7, trial.ID: 3}, counts)
+
+		require.Len(t, db.queries, 1)
+		assert.Equal(t, 2, strings.Count(db.queries[0], "COUNT(*) FILTER"))
+		assert.Equal(t, projectID, db.args[0][0])
+	})
+
+	t.Run("no segments skip the query", func(t *testing.T) {
+		db := &fakeDB{}
+
+		counts, err := (&segmentRepository{db: db}).CountUsersBySegment(context.Background(), projectID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+		assert.Empty(t, db.queries)
+	})
+}
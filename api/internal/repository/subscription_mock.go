@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// MockSubscriptionRepository is a mock implementation of SubscriptionRepository for testing
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+// NewMockSubscriptionRepository creates a new mock subscription repository
+func NewMockSubscriptionRepository() *MockSubscriptionRepository {
+	return &MockSubscriptionRepository{}
+}
+
+func (m *MockSubscriptionRepository) Get(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) (*domain.SubscriptionStatus, error) {
+	args := m.Called(ctx, projectID, feedbackID, follower)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SubscriptionStatus), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Subscribe(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) error {
+	args := m.Called(ctx, projectID, feedbackID, follower)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) Unsubscribe(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) error {
+	args := m.Called(ctx, projectID, feedbackID, follower)
+	return args.Error(0)
+}
+
+var _ SubscriptionRepository = (*MockSubscriptionRepository)(nil)
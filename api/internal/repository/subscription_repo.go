@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+type subscriptionRepository struct {
+	db DBTX
+}
+
+// NewSubscriptionRepository creates a new subscription repository
+func NewSubscriptionRepository(db *pgxpool.Pool) SubscriptionRepository {
+	return &subscriptionRepository{db: db}
+}
+
+func (r *subscriptionRepository) Get(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) (*domain.SubscriptionStatus, error) {
+	if err := follower.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Explicit follows win over automatic ones when the follower has rows
+	// under several linked identities
+	cond, arg := followerCondition("s", follower, 3)
+	query := fmt.Sprintf(`
+		SELECT s.reason
+		FROM feedback f
+		LEFT JOIN LATERAL (
+			SELECT s.reason
+			FROM feedback_subscriptions s
+			WHERE s.feedback_id = f.id AND NOT s.muted AND %s
+			ORDER BY (s.reason = 'manual') DESC, s.created_at ASC
+			LIMIT 1
+		) s ON true
+		WHERE f.id = $2 AND f.project_id = $1
+	`, cond)
+
+	var reason *domain.SubscriptionReason
+	err := r.db.QueryRow(ctx, query, projectID, feedbackID, arg).Scan(&reason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrFeedbackNotFound
+		}
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return &domain.SubscriptionStatus{
+		FeedbackID: feedbackID,
+		Subscribed: reason != nil,
+		Reason:     reason,
+	}, nil
+}
+
+func (r *subscriptionRepository) Subscribe(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) error {
+	if err := follower.Validate(); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO feedback_subscriptions (feedback_id, user_id, sdk_user_id, reason)
+		SELECT f.id, $3, $4, 'manual'
+		FROM feedback f
+		WHERE f.id = $2 AND f.project_id = $1
+		ON CONFLICT %s DO UPDATE SET muted = false, reason = 'manual'
+		RETURNING id
+	`, followerConflictTarget(follower))
+
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query, projectID, feedbackID, follower.UserID, follower.SDKUserID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrFeedbackNotFound
+		}
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	return nil
+}
+
+func (r *subscriptionRepository) Unsubscribe(ctx context.Context, projectID, feedbackID uuid.UUID, follower domain.Follower) error {
+	if err := follower.Validate(); err != nil {
+		return err
+	}
+
+	// Keep a muted row so automatic subscriptions don't come back
+	query := fmt.Sprintf(`
+		INSERT INTO feedback_subscriptions (feedback_id, user_id, sdk_user_id, reason, muted)
+		SELECT f.id, $3, $4, 'manual', true
+		FROM feedback f
+		WHERE f.id = $2 AND f.project_id = $1
+		ON CONFLICT %s DO UPDATE SET muted = true
+		RETURNING id
+	`, followerConflictTarget(follower))
+
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, query, projectID, feedbackID, follower.UserID, follower.SDKUserID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrFeedbackNotFound
+		}
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	// Also mute subscriptions held through linked identities
+	cond, arg := followerCondition("s", follower, 2)
+	linked := fmt.Sprintf(`
+		UPDATE feedback_subscriptions s
+		SET muted = true
+		WHERE s.feedback_id = $1 AND NOT s.muted AND %s
+	`, cond)
+	if _, err := r.db.Exec(ctx, linked, feedbackID, arg); err != nil {
+		return fmt.Errorf("failed to unsubscribe linked identities: %w", err)
+	}
+
+	return nil
+}
+
+// followerCondition matches subscriptions held by the follower or by the
+// identities linked to it. Subscriptions use the same identity columns as
+// votes.
+func followerCondition(alias string, follower domain.Follower, argIndex int) (string, interface{}) {
+	return voterCondition(alias, domain.Voter{UserID: follower.UserID, SDKUserID: follower.SDKUserID}, argIndex)
+}
+
+// followerConflictTarget returns the ON CONFLICT target for the follower's
+// partial unique index
+func followerConflictTarget(follower domain.Follower) string {
+	if follower.UserID != nil {
+		return "(feedback_id, user_id) WHERE user_id IS NOT NULL"
+	}
+	return "(feedback_id, sdk_user_id) WHERE sdk_user_id IS NOT NULL"
+}
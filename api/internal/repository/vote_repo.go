@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,23 +23,165 @@ func NewVoteRepository(db *pgxpool.Pool) VoteRepository {
 }
 
 func (r *voteRepository) Create(ctx context.Context, v *domain.Vote) error {
-	query := `
-		INSERT INTO votes (id, feedback_id, user_id, created_at)
-		VALUES ($1, $2, $3, NOW())
-		ON CONFLICT (feedback_id, user_id) DO NOTHING
-		RETURNING created_at
-	`
-
-	err := r.db.QueryRow(ctx, query,
+	return insertVote(ctx, r.db, v)
+}
+
+func (r *voteRepository) CreateWithinBudget(ctx context.Context, projectID uuid.UUID, v *domain.Vote) error {
+	return insertVoteWithinBudget(ctx, r.db, projectID, v)
+}
+
+func (r *voteRepository) Delete(ctx context.Context, feedbackID uuid.UUID, voter domain.Voter) error {
+	return deleteVote(ctx, r.db, feedbackID, voter)
+}
+
+func (r *voteRepository) Exists(ctx context.Context, feedbackID uuid.UUID, voter domain.Voter) (bool, error) {
+	return voteExists(ctx, r.db, feedbackID, voter)
+}
+
+func (r *voteRepository) CountByFeedback(ctx context.Context, feedbackID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM votes WHERE feedback_id = $1`
+
+	var count int
+	err := r.db.QueryRow(ctx, query, feedbackID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count votes: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *voteRepository) ResolveCustomer(ctx context.Context, projectID uuid.UUID, c domain.VoteCustomer) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	if c.SDKUserID != nil {
+		err := r.db.QueryRow(ctx,
+			`SELECT id FROM sdk_users WHERE id = $1 AND project_id = $2`,
+			*c.SDKUserID, projectID,
+		).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return uuid.Nil, domain.ErrSDKUserNotFound
+			}
+			return uuid.Nil, fmt.Errorf("failed to get SDK user: %w", err)
+		}
+		return id, nil
+	}
+
+	externalID := ""
+	if c.ExternalID != nil {
+		externalID = strings.TrimSpace(*c.ExternalID)
+	}
+
+	if externalID == "" {
+		email := strings.TrimSpace(*c.Email)
+
+		// Prefer an SDK user the app already identified with this email
+		err := r.db.QueryRow(ctx, `
+			SELECT id FROM sdk_users
+			WHERE project_id = $1 AND LOWER(email) = LOWER($2)
+			ORDER BY last_seen_at DESC
+			LIMIT 1
+		`, projectID, email).Scan(&id)
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("failed to find SDK user: %w", err)
+		}
+
+		externalID = "email:" + strings.ToLower(email)
+	}
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO sdk_users (project_id, external_id, email, name, last_seen_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (project_id, external_id) DO UPDATE SET
+			email = COALESCE(sdk_users.email, EXCLUDED.email),
+			name = COALESCE(sdk_users.name, EXCLUDED.name)
+		RETURNING id
+	`, projectID, externalID, c.Email, c.Name).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create SDK user: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *voteRepository) Budget(ctx context.Context, projectID uuid.UUID, voter domain.Voter) (*domain.VoteBudgetUsage, error) {
+	return voteBudget(ctx, r.db, projectID, voter)
+}
+
+func (r *voteRepository) ListByVoter(ctx context.Context, voter domain.Voter, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	return votedFeedbackIDs(ctx, r.db, voter, feedbackIDs)
+}
+
+// The helpers below are the single write and lookup path for votes. Both
+// voteRepository and portalRepository use them so every surface sees the
+// same rows and the trigger-maintained feedback.vote_count.
+
+// voterCondition returns a condition matching votes on alias cast by voter,
+// bound to the placeholder $argIndex, and the argument to bind. A Supabase
+// user also matches votes cast by SDK users linked to them, and the other
+// way round, so a person is never counted twice on one item.
+func voterCondition(alias string, voter domain.Voter, argIndex int) (string, interface{}) {
+	switch {
+	case voter.UserID != nil:
+		return fmt.Sprintf(
+			"(%[1]s.user_id = $%[2]d OR %[1]s.sdk_user_id IN (SELECT id FROM sdk_users WHERE linked_user_id = $%[2]d))",
+			alias, argIndex,
+		), *voter.UserID
+	case voter.SDKUserID != nil:
+		return fmt.Sprintf(
+			"(%[1]s.sdk_user_id = $%[2]d OR %[1]s.user_id = (SELECT linked_user_id FROM sdk_users WHERE id = $%[2]d))",
+			alias, argIndex,
+		), *voter.SDKUserID
+	case voter.Fingerprint != nil:
+		return fmt.Sprintf("%s.anonymous_fingerprint = $%d", alias, argIndex), *voter.Fingerprint
+	default:
+		return "false", nil
+	}
+}
+
+// insertVote records a vote unless the voter already has one on the item,
+// in which case any importance or note sent replaces the stored one.
+// Repeat votes are not an error.
+func insertVote(ctx context.Context, db DBTX, v *domain.Vote) error {
+	if err := v.Voter.Validate(); err != nil {
+		return err
+	}
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+
+	cond, arg := voterCondition("v", v.Voter, 9)
+	query := fmt.Sprintf(`
+		INSERT INTO votes (id, feedback_id, user_id, sdk_user_id, anonymous_fingerprint, importance, note, recorded_by)
+		SELECT $1::uuid, $2::uuid, $3::uuid, $4::uuid, $5::varchar,
+			COALESCE(NULLIF($6::varchar, ''), 'nice_to_have'), NULLIF($7::text, ''), $8::uuid
+		WHERE NOT EXISTS (SELECT 1 FROM votes v WHERE v.feedback_id = $2 AND %s)
+		ON CONFLICT DO NOTHING
+		RETURNING importance, created_at
+	`, cond)
+
+	err := db.QueryRow(ctx, query,
 		v.ID,
 		v.FeedbackID,
 		v.UserID,
-	).Scan(&v.CreatedAt)
+		v.SDKUserID,
+		v.Fingerprint,
+		string(v.Importance),
+		v.Note,
+		v.RecordedBy,
+		arg,
+	).Scan(&v.Importance, &v.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			// Vote already exists - not an error, just idempotent
-			return nil
+			return updateVoteDetails(ctx, db, v)
+		}
+		if isForeignKeyViolation(err) {
+			return domain.ErrFeedbackNotFound
 		}
 		return fmt.Errorf("failed to create vote: %w", err)
 	}
@@ -46,10 +189,41 @@ func (r *voteRepository) Create(ctx context.Context, v *domain.Vote) error {
 	return nil
 }
 
-func (r *voteRepository) Delete(ctx context.Context, feedbackID, userID uuid.UUID) error {
-	query := `DELETE FROM votes WHERE feedback_id = $1 AND user_id = $2`
+// updateVoteDetails replaces the importance and note sent with a repeat
+// vote, leaving fields that were not sent unchanged. An empty note clears
+// the stored one.
+func updateVoteDetails(ctx context.Context, db DBTX, v *domain.Vote) error {
+	if v.Importance == "" && v.Note == nil {
+		return nil
+	}
+
+	cond, arg := voterCondition("v", v.Voter, 2)
+	query := fmt.Sprintf(`
+		UPDATE votes v
+		SET importance = COALESCE(NULLIF($3::varchar, ''), v.importance),
+			note = CASE WHEN $4::text IS NULL THEN v.note ELSE NULLIF($4::text, '') END,
+			recorded_by = COALESCE($5::uuid, v.recorded_by)
+		WHERE v.feedback_id = $1 AND %s
+	`, cond)
 
-	result, err := r.db.Exec(ctx, query, feedbackID, userID)
+	if _, err := db.Exec(ctx, query, v.FeedbackID, arg, string(v.Importance), v.Note, v.RecordedBy); err != nil {
+		return fmt.Errorf("failed to update vote: %w", err)
+	}
+
+	return nil
+}
+
+// deleteVote removes the voter's vote on an item, returning ErrNotFound if
+// there was none
+func deleteVote(ctx context.Context, db DBTX, feedbackID uuid.UUID, voter domain.Voter) error {
+	if err := voter.Validate(); err != nil {
+		return err
+	}
+
+	cond, arg := voterCondition("v", voter, 2)
+	query := fmt.Sprintf(`DELETE FROM votes v WHERE v.feedback_id = $1 AND %s`, cond)
+
+	result, err := db.Exec(ctx, query, feedbackID, arg)
 	if err != nil {
 		return fmt.Errorf("failed to delete vote: %w", err)
 	}
@@ -61,11 +235,16 @@ func (r *voteRepository) Delete(ctx context.Context, feedbackID, userID uuid.UUI
 	return nil
 }
 
-func (r *voteRepository) Exists(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM votes WHERE feedback_id = $1 AND user_id = $2)`
+func voteExists(ctx context.Context, db DBTX, feedbackID uuid.UUID, voter domain.Voter) (bool, error) {
+	if err := voter.Validate(); err != nil {
+		return false, err
+	}
+
+	cond, arg := voterCondition("v", voter, 2)
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM votes v WHERE v.feedback_id = $1 AND %s)`, cond)
 
 	var exists bool
-	err := r.db.QueryRow(ctx, query, feedbackID, userID).Scan(&exists)
+	err := db.QueryRow(ctx, query, feedbackID, arg).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check vote: %w", err)
 	}
@@ -73,26 +252,18 @@ func (r *voteRepository) Exists(ctx context.Context, feedbackID, userID uuid.UUI
 	return exists, nil
 }
 
-func (r *voteRepository) CountByFeedback(ctx context.Context, feedbackID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM votes WHERE feedback_id = $1`
-
-	var count int
-	err := r.db.QueryRow(ctx, query, feedbackID).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count votes: %w", err)
-	}
-
-	return count, nil
-}
-
-func (r *voteRepository) ListByUser(ctx context.Context, userID uuid.UUID, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+func votedFeedbackIDs(ctx context.Context, db DBTX, voter domain.Voter, feedbackIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
 	if len(feedbackIDs) == 0 {
 		return make(map[uuid.UUID]bool), nil
 	}
+	if err := voter.Validate(); err != nil {
+		return nil, err
+	}
 
-	query := `SELECT feedback_id FROM votes WHERE user_id = $1 AND feedback_id = ANY($2)`
+	cond, arg := voterCondition("v", voter, 2)
+	query := fmt.Sprintf(`SELECT DISTINCT v.feedback_id FROM votes v WHERE v.feedback_id = ANY($1) AND %s`, cond)
 
-	rows, err := r.db.Query(ctx, query, userID, feedbackIDs)
+	rows, err := db.Query(ctx, query, feedbackIDs, arg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list votes: %w", err)
 	}
@@ -107,5 +278,95 @@ func (r *voteRepository) ListByUser(ctx context.Context, userID uuid.UUID, feedb
 		result[feedbackID] = true
 	}
 
-	return result, nil
+	return result, rows.Err()
+}
+
+// voteBudget counts the voter's active votes in a project against its vote
+// budget. Votes on resolved items and team-recorded votes are not counted.
+func voteBudget(ctx context.Context, db DBTX, projectID uuid.UUID, voter domain.Voter) (*domain.VoteBudgetUsage, error) {
+	if err := voter.Validate(); err != nil {
+		return nil, err
+	}
+
+	cond, arg := voterCondition("v", voter, 2)
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE((p.settings->>'vote_budget')::int, 0),
+			(
+				SELECT COUNT(*)
+				FROM votes v
+				JOIN feedback f ON f.id = v.feedback_id
+				WHERE f.project_id = p.id
+					AND f.status NOT IN ('completed', 'declined', 'duplicate')
+					AND v.recorded_by IS NULL
+					AND %s
+			)
+		FROM projects p
+		WHERE p.id = $1
+	`, cond)
+
+	var budget, used int
+	err := db.QueryRow(ctx, query, projectID, arg).Scan(&budget, &used)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get vote budget: %w", err)
+	}
+
+	return domain.NewVoteBudgetUsage(budget, used), nil
+}
+
+// insertVoteWithinBudget records a vote like insertVote, but returns
+// ErrVoteBudgetExhausted when a new vote would exceed the voter's budget.
+// Repeat votes are always accepted. The check and the insert run in one
+// transaction holding a per-voter advisory lock, so concurrent votes can't
+// both take the last slot.
+func insertVoteWithinBudget(ctx context.Context, db DBTX, projectID uuid.UUID, v *domain.Vote) error {
+	if err := v.Voter.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := beginTx(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to begin vote transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Linked SDK users share their Supabase user's lock, as they share a budget
+	_, err = tx.Exec(ctx, `
+		SELECT pg_advisory_xact_lock(hashtextextended('vote_budget:' || $1::text || ':' || CASE
+			WHEN $2::uuid IS NOT NULL THEN 'user:' || $2::text
+			WHEN $3::uuid IS NOT NULL THEN COALESCE(
+				(SELECT 'user:' || linked_user_id::text FROM sdk_users WHERE id = $3 AND linked_user_id IS NOT NULL),
+				'sdk:' || $3::text)
+			ELSE 'anonymous:' || $4::text
+		END, 0))
+	`, projectID, v.UserID, v.SDKUserID, v.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to lock vote budget: %w", err)
+	}
+
+	exists, err := voteExists(ctx, tx, v.FeedbackID, v.Voter)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		usage, err := voteBudget(ctx, tx, projectID, v.Voter)
+		if err != nil {
+			return err
+		}
+		if usage.Exhausted() {
+			return domain.ErrVoteBudgetExhausted
+		}
+	}
+
+	if err := insertVote(ctx, tx, v); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit vote: %w", err)
+	}
+	return nil
 }
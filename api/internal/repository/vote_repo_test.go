@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestVoterCondition(t *testing.T) {
+	t.Run("users also match votes from their linked SDK users", func(t *testing.T) {
+		userID := uuid.New()
+
+		cond, arg := voterCondition("v", domain.UserVoter(userID), 3)
+
+		assert.Equal(t, "(v.user_id = $3 OR v.sdk_user_id IN (SELECT id FROM sdk_users WHERE linked_user_id = $3))", cond)
+		assert.Equal(t, userID, arg)
+	})
+
+	t.Run("SDK users also match votes from their linked user", func(t *testing.T) {
+		sdkUserID := uuid.New()
+
+		cond, arg := voterCondition("v", domain.SDKUserVoter(sdkUserID), 2)
+
+		assert.Equal(t, "(v.sdk_user_id = $2 OR v.user_id = (SELECT linked_user_id FROM sdk_users WHERE id = $2))", cond)
+		assert.Equal(t, sdkUserID, arg)
+	})
+
+	t.Run("anonymous voters match on fingerprint only", func(t *testing.T) {
+		cond, arg := voterCondition("v", domain.AnonymousVoter("fp-123"), 2)
+
+		assert.Equal(t, "v.anonymous_fingerprint = $2", cond)
+		assert.Equal(t, "fp-123", arg)
+	})
+
+	t.Run("empty voter matches nothing", func(t *testing.T) {
+		cond, arg := voterCondition("v", domain.Voter{}, 2)
+
+		assert.Equal(t, "false", cond)
+		assert.Nil(t, arg)
+	})
+}
+
+func TestVoterValidate(t *testing.T) {
+	userID := uuid.New()
+	sdkUserID := uuid.New()
+	empty := ""
+
+	assert.NoError(t, domain.UserVoter(userID).Validate())
+	assert.NoError(t, domain.SDKUserVoter(sdkUserID).Validate())
+	assert.NoError(t, domain.AnonymousVoter("fp").Validate())
+
+	assert.ErrorIs(t, domain.Voter{}.Validate(), domain.ErrInvalidVoter)
+	assert.ErrorIs(t, domain.Voter{UserID: &userID, SDKUserID: &sdkUserID}.Validate(), domain.ErrInvalidVoter)
+	assert.ErrorIs(t, domain.Voter{Fingerprint: &empty}.Validate(), domain.ErrInvalidVoter)
+}
+
+func TestVoteDetailsValidate(t *testing.T) {
+	long := strings.Repeat("x", domain.MaxVoteNoteLength+1)
+	note := "Blocks our Q3 rollout"
+
+	assert.NoError(t, domain.VoteDetails{}.Validate())
+	assert.NoError(t, domain.VoteDetails{Importance: domain.ImportanceCritical, Note: &note}.Validate())
+
+	assert.Error(t, domain.VoteDetails{Importance: "urgent"}.Validate())
+	assert.Error(t, domain.VoteDetails{Note: &long}.Validate())
+}
+
+func TestVoteImportanceWeight(t *testing.T) {
+	assert.Equal(t, 1, domain.ImportanceNiceToHave.Weight())
+	assert.Equal(t, 2, domain.ImportanceImportant.Weight())
+	assert.Equal(t, 3, domain.ImportanceCritical.Weight())
+	assert.Equal(t, 1, domain.VoteImportance("").Weight())
+}
+
+func TestNewVoteBudgetUsage(t *testing.T) {
+	t.Run("unlimited when the project has no budget", func(t *testing.T) {
+		usage := domain.NewVoteBudgetUsage(0, 12)
+
+		assert.Nil(t, usage.Budget)
+		assert.Nil(t, usage.Remaining)
+		assert.Equal(t, 12, usage.Used)
+		assert.False(t, usage.Exhausted())
+	})
+
+	t.Run("reports remaining votes", func(t *testing.T) {
+		usage := domain.NewVoteBudgetUsage(10, 4)
+
+		assert.Equal(t, 10, *usage.Budget)
+		assert.Equal(t, 6, *usage.Remaining)
+		assert.False(t, usage.Exhausted())
+	})
+
+	t.Run("never reports negative remaining votes", func(t *testing.T) {
+		// Lowering the budget can leave voters over it
+		usage := domain.NewVoteBudgetUsage(5, 8)
+
+		assert.Equal(t, 0, *usage.Remaining)
+		assert.True(t, usage.Exhausted())
+	})
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+type brandingService struct {
+	projectRepo    repository.ProjectRepository
+	storage        storage.ObjectStorage
+	uploadExpiry   time.Duration
+	downloadExpiry time.Duration
+	now            func() time.Time
+}
+
+// NewBrandingService creates a new branding service. storage may be nil, in
+// which case logos cannot be uploaded.
+func NewBrandingService(projectRepo repository.ProjectRepository, storage storage.ObjectStorage) BrandingService {
+	return &brandingService{
+		projectRepo:    projectRepo,
+		storage:        storage,
+		uploadExpiry:   15 * time.Minute,
+		downloadExpiry: 24 * time.Hour,
+		now:            time.Now,
+	}
+}
+
+func (s *brandingService) Get(ctx context.Context, projectID uuid.UUID) (*domain.PublicPortalConfig, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.config(ctx, project), nil
+}
+
+func (s *brandingService) Update(ctx context.Context, projectID uuid.UUID, req domain.UpdateBrandingRequest) (*domain.PublicPortalConfig, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Apply(project)
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return s.config(ctx, project), nil
+}
+
+func (s *brandingService) InitiateLogoUpload(ctx context.Context, projectID uuid.UUID, req domain.LogoUploadRequest) (*LogoUploadInfo, error) {
+	if s.storage == nil {
+		return nil, domain.NewDomainError("STORAGE_UNAVAILABLE", "Logo uploads are not available", 503)
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	// A fresh name per upload so caches never serve a replaced logo
+	path := domain.LogoPathPrefix(projectID) + "logo-" + uuid.NewString() + domain.LogoContentTypes[req.ContentType]
+	uploadURL, err := s.storage.GenerateUploadURL(ctx, path, req.ContentType, s.uploadExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
+	}
+
+	return &LogoUploadInfo{
+		Path:      path,
+		UploadURL: uploadURL,
+		ExpiresAt: s.now().Add(s.uploadExpiry),
+	}, nil
+}
+
+func (s *brandingService) CompleteLogoUpload(ctx context.Context, projectID uuid.UUID, path string) (*domain.PublicPortalConfig, error) {
+	if s.storage == nil {
+		return nil, domain.NewDomainError("STORAGE_UNAVAILABLE", "Logo uploads are not available", 503)
+	}
+	// Only paths issued for this project may become its logo
+	if !strings.HasPrefix(path, domain.LogoPathPrefix(projectID)) || strings.Contains(path, "..") {
+		return nil, domain.ErrValidation.WithMessage("invalid logo path")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.storage.Exists(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify upload: %w", err)
+	}
+	if !exists {
+		return nil, domain.NewDomainError("UPLOAD_NOT_FOUND", "Upload was not completed", 400)
+	}
+
+	previous := project.Settings.Branding.LogoPath
+	project.Settings.Branding.LogoPath = path
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	if previous != "" && previous != path {
+		s.deleteLogo(ctx, previous)
+	}
+
+	return s.config(ctx, project), nil
+}
+
+func (s *brandingService) RemoveLogo(ctx context.Context, projectID uuid.UUID) (*domain.PublicPortalConfig, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := project.Settings.Branding.LogoPath
+	project.Settings.Branding.LogoPath = ""
+	project.LogoURL = nil
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	if previous != "" {
+		s.deleteLogo(ctx, previous)
+	}
+
+	return s.config(ctx, project), nil
+}
+
+func (s *brandingService) GetPublicConfig(ctx context.Context, slug string) (*domain.PublicPortalConfig, error) {
+	project, err := s.projectRepo.GetBySlug(ctx, slug)
+	return s.publicConfig(ctx, project, err)
+}
+
+func (s *brandingService) GetPublicConfigByID(ctx context.Context, projectID uuid.UUID) (*domain.PublicPortalConfig, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	return s.publicConfig(ctx, project, err)
+}
+
+func (s *brandingService) publicConfig(ctx context.Context, project *domain.Project, err error) (*domain.PublicPortalConfig, error) {
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	// Archived projects have no public portal
+	if project.IsArchived() {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.config(ctx, project), nil
+}
+
+// config builds the portal config, resolving an uploaded logo to a signed
+// URL. A project without an uploaded logo falls back to its logo_url.
+func (s *brandingService) config(ctx context.Context, project *domain.Project) *domain.PublicPortalConfig {
+	logoURL := project.LogoURL
+	if path := project.Settings.Branding.LogoPath; path != "" && s.storage != nil {
+		url, err := s.storage.GenerateDownloadURL(ctx, path, s.downloadExpiry)
+		if err != nil {
+			log.Warn().Err(err).Str("project_id", project.ID.String()).Msg("Failed to sign logo URL")
+		} else {
+			logoURL = &url
+		}
+	}
+	return domain.NewPublicPortalConfig(project, logoURL)
+}
+
+func (s *brandingService) deleteLogo(ctx context.Context, path string) {
+	if s.storage == nil {
+		return
+	}
+	if err := s.storage.Delete(ctx, path); err != nil {
+		log.Warn().Err(err).Str("path", path).Msg("Failed to delete previous logo")
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func brandedProject() *domain.Project {
+	return &domain.Project{
+		ID:           uuid.New(),
+		Name:         "Acme",
+		Slug:         "acme",
+		Settings:     domain.DefaultProjectSettings(),
+		PrimaryColor: "#111111",
+	}
+}
+
+func TestBrandingService_Update(t *testing.T) {
+	ctx := context.Background()
+	project := brandedProject()
+	repo := repository.NewMockProjectRepository()
+	repo.On("GetByID", ctx, project.ID).Return(project, nil)
+	repo.On("Update", ctx, mock.MatchedBy(func(p *domain.Project) bool {
+		return p.PrimaryColor == "#FF0000" && p.Settings.Branding.Title == "Acme Feedback"
+	})).Return(nil)
+
+	title, color := "Acme Feedback", "#FF0000"
+	types := []domain.FeedbackType{domain.FeedbackTypeBug}
+	config, err := NewBrandingService(repo, nil).Update(ctx, project.ID, domain.UpdateBrandingRequest{
+		Title:         &title,
+		PrimaryColor:  &color,
+		AcceptedTypes: &types,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Acme Feedback", config.Title)
+	assert.Equal(t, "#FF0000", config.PrimaryColor)
+	assert.Equal(t, types, config.AcceptedTypes)
+	// Untouched fields keep their values
+	assert.Equal(t, "Acme", config.Name)
+	assert.Empty(t, config.Links)
+}
+
+func TestBrandingService_Logo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("confirming an upload replaces the previous logo", func(t *testing.T) {
+		project := brandedProject()
+		old := domain.LogoPathPrefix(project.ID) + "logo-old.png"
+		project.Settings.Branding.LogoPath = old
+		store := &fakeStorage{existing: map[string]bool{}}
+		repo := repository.NewMockProjectRepository()
+		repo.On("GetByID", ctx, project.ID).Return(project, nil)
+		repo.On("Update", ctx, project).Return(nil)
+		svc := NewBrandingService(repo, store)
+
+		upload, err := svc.InitiateLogoUpload(ctx, project.ID, domain.LogoUploadRequest{ContentType: "image/png", SizeBytes: 1024})
+		require.NoError(t, err)
+		assert.Contains(t, upload.Path, domain.LogoPathPrefix(project.ID))
+		assert.True(t, upload.ExpiresAt.After(time.Now()))
+
+		// Nothing uploaded yet
+		_, err = svc.CompleteLogoUpload(ctx, project.ID, upload.Path)
+		assert.Error(t, err)
+
+		store.existing[upload.Path] = true
+		config, err := svc.CompleteLogoUpload(ctx, project.ID, upload.Path)
+		require.NoError(t, err)
+
+		require.NotNil(t, config.LogoURL)
+		assert.Equal(t, "https://storage.test/"+upload.Path, *config.LogoURL)
+		assert.Equal(t, []string{old}, store.deleted)
+	})
+
+	t.Run("rejects paths of other projects", func(t *testing.T) {
+		project := brandedProject()
+		store := &fakeStorage{existing: map[string]bool{}}
+		svc := NewBrandingService(repository.NewMockProjectRepository(), store)
+
+		for _, path := range []string{
+			domain.LogoPathPrefix(uuid.New()) + "logo.png",
+			domain.LogoPathPrefix(project.ID) + "../attachments/secret.pdf",
+		} {
+			_, err := svc.CompleteLogoUpload(ctx, project.ID, path)
+			assert.ErrorIs(t, err, domain.ErrValidation, path)
+		}
+	})
+
+	t.Run("uploads need storage", func(t *testing.T) {
+		_, err := NewBrandingService(repository.NewMockProjectRepository(), nil).
+			InitiateLogoUpload(ctx, uuid.New(), domain.LogoUploadRequest{ContentType: "image/png", SizeBytes: 1024})
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, 503, domainErr.Status)
+	})
+}
+
+func TestBrandingService_GetPublicConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults the title to the project name", func(t *testing.T) {
+		project := brandedProject()
+		repo := repository.NewMockProjectRepository()
+		repo.On("GetBySlug", ctx, "acme").Return(project, nil)
+
+		config, err := NewBrandingService(repo, nil).GetPublicConfig(ctx, "acme")
+		require.NoError(t, err)
+		assert.Equal(t, "Acme", config.Title)
+		assert.Len(t, config.AcceptedTypes, 3)
+	})
+
+	t.Run("hides archived projects", func(t *testing.T) {
+		project := brandedProject()
+		archivedAt := time.Now()
+		project.ArchivedAt = &archivedAt
+		repo := repository.NewMockProjectRepository()
+		repo.On("GetBySlug", ctx, "acme").Return(project, nil)
+
+		_, err := NewBrandingService(repo, nil).GetPublicConfig(ctx, "acme")
+		assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+	})
+}
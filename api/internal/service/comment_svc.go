@@ -11,18 +11,21 @@ import (
 )
 
 type commentService struct {
-	commentRepo  repository.CommentRepository
-	feedbackRepo repository.FeedbackRepository
+	commentRepo    repository.CommentRepository
+	feedbackRepo   repository.FeedbackRepository
+	membershipRepo repository.MembershipRepository
 }
 
 // NewCommentService creates a new comment service
 func NewCommentService(
 	commentRepo repository.CommentRepository,
 	feedbackRepo repository.FeedbackRepository,
+	membershipRepo repository.MembershipRepository,
 ) CommentService {
 	return &commentService{
-		commentRepo:  commentRepo,
-		feedbackRepo: feedbackRepo,
+		commentRepo:    commentRepo,
+		feedbackRepo:   feedbackRepo,
+		membershipRepo: membershipRepo,
 	}
 }
 
@@ -32,6 +35,9 @@ func (s *commentService) Create(ctx context.Context, req CreateCommentRequest) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to get feedback: %w", err)
 	}
+	if feedback.ProjectID != req.ProjectID {
+		return nil, domain.ErrFeedbackNotFound
+	}
 
 	// Validate parent if provided
 	if req.ParentID != nil {
@@ -50,12 +56,24 @@ func (s *commentService) Create(ctx context.Context, req CreateCommentRequest) (
 		ID:         uuid.New(),
 		FeedbackID: feedback.ID,
 		AuthorID:   req.AuthorID,
+		SDKUserID:  req.SDKUserID,
 		ParentID:   req.ParentID,
 		Body:       req.Body,
 		Visibility: req.Visibility,
 		IsEdited:   false,
 	}
 
+	// Mentions are resolved up front so the repository records them with
+	// the comment
+	if handles := domain.ParseMentions(comment.Body); len(handles) > 0 {
+		members, err := s.membershipRepo.ListByProject(ctx, feedback.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members: %w", err)
+		}
+
+		comment.Mentions = resolveMentions(handles, members, comment, feedback.Visibility)
+	}
+
 	if err := s.commentRepo.Create(ctx, comment); err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
@@ -63,6 +81,41 @@ func (s *commentService) Create(ctx context.Context, req CreateCommentRequest) (
 	return comment, nil
 }
 
+// resolveMentions matches handles to project members by display name.
+// Mentions only reach members who can see both the comment and its item,
+// never the author, and a handle shared by several members reaches none of
+// them rather than guessing.
+func resolveMentions(handles []string, members []domain.Membership, comment *domain.Comment, feedbackVisibility domain.Visibility) []uuid.UUID {
+	byHandle := make(map[string][]domain.Membership)
+	for _, m := range members {
+		if handle := m.MentionHandle(); handle != "" {
+			byHandle[handle] = append(byHandle[handle], m)
+		}
+	}
+
+	var mentioned []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, handle := range handles {
+		matches := byHandle[handle]
+		if len(matches) != 1 {
+			continue
+		}
+
+		m := matches[0]
+		if m.UserID == comment.AuthorID || seen[m.UserID] {
+			continue
+		}
+		if !comment.Visibility.IsVisibleTo(m.Role) || !feedbackVisibility.IsVisibleTo(m.Role) {
+			continue
+		}
+
+		seen[m.UserID] = true
+		mentioned = append(mentioned, m.UserID)
+	}
+
+	return mentioned
+}
+
 func (s *commentService) ListByFeedback(ctx context.Context, feedbackID uuid.UUID, includeTeamOnly bool) ([]domain.Comment, error) {
 	comments, err := s.commentRepo.ListByFeedback(ctx, feedbackID, includeTeamOnly)
 	if err != nil {
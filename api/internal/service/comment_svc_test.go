@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// commentsCreated records the comments handed to the repository
+type commentsCreated struct {
+	repository.CommentRepository
+	created []domain.Comment
+}
+
+func (f *commentsCreated) Create(_ context.Context, c *domain.Comment) error {
+	f.created = append(f.created, *c)
+	return nil
+}
+
+// feedbackByID serves feedback keyed by ID
+type feedbackByID struct {
+	repository.FeedbackRepository
+	byID map[uuid.UUID]*domain.Feedback
+}
+
+func (f feedbackByID) GetByID(_ context.Context, id uuid.UUID) (*domain.Feedback, error) {
+	fb, ok := f.byID[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return fb, nil
+}
+
+// projectMembers lists the same members for every project
+type projectMembers struct {
+	repository.MembershipRepository
+	members []domain.Membership
+}
+
+func (f projectMembers) ListByProject(context.Context, uuid.UUID) ([]domain.Membership, error) {
+	return f.members, nil
+}
+
+func TestCommentService_Create(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	feedback := &domain.Feedback{ID: uuid.New(), ProjectID: projectID, Visibility: domain.VisibilityCommunity}
+	feedbacks := feedbackByID{byID: map[uuid.UUID]*domain.Feedback{feedback.ID: feedback}}
+	janeName := "Jane"
+	jane := domain.Membership{UserID: uuid.New(), Role: domain.RoleMember, DisplayName: &janeName}
+	members := projectMembers{members: []domain.Membership{jane}}
+
+	t.Run("hands mentions to the repository with the comment", func(t *testing.T) {
+		comments := &commentsCreated{}
+		svc := NewCommentService(comments, feedbacks, members)
+
+		comment, err := svc.Create(ctx, CreateCommentRequest{
+			ProjectID:  projectID,
+			FeedbackID: feedback.ID,
+			AuthorID:   uuid.New(),
+			Body:       "Thoughts, @jane?",
+			Visibility: domain.VisibilityTeamOnly,
+		})
+		require.NoError(t, err)
+		require.Len(t, comments.created, 1)
+		assert.Equal(t, []uuid.UUID{jane.UserID}, comments.created[0].Mentions)
+		assert.Equal(t, []uuid.UUID{jane.UserID}, comment.Mentions)
+	})
+
+	t.Run("keeps the portal user's SDK user", func(t *testing.T) {
+		comments := &commentsCreated{}
+		sdkUserID := uuid.New()
+
+		_, err := NewCommentService(comments, feedbacks, members).Create(ctx, CreateCommentRequest{
+			ProjectID:  projectID,
+			FeedbackID: feedback.ID,
+			AuthorID:   uuid.New(),
+			SDKUserID:  &sdkUserID,
+			Body:       "Me too",
+			Visibility: domain.VisibilityCommunity,
+		})
+		require.NoError(t, err)
+		require.Len(t, comments.created, 1)
+		assert.Equal(t, &sdkUserID, comments.created[0].SDKUserID)
+		assert.Empty(t, comments.created[0].Mentions)
+	})
+
+	t.Run("feedback from another project is not found", func(t *testing.T) {
+		comments := &commentsCreated{}
+
+		_, err := NewCommentService(comments, feedbacks, members).Create(ctx, CreateCommentRequest{
+			ProjectID:  uuid.New(),
+			FeedbackID: feedback.ID,
+			AuthorID:   uuid.New(),
+			Body:       "@jane",
+			Visibility: domain.VisibilityCommunity,
+		})
+		assert.ErrorIs(t, err, domain.ErrFeedbackNotFound)
+		assert.Empty(t, comments.created)
+	})
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"single", "@jane can you look?", []string{"jane"}},
+		{"normalized and deduplicated", "@Jane.Doe and @janedoe, also @sam_lee.", []string{"janedoe", "samlee"}},
+		{"email addresses are not mentions", "mail jane@example.com", nil},
+		{"bare at sign", "meet @ noon", nil},
+		{"inside parentheses", "(cc @sam)", []string{"sam"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.ParseMentions(tt.body))
+		})
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	member := func(name string, role domain.Role) domain.Membership {
+		return domain.Membership{UserID: uuid.New(), Role: role, DisplayName: &name}
+	}
+	author := member("Alex Author", domain.RoleMember)
+	jane := member("Jane Doe", domain.RoleMember)
+	viewer := member("Vic", domain.RoleViewer)
+	community := member("Cam", domain.RoleCommunity)
+	samA := member("Sam", domain.RoleMember)
+	samB := member("sam", domain.RoleAdmin)
+	members := []domain.Membership{author, jane, viewer, community, samA, samB}
+
+	comment := func(visibility domain.Visibility) *domain.Comment {
+		return &domain.Comment{AuthorID: author.UserID, Visibility: visibility}
+	}
+
+	t.Run("team notes reach team members only", func(t *testing.T) {
+		handles := domain.ParseMentions("@jane.doe @vic @cam")
+
+		got := resolveMentions(handles, members, comment(domain.VisibilityTeamOnly), domain.VisibilityCommunity)
+
+		assert.Equal(t, []uuid.UUID{jane.UserID, viewer.UserID}, got)
+	})
+
+	t.Run("community comments on team-only items reach team members only", func(t *testing.T) {
+		got := resolveMentions([]string{"cam", "vic"}, members, comment(domain.VisibilityCommunity), domain.VisibilityTeamOnly)
+
+		assert.Equal(t, []uuid.UUID{viewer.UserID}, got)
+	})
+
+	t.Run("community comments reach community members", func(t *testing.T) {
+		got := resolveMentions([]string{"cam"}, members, comment(domain.VisibilityCommunity), domain.VisibilityCommunity)
+
+		assert.Equal(t, []uuid.UUID{community.UserID}, got)
+	})
+
+	t.Run("skips the author, unknown and ambiguous handles", func(t *testing.T) {
+		got := resolveMentions([]string{"alexauthor", "nobody", "sam"}, members, comment(domain.VisibilityTeamOnly), domain.VisibilityCommunity)
+
+		assert.Empty(t, got)
+	})
+}
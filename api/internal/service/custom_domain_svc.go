@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// TXTResolver looks up DNS TXT records. *net.Resolver satisfies it; tests
+// use a fake.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// customDomainCacheTTL bounds how long a verified or removed domain takes to
+// reach every API instance
+const customDomainCacheTTL = time.Minute
+
+type customDomainService struct {
+	repo     repository.CustomDomainRepository
+	resolver TXTResolver
+	now      func() time.Time
+
+	mu       sync.Mutex
+	hosts    map[string]uuid.UUID
+	loadedAt time.Time
+}
+
+// NewCustomDomainService creates a new custom domain service that verifies
+// domains with resolver
+func NewCustomDomainService(repo repository.CustomDomainRepository, resolver TXTResolver) CustomDomainService {
+	return &customDomainService{
+		repo:     repo,
+		resolver: resolver,
+		now:      time.Now,
+	}
+}
+
+func (s *customDomainService) Get(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error) {
+	return s.repo.GetByProject(ctx, projectID)
+}
+
+func (s *customDomainService) Register(ctx context.Context, projectID uuid.UUID, hostname string) (*domain.CustomDomain, error) {
+	existing, err := s.repo.GetByProject(ctx, projectID)
+	if err != nil && err != domain.ErrDomainNotFound {
+		return nil, err
+	}
+	if existing != nil && existing.Hostname == hostname {
+		return existing, nil
+	}
+	if owner, ok := s.verifiedHosts(ctx)[hostname]; ok && owner != projectID {
+		return nil, domain.ErrDomainTaken
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	d := &domain.CustomDomain{
+		ID:                uuid.New(),
+		ProjectID:         projectID,
+		Hostname:          hostname,
+		VerificationToken: hex.EncodeToString(token),
+	}
+	if err := s.repo.Upsert(ctx, d); err != nil {
+		return nil, err
+	}
+	// The previous domain, if verified, stops resolving
+	s.invalidate()
+	return d, nil
+}
+
+func (s *customDomainService) Verify(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error) {
+	d, err := s.repo.GetByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if d.IsVerified() {
+		return d, nil
+	}
+
+	if !s.hasRecord(ctx, d) {
+		if err := s.repo.MarkChecked(ctx, d); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrDomainNotVerified.WithMessage(
+			fmt.Sprintf("no TXT record %q found at %s", d.TXTRecordValue(), d.TXTRecordName()))
+	}
+
+	if err := s.repo.MarkVerified(ctx, d); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return d, nil
+}
+
+func (s *customDomainService) Remove(ctx context.Context, projectID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, projectID); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+func (s *customDomainService) ResolveHost(ctx context.Context, host string) (uuid.UUID, bool) {
+	projectID, ok := s.verifiedHosts(ctx)[domain.NormalizeHostname(host)]
+	return projectID, ok
+}
+
+func (s *customDomainService) IsVerifiedOrigin(ctx context.Context, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	_, ok := s.ResolveHost(ctx, u.Host)
+	return ok
+}
+
+// hasRecord reports whether the domain's verification record is published
+func (s *customDomainService) hasRecord(ctx context.Context, d *domain.CustomDomain) bool {
+	records, err := s.resolver.LookupTXT(ctx, d.TXTRecordName())
+	if err != nil {
+		log.Debug().Err(err).Str("hostname", d.Hostname).Msg("Custom domain TXT lookup failed")
+		return false
+	}
+	for _, record := range records {
+		if strings.TrimSpace(record) == d.TXTRecordValue() {
+			return true
+		}
+	}
+	return false
+}
+
+// verifiedHosts returns the verified hostnames, reloading them once the
+// cache has expired. On errors the previous set is kept.
+func (s *customDomainService) verifiedHosts(ctx context.Context) map[string]uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.hosts != nil && s.now().Sub(s.loadedAt) < customDomainCacheTTL {
+		return s.hosts
+	}
+
+	hosts, err := s.repo.ListVerified(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load verified custom domains")
+		if s.hosts == nil {
+			return map[string]uuid.UUID{}
+		}
+		hosts = s.hosts
+	}
+	s.hosts, s.loadedAt = hosts, s.now()
+	return s.hosts
+}
+
+func (s *customDomainService) invalidate() {
+	s.mu.Lock()
+	s.hosts = nil
+	s.mu.Unlock()
+}
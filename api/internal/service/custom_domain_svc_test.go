@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// fakeResolver serves TXT records by name
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := f[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+func TestCustomDomainService_Verify(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	pending := func() *domain.CustomDomain {
+		return &domain.CustomDomain{ID: uuid.New(), ProjectID: projectID, Hostname: "feedback.example.com", VerificationToken: "abc123"}
+	}
+
+	t.Run("verifies when the TXT record is published", func(t *testing.T) {
+		d := pending()
+		repo := repository.NewMockCustomDomainRepository()
+		repo.On("GetByProject", ctx, projectID).Return(d, nil)
+		repo.On("MarkVerified", ctx, d).Return(nil)
+		resolver := fakeResolver{"_fulldisclosure.feedback.example.com": {"v=spf1 -all", "fulldisclosure-verification=abc123"}}
+
+		_, err := NewCustomDomainService(repo, resolver).Verify(ctx, projectID)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("fails without the record", func(t *testing.T) {
+		d := pending()
+		repo := repository.NewMockCustomDomainRepository()
+		repo.On("GetByProject", ctx, projectID).Return(d, nil)
+		repo.On("MarkChecked", ctx, d).Return(nil)
+		resolver := fakeResolver{"_fulldisclosure.feedback.example.com": {"fulldisclosure-verification=wrong"}}
+
+		_, err := NewCustomDomainService(repo, resolver).Verify(ctx, projectID)
+		assert.ErrorIs(t, err, domain.ErrDomainNotVerified)
+		repo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCustomDomainService_Register(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects a hostname another project verified", func(t *testing.T) {
+		projectID := uuid.New()
+		repo := repository.NewMockCustomDomainRepository()
+		repo.On("GetByProject", ctx, projectID).Return(nil, domain.ErrDomainNotFound)
+		repo.On("ListVerified", ctx).Return(map[string]uuid.UUID{"feedback.example.com": uuid.New()}, nil)
+
+		_, err := NewCustomDomainService(repo, fakeResolver{}).Register(ctx, projectID, "feedback.example.com")
+		assert.ErrorIs(t, err, domain.ErrDomainTaken)
+	})
+
+	t.Run("issues a verification token", func(t *testing.T) {
+		projectID := uuid.New()
+		repo := repository.NewMockCustomDomainRepository()
+		repo.On("GetByProject", ctx, projectID).Return(nil, domain.ErrDomainNotFound)
+		repo.On("ListVerified", ctx).Return(map[string]uuid.UUID{}, nil)
+		repo.On("Upsert", ctx, mock.Anything).Return(nil)
+
+		d, err := NewCustomDomainService(repo, fakeResolver{}).Register(ctx, projectID, "feedback.example.com")
+		require.NoError(t, err)
+		assert.Len(t, d.VerificationToken, 32)
+		assert.False(t, d.IsVerified())
+	})
+}
+
+func TestCustomDomainService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	repo := repository.NewMockCustomDomainRepository()
+	repo.On("ListVerified", ctx).Return(map[string]uuid.UUID{"feedback.example.com": projectID}, nil).Once()
+	svc := NewCustomDomainService(repo, fakeResolver{})
+
+	got, ok := svc.ResolveHost(ctx, "Feedback.Example.com:443")
+	require.True(t, ok)
+	assert.Equal(t, projectID, got)
+
+	_, ok = svc.ResolveHost(ctx, "other.example.com")
+	assert.False(t, ok)
+
+	// Served from the cache
+	assert.True(t, svc.IsVerifiedOrigin(ctx, "https://feedback.example.com"))
+	assert.False(t, svc.IsVerifiedOrigin(ctx, "http://feedback.example.com"))
+	assert.False(t, svc.IsVerifiedOrigin(ctx, "https://evil.com"))
+	repo.AssertNumberOfCalls(t, "ListVerified", 1)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// staleExportJobError is recorded on export jobs the sweeper fails
+const staleExportJobError = "Export was interrupted; please start it again"
+
+// ExportJobSweeper fails background exports that can no longer finish.
+// Exports run inside the API process, so a restart or crash leaves their
+// jobs pending or running forever. Several API instances may run it; a job
+// is only failed once it has outlived the export timeout, so exports still
+// running on another instance are left alone.
+type ExportJobSweeper struct {
+	repo     repository.ExportRepository
+	maxAge   time.Duration
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewExportJobSweeper creates a new export job sweeper
+func NewExportJobSweeper(repo repository.ExportRepository) *ExportJobSweeper {
+	return &ExportJobSweeper{
+		repo:     repo,
+		maxAge:   exportJobTimeout,
+		interval: 5 * time.Minute,
+		now:      time.Now,
+	}
+}
+
+// Run sweeps on startup and then periodically until ctx is cancelled
+func (s *ExportJobSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to sweep stale export jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep fails unfinished jobs older than the export timeout and returns how
+// many it failed
+func (s *ExportJobSweeper) Sweep(ctx context.Context) (int64, error) {
+	failed, err := s.repo.FailStaleJobs(ctx, s.now().Add(-s.maxAge), staleExportJobError)
+	if err != nil {
+		return 0, err
+	}
+	if failed > 0 {
+		log.Warn().Int64("count", failed).Msg("Failed stale export jobs")
+	}
+	return failed, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+// exportFlushEvery controls how often buffered rows are pushed to the writer
+const exportFlushEvery = 500
+
+// exportJobTimeout bounds a background export; a job unfinished after that
+// long was lost with the instance running it
+const exportJobTimeout = 30 * time.Minute
+
+type exportService struct {
+	exportRepo     repository.ExportRepository
+	storage        storage.ObjectStorage
+	jobTimeout     time.Duration
+	downloadExpiry time.Duration
+}
+
+// NewExportService creates a new export service. Storage may be nil, in
+// which case only synchronous exports are available.
+func NewExportService(
+	exportRepo repository.ExportRepository,
+	storage storage.ObjectStorage,
+) ExportService {
+	return &exportService{
+		exportRepo:     exportRepo,
+		storage:        storage,
+		jobTimeout:     exportJobTimeout,
+		downloadExpiry: 1 * time.Hour,
+	}
+}
+
+func (s *exportService) Count(ctx context.Context, projectID uuid.UUID, filter domain.SavedViewFilter) (int, error) {
+	return s.exportRepo.Count(ctx, projectID, repository.FeedbackFilterFromView(filter))
+}
+
+func (s *exportService) Write(ctx context.Context, projectID uuid.UUID, filter domain.SavedViewFilter, format domain.ExportFormat, w io.Writer) (int, error) {
+	if !format.IsValid() {
+		return 0, domain.NewDomainError("INVALID_FORMAT", "Export format must be csv or ndjson", 400)
+	}
+
+	enc, err := newExportEncoder(format, w)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start export: %w", err)
+	}
+
+	count := 0
+	err = s.exportRepo.Stream(ctx, projectID, repository.FeedbackFilterFromView(filter), func(row *domain.ExportRow) error {
+		if err := enc.WriteRow(row); err != nil {
+			return fmt.Errorf("failed to write export row: %w", err)
+		}
+		count++
+		if count%exportFlushEvery == 0 {
+			return enc.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if err := enc.Flush(); err != nil {
+		return count, fmt.Errorf("failed to flush export: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *exportService) StartJob(ctx context.Context, projectID, requestedBy uuid.UUID, filter domain.SavedViewFilter, format domain.ExportFormat) (*domain.ExportJob, error) {
+	if !format.IsValid() {
+		return nil, domain.NewDomainError("INVALID_FORMAT", "Export format must be csv or ndjson", 400)
+	}
+	if s.storage == nil {
+		return nil, domain.NewDomainError("EXPORT_STORAGE_UNAVAILABLE", "Background exports are not available", 503)
+	}
+
+	job := &domain.ExportJob{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		RequestedBy: requestedBy,
+		Format:      format,
+		Filter:      filter,
+		Status:      domain.ExportJobPending,
+	}
+
+	if err := s.exportRepo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The job outlives the request, so it gets its own context
+	go s.runJob(*job)
+
+	return job, nil
+}
+
+func (s *exportService) runJob(job domain.ExportJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.jobTimeout)
+	defer cancel()
+
+	logger := log.With().
+		Str("export_job_id", job.ID.String()).
+		Str("project_id", job.ProjectID.String()).
+		Logger()
+
+	job.Status = domain.ExportJobRunning
+	if err := s.exportRepo.UpdateJob(ctx, &job); err != nil {
+		logger.Error().Err(err).Msg("Failed to mark export job as running")
+	}
+
+	path := fmt.Sprintf("projects/%s/exports/%s.%s", job.ProjectID, job.ID, job.Format)
+
+	// Rows are piped straight into storage so the export never sits in memory
+	pr, pw := io.Pipe()
+	written := make(chan int, 1)
+	go func() {
+		count, err := s.Write(ctx, job.ProjectID, job.Filter, job.Format, pw)
+		pw.CloseWithError(err)
+		written <- count
+	}()
+
+	err := s.storage.Upload(ctx, path, job.Format.ContentType(), pr)
+	if err != nil {
+		// Unblock the writer if the upload stopped reading early
+		pr.CloseWithError(err)
+	}
+	job.RowCount = <-written
+
+	now := time.Now()
+	job.CompletedAt = &now
+	if err != nil {
+		msg := err.Error()
+		job.Status = domain.ExportJobFailed
+		job.Error = &msg
+		logger.Error().Err(err).Msg("Export job failed")
+	} else {
+		job.Status = domain.ExportJobCompleted
+		job.StoragePath = &path
+		logger.Info().Int("row_count", job.RowCount).Msg("Export job completed")
+	}
+
+	if err := s.exportRepo.UpdateJob(ctx, &job); err != nil {
+		logger.Error().Err(err).Msg("Failed to record export job result")
+	}
+}
+
+func (s *exportService) GetJob(ctx context.Context, projectID, jobID uuid.UUID) (*domain.ExportJob, error) {
+	job, err := s.exportRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.ProjectID != projectID {
+		return nil, domain.ErrExportJobNotFound
+	}
+
+	if job.Status == domain.ExportJobCompleted && job.StoragePath != nil && s.storage != nil {
+		url, err := s.storage.GenerateDownloadURL(ctx, *job.StoragePath, s.downloadExpiry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate download URL: %w", err)
+		}
+		job.DownloadURL = &url
+	}
+
+	return job, nil
+}
+
+func (s *exportService) ListJobs(ctx context.Context, projectID uuid.UUID) ([]domain.ExportJob, error) {
+	return s.exportRepo.ListJobs(ctx, projectID, 50)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+// exportJobs streams fixed rows and records every job update
+type exportJobs struct {
+	repository.ExportRepository
+	rows      int
+	streamErr error
+	updates   []domain.ExportJob
+
+	staleBefore time.Time
+	staleReason string
+}
+
+func (f *exportJobs) Stream(_ context.Context, _ uuid.UUID, _ repository.FeedbackFilter, fn func(*domain.ExportRow) error) error {
+	for i := 0; i < f.rows; i++ {
+		if err := fn(&domain.ExportRow{ID: uuid.New(), Title: "Row"}); err != nil {
+			return err
+		}
+	}
+	return f.streamErr
+}
+
+func (f *exportJobs) UpdateJob(_ context.Context, job *domain.ExportJob) error {
+	f.updates = append(f.updates, *job)
+	return nil
+}
+
+func (f *exportJobs) FailStaleJobs(_ context.Context, createdBefore time.Time, reason string) (int64, error) {
+	f.staleBefore, f.staleReason = createdBefore, reason
+	return 2, nil
+}
+
+// exportUploads stores uploaded objects, or fails after reading readLimit
+// bytes when failAfterRead is set
+type exportUploads struct {
+	storage.ObjectStorage
+	objects       map[string]string
+	failAfterRead error
+	readLimit     int64
+}
+
+func (f *exportUploads) Upload(_ context.Context, path, _ string, r io.Reader) error {
+	if f.failAfterRead != nil {
+		_, _ = io.CopyN(io.Discard, r, f.readLimit)
+		return f.failAfterRead
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.objects[path] = string(body)
+	return nil
+}
+
+// runExportJob runs job to completion, failing the test if it hangs
+func runExportJob(t *testing.T, s *exportService, job domain.ExportJob) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		s.runJob(job)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("export job did not finish")
+	}
+}
+
+func TestExportService_RunJob(t *testing.T) {
+	newJob := func() domain.ExportJob {
+		return domain.ExportJob{ID: uuid.New(), ProjectID: uuid.New(), Format: domain.ExportFormatCSV, Status: domain.ExportJobPending}
+	}
+
+	t.Run("runs and completes with the stored file", func(t *testing.T) {
+		jobs := &exportJobs{rows: 3}
+		uploads := &exportUploads{objects: map[string]string{}}
+		job := newJob()
+
+		runExportJob(t, NewExportService(jobs, uploads).(*exportService), job)
+
+		require.Len(t, jobs.updates, 2)
+		assert.Equal(t, domain.ExportJobRunning, jobs.updates[0].Status)
+
+		done := jobs.updates[1]
+		assert.Equal(t, domain.ExportJobCompleted, done.Status)
+		assert.Equal(t, 3, done.RowCount)
+		assert.NotNil(t, done.CompletedAt)
+		assert.Nil(t, done.Error)
+		require.NotNil(t, done.StoragePath)
+		assert.Len(t, strings.Split(strings.TrimSpace(uploads.objects[*done.StoragePath]), "\n"), 4, "header and three rows")
+	})
+
+	t.Run("fails without a file when the upload stops early", func(t *testing.T) {
+		jobs := &exportJobs{rows: exportFlushEvery * 4}
+		uploads := &exportUploads{failAfterRead: errors.New("connection reset"), readLimit: 64}
+
+		runExportJob(t, NewExportService(jobs, uploads).(*exportService), newJob())
+
+		require.Len(t, jobs.updates, 2)
+		failed := jobs.updates[1]
+		assert.Equal(t, domain.ExportJobFailed, failed.Status)
+		require.NotNil(t, failed.Error)
+		assert.Contains(t, *failed.Error, "connection reset")
+		assert.Nil(t, failed.StoragePath)
+		assert.NotNil(t, failed.CompletedAt)
+	})
+
+	t.Run("fails when reading feedback fails", func(t *testing.T) {
+		jobs := &exportJobs{rows: 1, streamErr: errors.New("cursor closed")}
+		uploads := &exportUploads{objects: map[string]string{}}
+
+		runExportJob(t, NewExportService(jobs, uploads).(*exportService), newJob())
+
+		require.Len(t, jobs.updates, 2)
+		failed := jobs.updates[1]
+		assert.Equal(t, domain.ExportJobFailed, failed.Status)
+		require.NotNil(t, failed.Error)
+		assert.Contains(t, *failed.Error, "cursor closed")
+		assert.Empty(t, uploads.objects)
+	})
+}
+
+func TestExportService_StartJobWithoutStorage(t *testing.T) {
+	_, err := NewExportService(&exportJobs{}, nil).StartJob(context.Background(), uuid.New(), uuid.New(), domain.SavedViewFilter{}, domain.ExportFormatCSV)
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "EXPORT_STORAGE_UNAVAILABLE", domainErr.Code)
+}
+
+func TestExportJobSweeper_Sweep(t *testing.T) {
+	jobs := &exportJobs{}
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	sweeper := NewExportJobSweeper(jobs)
+	sweeper.now = func() time.Time { return now }
+
+	failed, err := sweeper.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), failed)
+	assert.Equal(t, now.Add(-exportJobTimeout), jobs.staleBefore, "jobs that may still be running elsewhere are kept")
+	assert.Equal(t, staleExportJobError, jobs.staleReason)
+}
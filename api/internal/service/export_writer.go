@@ -0,0 +1,136 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+// exportEncoder writes export rows in a specific file format
+type exportEncoder interface {
+	WriteRow(row *domain.ExportRow) error
+	Flush() error
+}
+
+func newExportEncoder(format domain.ExportFormat, w io.Writer) (exportEncoder, error) {
+	if format == domain.ExportFormatNDJSON {
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+	}
+
+	c := &csvEncoder{w: csv.NewWriter(w)}
+	if err := c.w.Write(csvExportHeader); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) WriteRow(row *domain.ExportRow) error {
+	return e.enc.Encode(row)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return nil
+}
+
+var csvExportHeader = []string{
+	"id", "title", "description", "type", "status", "severity", "visibility",
+	"tags", "vote_count", "comment_count", "assigned_to", "source",
+	"submitter_email", "submitter_name", "submitter_identifier",
+	"sdk_user_id", "sdk_user_external_id", "sdk_user_email", "sdk_user_name", "sdk_user_traits",
+	"metadata", "created_at", "updated_at", "resolved_at",
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) WriteRow(row *domain.ExportRow) error {
+	var severity string
+	if row.Severity != nil {
+		severity = string(*row.Severity)
+	}
+	var assignedTo, sdkUserID string
+	if row.AssignedTo != nil {
+		assignedTo = row.AssignedTo.String()
+	}
+	if row.SDKUserID != nil {
+		sdkUserID = row.SDKUserID.String()
+	}
+	var resolvedAt string
+	if row.ResolvedAt != nil {
+		resolvedAt = row.ResolvedAt.Format(time.RFC3339)
+	}
+
+	record := []string{
+		row.ID.String(),
+		row.Title,
+		row.Description,
+		string(row.Type),
+		string(row.Status),
+		severity,
+		string(row.Visibility),
+		strings.Join(row.Tags, ";"),
+		strconv.Itoa(row.VoteCount),
+		strconv.Itoa(row.CommentCount),
+		assignedTo,
+		derefString(row.Source),
+		derefString(row.SubmitterEmail),
+		derefString(row.SubmitterName),
+		derefString(row.SubmitterIdentifier),
+		sdkUserID,
+		derefString(row.SDKUserExternalID),
+		derefString(row.SDKUserEmail),
+		derefString(row.SDKUserName),
+		jsonCell(row.SDKUserTraits),
+		jsonCell(row.Metadata),
+		row.CreatedAt.Format(time.RFC3339),
+		row.UpdatedAt.Format(time.RFC3339),
+		resolvedAt,
+	}
+	for i, cell := range record {
+		record[i] = escapeCSVFormula(cell)
+	}
+	return e.w.Write(record)
+}
+
+// escapeCSVFormula stops spreadsheets from evaluating user-supplied text as
+// a formula by prefixing cells that start with a formula trigger
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// jsonCell renders nested key-value data as a JSON string inside a CSV cell
+func jsonCell(m map[string]interface{}) string {
+	if len(m) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func TestCSVEncoder_EscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := newExportEncoder(domain.ExportFormatCSV, &buf)
+	require.NoError(t, err)
+
+	name := "@SUM(A1:A2)"
+	require.NoError(t, enc.WriteRow(&domain.ExportRow{
+		ID:            uuid.New(),
+		Title:         `=HYPERLINK("http://evil.example","click")`,
+		Description:   "-2+3",
+		Tags:          []string{"+cmd"},
+		SubmitterName: &name,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}))
+	require.NoError(t, enc.Flush())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	row := records[1]
+	assert.Equal(t, `'=HYPERLINK("http://evil.example","click")`, row[1])
+	assert.Equal(t, "'-2+3", row[2])
+	assert.Equal(t, "'+cmd", row[7])
+	assert.Equal(t, "'@SUM(A1:A2)", row[13])
+	assert.Equal(t, "0", row[8], "plain cells are untouched")
+}
+
+func TestEscapeCSVFormula(t *testing.T) {
+	assert.Equal(t, "'\tcmd", escapeCSVFormula("\tcmd"))
+	assert.Equal(t, "'\rcmd", escapeCSVFormula("\rcmd"))
+	assert.Equal(t, "Login fails", escapeCSVFormula("Login fails"))
+	assert.Equal(t, "", escapeCSVFormula(""))
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func (s *importService) ImportCSV(ctx context.Context, projectID, importerID uuid.UUID, source string, mapping domain.ImportColumnMapping, r io.Reader, dryRun bool) (*domain.ImportResult, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, domain.NewDomainError("INVALID_MAPPING", err.Error(), 400)
+	}
+
+	items, parseErrs, err := parseImportCSV(r, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.importItems(ctx, projectID, importerID, domain.ImportRequest{Source: source, Items: items}, dryRun, parseErrs)
+}
+
+// parseImportCSV converts CSV rows into import items using the column
+// mapping. Each data row yields exactly one item; values that can't be
+// converted are reported in the matching parseErrs entry.
+func parseImportCSV(r io.Reader, mapping domain.ImportColumnMapping) ([]domain.ImportItem, [][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, domain.NewDomainError("EMPTY_CSV", "CSV file has no header row", 400)
+		}
+		return nil, nil, domain.NewDomainError("INVALID_CSV", fmt.Sprintf("Could not read CSV header: %v", err), 400)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	mapped := map[string]string{
+		"source_id":             mapping.SourceID,
+		"title":                 mapping.Title,
+		"description":           mapping.Description,
+		"type":                  mapping.Type,
+		"status":                mapping.Status,
+		"visibility":            mapping.Visibility,
+		"tags":                  mapping.Tags,
+		"vote_count":            mapping.VoteCount,
+		"submitter_external_id": mapping.SubmitterExternalID,
+		"submitter_email":       mapping.SubmitterEmail,
+		"submitter_name":        mapping.SubmitterName,
+		"created_at":            mapping.CreatedAt,
+	}
+	for field, column := range mapped {
+		if column == "" {
+			continue
+		}
+		if _, ok := columns[column]; !ok {
+			return nil, nil, domain.NewDomainError("INVALID_MAPPING", fmt.Sprintf("Column %q mapped to %s is not in the CSV header", column, field), 400)
+		}
+	}
+
+	separator := mapping.TagSeparator
+	if separator == "" {
+		separator = ","
+	}
+
+	var items []domain.ImportItem
+	var parseErrs [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if len(items) >= domain.MaxImportItems {
+			return nil, nil, domain.NewDomainError("IMPORT_TOO_LARGE", fmt.Sprintf("Imports are limited to %d items", domain.MaxImportItems), 400)
+		}
+
+		var rowErrs []string
+		if err != nil {
+			// Keep the row so numbering stays aligned with the file
+			items = append(items, domain.ImportItem{})
+			parseErrs = append(parseErrs, []string{fmt.Sprintf("malformed CSV row: %v", err)})
+			continue
+		}
+
+		get := func(column string) string {
+			if column == "" {
+				return ""
+			}
+			if i := columns[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		item := domain.ImportItem{
+			SourceID:    get(mapping.SourceID),
+			Title:       get(mapping.Title),
+			Description: get(mapping.Description),
+			Type:        domain.FeedbackType(strings.ToLower(get(mapping.Type))),
+			Status:      domain.FeedbackStatus(strings.ToLower(get(mapping.Status))),
+			Visibility:  domain.Visibility(strings.ToUpper(get(mapping.Visibility))),
+		}
+
+		if tags := get(mapping.Tags); tags != "" {
+			item.Tags = strings.Split(tags, separator)
+		}
+
+		if votes := get(mapping.VoteCount); votes != "" {
+			n, err := strconv.Atoi(votes)
+			if err != nil {
+				rowErrs = append(rowErrs, fmt.Sprintf("vote_count %q is not a whole number", votes))
+			}
+			item.VoteCount = n
+		}
+
+		if created := get(mapping.CreatedAt); created != "" {
+			t, err := parseImportTime(created)
+			if err != nil {
+				rowErrs = append(rowErrs, fmt.Sprintf("created_at %q is not a recognized date", created))
+			} else {
+				item.CreatedAt = &t
+			}
+		}
+
+		submitter := domain.ImportSubmitter{
+			ExternalID: get(mapping.SubmitterExternalID),
+			Email:      get(mapping.SubmitterEmail),
+			Name:       get(mapping.SubmitterName),
+		}
+		if submitter.ExternalID != "" || submitter.Email != "" {
+			item.Submitter = &submitter
+		}
+
+		items = append(items, item)
+		parseErrs = append(parseErrs, rowErrs)
+	}
+
+	return items, parseErrs, nil
+}
+
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format")
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+type importService struct {
+	importRepo  repository.ImportRepository
+	projectRepo repository.ProjectRepository
+}
+
+// NewImportService creates a new import service
+func NewImportService(
+	importRepo repository.ImportRepository,
+	projectRepo repository.ProjectRepository,
+) ImportService {
+	return &importService{
+		importRepo:  importRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *importService) Import(ctx context.Context, projectID, importerID uuid.UUID, req domain.ImportRequest, dryRun bool) (*domain.ImportResult, error) {
+	return s.importItems(ctx, projectID, importerID, req, dryRun, nil)
+}
+
+// importItems runs the import. parseErrs, when set, holds errors found while
+// converting each item from another format and is indexed like req.Items.
+func (s *importService) importItems(ctx context.Context, projectID, importerID uuid.UUID, req domain.ImportRequest, dryRun bool, parseErrs [][]string) (*domain.ImportResult, error) {
+	if err := domain.ValidateImportSource(req.Source); err != nil {
+		return nil, domain.NewDomainError("INVALID_SOURCE", err.Error(), 400)
+	}
+	if len(req.Items) > domain.MaxImportItems {
+		return nil, domain.NewDomainError("IMPORT_TOO_LARGE", fmt.Sprintf("Imports are limited to %d items", domain.MaxImportItems), 400)
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate everything up front so dry runs and real runs report the same errors
+	rows := make([]domain.ImportRowResult, len(req.Items))
+	seen := make(map[string]bool, len(req.Items))
+	var sourceIDs []string
+	for i := range req.Items {
+		item := &req.Items[i]
+		item.Normalize()
+		if item.Visibility == "" {
+			item.Visibility = project.Settings.GetDefaultVisibility(item.Type)
+		}
+
+		rows[i] = domain.ImportRowResult{Row: i + 1, SourceID: item.SourceID, Status: domain.ImportRowValid}
+		errs := item.Validate()
+		if parseErrs != nil {
+			errs = append(parseErrs[i], errs...)
+		}
+		if len(errs) > 0 {
+			rows[i].Status = domain.ImportRowInvalid
+			rows[i].Errors = errs
+			continue
+		}
+		if seen[item.SourceID] {
+			rows[i].Status = domain.ImportRowInvalid
+			rows[i].Errors = []string{"duplicate source_id in this import"}
+			continue
+		}
+		seen[item.SourceID] = true
+		sourceIDs = append(sourceIDs, item.SourceID)
+	}
+
+	existing, err := s.importRepo.FindImported(ctx, projectID, req.Source, sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range req.Items {
+		if rows[i].Status != domain.ImportRowValid {
+			continue
+		}
+		if id, ok := existing[req.Items[i].SourceID]; ok {
+			rows[i].Status = domain.ImportRowSkipped
+			rows[i].FeedbackID = &id
+			continue
+		}
+		if dryRun {
+			continue
+		}
+
+		id, created, err := s.importRepo.ImportItem(ctx, projectID, importerID, req.Source, &req.Items[i])
+		switch {
+		case err != nil:
+			rows[i].Status = domain.ImportRowFailed
+			rows[i].Errors = []string{err.Error()}
+		case created:
+			rows[i].Status = domain.ImportRowCreated
+			rows[i].FeedbackID = &id
+		default:
+			rows[i].Status = domain.ImportRowSkipped
+			rows[i].FeedbackID = &id
+		}
+	}
+
+	result := &domain.ImportResult{DryRun: dryRun}
+	for _, row := range rows {
+		result.Add(row)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// importedItems remembers items by source ID the way import_source_id does,
+// so running the same import twice hits the real skip paths
+type importedItems struct {
+	repository.ImportRepository
+	bySource map[string]uuid.UUID
+	imported []domain.ImportItem
+	failFor  string
+
+	// racedBy holds source IDs another import writes between FindImported
+	// and ImportItem
+	racedBy map[string]bool
+}
+
+func newImportedItems() *importedItems {
+	return &importedItems{bySource: map[string]uuid.UUID{}, racedBy: map[string]bool{}}
+}
+
+func (f *importedItems) FindImported(_ context.Context, _ uuid.UUID, source string, sourceIDs []string) (map[string]uuid.UUID, error) {
+	found := make(map[string]uuid.UUID)
+	for _, id := range sourceIDs {
+		if feedbackID, ok := f.bySource[source+"/"+id]; ok {
+			found[id] = feedbackID
+		}
+	}
+	for id := range f.racedBy {
+		f.bySource[source+"/"+id] = uuid.New()
+	}
+	return found, nil
+}
+
+func (f *importedItems) ImportItem(_ context.Context, _, _ uuid.UUID, source string, item *domain.ImportItem) (uuid.UUID, bool, error) {
+	if item.SourceID == f.failFor {
+		return uuid.Nil, false, errors.New("connection reset")
+	}
+	if id, ok := f.bySource[source+"/"+item.SourceID]; ok {
+		return id, false, nil
+	}
+	id := uuid.New()
+	f.bySource[source+"/"+item.SourceID] = id
+	f.imported = append(f.imported, *item)
+	return id, true, nil
+}
+
+func importProject(ctx context.Context) (*domain.Project, *repository.MockProjectRepository) {
+	project := &domain.Project{ID: uuid.New(), Settings: domain.DefaultProjectSettings()}
+	projects := repository.NewMockProjectRepository()
+	projects.On("GetByID", ctx, project.ID).Return(project, nil)
+	return project, projects
+}
+
+func importRequest() domain.ImportRequest {
+	return domain.ImportRequest{
+		Source: "canny",
+		Items: []domain.ImportItem{
+			{SourceID: "a1", Title: "Dark mode", Description: "Please add a dark theme"},
+			{SourceID: "a2", Title: "Export", Description: "CSV export", Type: domain.FeedbackTypeBug},
+			{SourceID: "a1", Title: "Dark mode again", Description: "Duplicate row"},
+			{SourceID: "a3", Title: "", Description: "Missing title"},
+		},
+	}
+}
+
+func statuses(result *domain.ImportResult) []domain.ImportRowStatus {
+	out := make([]domain.ImportRowStatus, len(result.Rows))
+	for i, row := range result.Rows {
+		out[i] = row.Status
+	}
+	return out
+}
+
+func TestImportService_Import(t *testing.T) {
+	ctx := context.Background()
+	importerID := uuid.New()
+
+	t.Run("creates valid rows and reports invalid ones", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+
+		result, err := NewImportService(repo, projects).Import(ctx, project.ID, importerID, importRequest(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.ImportRowStatus{
+			domain.ImportRowCreated, domain.ImportRowCreated, domain.ImportRowInvalid, domain.ImportRowInvalid,
+		}, statuses(result))
+		assert.Equal(t, 2, result.Created)
+		assert.Equal(t, 2, result.Invalid)
+		assert.Equal(t, []string{"duplicate source_id in this import"}, result.Rows[2].Errors)
+		assert.Contains(t, result.Rows[3].Errors, "title is required")
+
+		require.Len(t, repo.imported, 2)
+		// Visibility falls back to the project default for the item's type
+		assert.Equal(t, project.Settings.GetDefaultVisibility(domain.FeedbackTypeFeature), repo.imported[0].Visibility)
+		assert.Equal(t, domain.StatusNew, repo.imported[0].Status)
+	})
+
+	t.Run("re-running an import skips rows already imported", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+		svc := NewImportService(repo, projects)
+
+		first, err := svc.Import(ctx, project.ID, importerID, importRequest(), false)
+		require.NoError(t, err)
+
+		second, err := svc.Import(ctx, project.ID, importerID, importRequest(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, second.Created)
+		assert.Equal(t, 2, second.Skipped)
+		assert.Equal(t, first.Rows[0].FeedbackID, second.Rows[0].FeedbackID)
+		assert.Equal(t, first.Rows[1].FeedbackID, second.Rows[1].FeedbackID)
+		assert.Len(t, repo.imported, 2)
+	})
+
+	t.Run("an item imported concurrently is skipped", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+		repo.racedBy["a2"] = true
+
+		result, err := NewImportService(repo, projects).Import(ctx, project.ID, importerID, importRequest(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.ImportRowCreated, result.Rows[0].Status)
+		assert.Equal(t, domain.ImportRowSkipped, result.Rows[1].Status)
+		require.NotNil(t, result.Rows[1].FeedbackID)
+		assert.Equal(t, repo.bySource["canny/a2"], *result.Rows[1].FeedbackID)
+	})
+
+	t.Run("a write failure only fails its row", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+		repo.failFor = "a1"
+
+		result, err := NewImportService(repo, projects).Import(ctx, project.ID, importerID, importRequest(), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.ImportRowFailed, result.Rows[0].Status)
+		assert.Equal(t, []string{"connection reset"}, result.Rows[0].Errors)
+		assert.Equal(t, domain.ImportRowCreated, result.Rows[1].Status)
+		assert.Equal(t, 1, result.Failed)
+	})
+
+	t.Run("dry run validates without writing", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+		repo.bySource["canny/a2"] = uuid.New()
+
+		result, err := NewImportService(repo, projects).Import(ctx, project.ID, importerID, importRequest(), true)
+		require.NoError(t, err)
+
+		assert.True(t, result.DryRun)
+		assert.Equal(t, []domain.ImportRowStatus{
+			domain.ImportRowValid, domain.ImportRowSkipped, domain.ImportRowInvalid, domain.ImportRowInvalid,
+		}, statuses(result))
+		assert.Equal(t, 0, result.Created)
+		assert.Empty(t, repo.imported)
+	})
+
+	t.Run("rejects an invalid source", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		req := importRequest()
+		req.Source = "Not Valid"
+
+		_, err := NewImportService(newImportedItems(), projects).Import(ctx, project.ID, importerID, req, false)
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_SOURCE", domainErr.Code)
+	})
+}
+
+func TestImportService_ImportCSV(t *testing.T) {
+	ctx := context.Background()
+	importerID := uuid.New()
+	mapping := domain.ImportColumnMapping{
+		SourceID:       "ID",
+		Title:          "Title",
+		Description:    "Details",
+		Tags:           "Tags",
+		VoteCount:      "Votes",
+		SubmitterEmail: "Email",
+		CreatedAt:      "Created",
+		TagSeparator:   ";",
+	}
+	csvFile := "\ufeffID,Title,Details,Tags,Votes,Email,Created\n" +
+		"p1,Dark mode,Add a dark theme,ui; themes,12,Ada@Example.com,2024-01-31\n" +
+		"p2,Export,CSV export,,lots,,yesterday\n"
+
+	t.Run("maps columns onto items", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+
+		result, err := NewImportService(repo, projects).ImportCSV(ctx, project.ID, importerID, "csv", mapping, strings.NewReader(csvFile), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.ImportRowStatus{domain.ImportRowCreated, domain.ImportRowInvalid}, statuses(result))
+		assert.Equal(t, []string{
+			`vote_count "lots" is not a whole number`,
+			`created_at "yesterday" is not a recognized date`,
+		}, result.Rows[1].Errors)
+
+		require.Len(t, repo.imported, 1)
+		item := repo.imported[0]
+		assert.Equal(t, "p1", item.SourceID)
+		assert.Equal(t, []string{"ui", "themes"}, item.Tags)
+		assert.Equal(t, 12, item.VoteCount)
+		require.NotNil(t, item.Submitter)
+		assert.Equal(t, "ada@example.com", item.Submitter.Email)
+		require.NotNil(t, item.CreatedAt)
+		assert.Equal(t, "2024-01-31", item.CreatedAt.Format("2006-01-02"))
+	})
+
+	t.Run("re-importing the same file skips existing rows", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+		svc := NewImportService(repo, projects)
+
+		_, err := svc.ImportCSV(ctx, project.ID, importerID, "csv", mapping, strings.NewReader(csvFile), false)
+		require.NoError(t, err)
+		result, err := svc.ImportCSV(ctx, project.ID, importerID, "csv", mapping, strings.NewReader(csvFile), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.ImportRowStatus{domain.ImportRowSkipped, domain.ImportRowInvalid}, statuses(result))
+		assert.Len(t, repo.imported, 1)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		repo := newImportedItems()
+
+		result, err := NewImportService(repo, projects).ImportCSV(ctx, project.ID, importerID, "csv", mapping, strings.NewReader(csvFile), true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.ImportRowStatus{domain.ImportRowValid, domain.ImportRowInvalid}, statuses(result))
+		assert.Empty(t, repo.imported)
+	})
+
+	t.Run("rejects a mapping to a missing column", func(t *testing.T) {
+		project, projects := importProject(ctx)
+		bad := mapping
+		bad.Status = "State"
+
+		_, err := NewImportService(newImportedItems(), projects).ImportCSV(ctx, project.ID, importerID, "csv", bad, strings.NewReader(csvFile), false)
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_MAPPING", domainErr.Code)
+	})
+}
+
+func TestParseImportCSV(t *testing.T) {
+	mapping := domain.ImportColumnMapping{SourceID: "id", Title: "title", Description: "body"}
+
+	t.Run("keeps malformed rows aligned with the file", func(t *testing.T) {
+		items, parseErrs, err := parseImportCSV(strings.NewReader("id,title,body\n1,One,First\n2,\"Two,Second\n"), mapping)
+		require.NoError(t, err)
+
+		require.Len(t, items, 2)
+		require.Len(t, parseErrs, 2)
+		assert.Equal(t, "1", items[0].SourceID)
+		assert.Empty(t, parseErrs[0])
+		assert.Contains(t, parseErrs[1][0], "malformed CSV row")
+	})
+
+	t.Run("short rows leave missing columns empty", func(t *testing.T) {
+		items, _, err := parseImportCSV(strings.NewReader("id,title,body\n1,One\n"), mapping)
+		require.NoError(t, err)
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "One", items[0].Title)
+		assert.Empty(t, items[0].Description)
+	})
+
+	t.Run("an empty file is rejected", func(t *testing.T) {
+		_, _, err := parseImportCSV(strings.NewReader(""), mapping)
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "EMPTY_CSV", domainErr.Code)
+	})
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -60,6 +62,7 @@ type FeedbackListResult struct {
 type VoteResult struct {
 	FeedbackID uuid.UUID
 	VoteCount  int
+	VoteScore  int
 	HasVoted   bool
 }
 
@@ -73,8 +76,10 @@ type InviteMemberRequest struct {
 
 // CreateCommentRequest contains the data needed to create a comment
 type CreateCommentRequest struct {
+	ProjectID  uuid.UUID // The route's project; feedback elsewhere is not found
 	FeedbackID uuid.UUID
 	AuthorID   uuid.UUID
+	SDKUserID  *uuid.UUID // The portal user's linked SDK user, if any
 	ParentID   *uuid.UUID
 	Body       string
 	Visibility domain.Visibility
@@ -99,10 +104,14 @@ type FeedbackService interface {
 
 // VoteService defines the business logic interface for votes
 type VoteService interface {
-	Vote(ctx context.Context, projectID, feedbackID, userID uuid.UUID) (*VoteResult, error)
-	Unvote(ctx context.Context, projectID, feedbackID, userID uuid.UUID) (*VoteResult, error)
-	HasVoted(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error)
-	EnrichWithVoteStatus(ctx context.Context, feedbacks []domain.Feedback, userID uuid.UUID) error
+	Vote(ctx context.Context, projectID, feedbackID uuid.UUID, voter domain.Voter, details domain.VoteDetails) (*VoteResult, error)
+	// RecordVote adds a vote a team member took on behalf of a customer
+	RecordVote(ctx context.Context, projectID, feedbackID uuid.UUID, customer domain.VoteCustomer, details domain.VoteDetails, recordedBy uuid.UUID) (*VoteResult, error)
+	Unvote(ctx context.Context, projectID, feedbackID uuid.UUID, voter domain.Voter) (*VoteResult, error)
+	// Budget returns the voter's active votes against the project's budget
+	Budget(ctx context.Context, projectID uuid.UUID, voter domain.Voter) (*domain.VoteBudgetUsage, error)
+	HasVoted(ctx context.Context, feedbackID uuid.UUID, voter domain.Voter) (bool, error)
+	EnrichWithVoteStatus(ctx context.Context, feedbacks []domain.Feedback, voter domain.Voter) error
 }
 
 // CommentService defines the business logic interface for comments
@@ -155,6 +164,112 @@ type AttachmentService interface {
 	Delete(ctx context.Context, attachmentID uuid.UUID, actorID uuid.UUID) error
 }
 
+// ExportService defines the business logic interface for feedback exports
+type ExportService interface {
+	Count(ctx context.Context, projectID uuid.UUID, filter domain.SavedViewFilter) (int, error)
+	Write(ctx context.Context, projectID uuid.UUID, filter domain.SavedViewFilter, format domain.ExportFormat, w io.Writer) (int, error)
+	StartJob(ctx context.Context, projectID, requestedBy uuid.UUID, filter domain.SavedViewFilter, format domain.ExportFormat) (*domain.ExportJob, error)
+	GetJob(ctx context.Context, projectID, jobID uuid.UUID) (*domain.ExportJob, error)
+	ListJobs(ctx context.Context, projectID uuid.UUID) ([]domain.ExportJob, error)
+}
+
+// ImportService defines the business logic interface for bulk feedback imports
+type ImportService interface {
+	Import(ctx context.Context, projectID, importerID uuid.UUID, req domain.ImportRequest, dryRun bool) (*domain.ImportResult, error)
+	ImportCSV(ctx context.Context, projectID, importerID uuid.UUID, source string, mapping domain.ImportColumnMapping, r io.Reader, dryRun bool) (*domain.ImportResult, error)
+}
+
+// ProjectLifecycleService archives, restores, deletes and transfers projects
+type ProjectLifecycleService interface {
+	Archive(ctx context.Context, projectID uuid.UUID) (*domain.Project, error)
+	// Restore unarchives a project, cancelling its scheduled deletion
+	Restore(ctx context.Context, projectID uuid.UUID) (*domain.Project, error)
+	// Delete archives the project and schedules its purge after the grace period
+	Delete(ctx context.Context, projectID uuid.UUID) (*domain.Project, error)
+
+	// RequestTransfer proposes handing ownership to another team member
+	RequestTransfer(ctx context.Context, projectID, fromUserID, toUserID uuid.UUID) (*domain.ProjectTransfer, error)
+	GetPendingTransfer(ctx context.Context, projectID uuid.UUID) (*domain.ProjectTransfer, error)
+	CancelTransfer(ctx context.Context, projectID uuid.UUID) error
+	// AcceptTransfer completes the pending transfer; only its recipient may
+	AcceptTransfer(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectTransfer, error)
+}
+
+// BrandingService manages project branding and the public portal config
+type BrandingService interface {
+	Get(ctx context.Context, projectID uuid.UUID) (*domain.PublicPortalConfig, error)
+	Update(ctx context.Context, projectID uuid.UUID, req domain.UpdateBrandingRequest) (*domain.PublicPortalConfig, error)
+	// InitiateLogoUpload returns a signed URL the logo is uploaded to
+	InitiateLogoUpload(ctx context.Context, projectID uuid.UUID, req domain.LogoUploadRequest) (*LogoUploadInfo, error)
+	// CompleteLogoUpload makes an uploaded file the project's logo
+	CompleteLogoUpload(ctx context.Context, projectID uuid.UUID, path string) (*domain.PublicPortalConfig, error)
+	RemoveLogo(ctx context.Context, projectID uuid.UUID) (*domain.PublicPortalConfig, error)
+	// GetPublicConfig returns the config of an active project by slug
+	GetPublicConfig(ctx context.Context, slug string) (*domain.PublicPortalConfig, error)
+	// GetPublicConfigByID returns the config of an active project by ID
+	GetPublicConfigByID(ctx context.Context, projectID uuid.UUID) (*domain.PublicPortalConfig, error)
+}
+
+// CustomDomainService registers and verifies project custom domains and
+// resolves requests made to them
+type CustomDomainService interface {
+	Get(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error)
+	// Register sets the project's domain; it must be verified before use
+	Register(ctx context.Context, projectID uuid.UUID, hostname string) (*domain.CustomDomain, error)
+	// Verify checks the domain's DNS TXT record
+	Verify(ctx context.Context, projectID uuid.UUID) (*domain.CustomDomain, error)
+	Remove(ctx context.Context, projectID uuid.UUID) error
+	// ResolveHost returns the project a verified hostname belongs to
+	ResolveHost(ctx context.Context, host string) (uuid.UUID, bool)
+	// IsVerifiedOrigin reports whether origin is https on a verified domain
+	IsVerifiedOrigin(ctx context.Context, origin string) bool
+}
+
+// OrganizationService manages organizations, their members and limits
+type OrganizationService interface {
+	// Create creates an organization owned by ownerID
+	Create(ctx context.Context, ownerID uuid.UUID, req domain.CreateOrganizationRequest) (*domain.Organization, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.OrganizationDetail, error)
+	Update(ctx context.Context, id uuid.UUID, req domain.UpdateOrganizationRequest) (*domain.Organization, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]domain.OrganizationWithRole, error)
+	ListProjects(ctx context.Context, id uuid.UUID) ([]domain.Project, error)
+
+	// Member management; actor is the caller's own organization membership
+	ListMembers(ctx context.Context, id uuid.UUID) ([]domain.OrganizationMember, error)
+	AddMember(ctx context.Context, actor *domain.OrganizationMember, req domain.OrganizationMemberRequest) (*domain.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, actor *domain.OrganizationMember, userID uuid.UUID, role domain.Role) (*domain.OrganizationMember, error)
+	RemoveMember(ctx context.Context, actor *domain.OrganizationMember, userID uuid.UUID) error
+
+	// Search searches feedback across the organization's projects
+	Search(ctx context.Context, id uuid.UUID, query string) ([]domain.OrganizationSearchResult, error)
+}
+
+// ProjectRoleService manages custom project roles and their assignment
+type ProjectRoleService interface {
+	// List returns the built-in roles' default permissions and the
+	// project's custom roles
+	List(ctx context.Context, projectID uuid.UUID) (*domain.ProjectRolesResponse, error)
+	// Create, Update and Assign act in the actor's project and can't grant
+	// permissions the actor lacks
+	Create(ctx context.Context, actor *domain.Membership, req domain.ProjectRoleRequest) (*domain.ProjectRole, error)
+	Update(ctx context.Context, actor *domain.Membership, roleID uuid.UUID, req domain.ProjectRoleRequest) (*domain.ProjectRole, error)
+	Delete(ctx context.Context, projectID, roleID uuid.UUID) error
+	Assign(ctx context.Context, actor *domain.Membership, membershipID uuid.UUID, req domain.AssignProjectRoleRequest) (*domain.Membership, error)
+}
+
+// MembershipResolver resolves a user's effective membership in a project,
+// including the role inherited from its organization
+type MembershipResolver interface {
+	GetByProjectAndUser(ctx context.Context, projectID, userID string) (*domain.Membership, error)
+}
+
+// LogoUploadInfo contains signed URL info for uploading a logo
+type LogoUploadInfo struct {
+	Path      string    `json:"path"`
+	UploadURL string    `json:"upload_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // UploadInfo contains signed URL info for uploading
 type UploadInfo struct {
 	AttachmentID uuid.UUID
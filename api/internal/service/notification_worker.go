@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// NotificationWorker fans notification events out to their recipients,
+// storing in-app notifications and queueing emails for each.
+// Several API instances may run it; events are leased to one at a time.
+type NotificationWorker struct {
+	repo      repository.NotificationRepository
+	interval  time.Duration
+	batchSize int
+	lease     time.Duration
+}
+
+// NewNotificationWorker creates a new notification worker
+func NewNotificationWorker(repo repository.NotificationRepository) *NotificationWorker {
+	return &NotificationWorker{
+		repo:      repo,
+		interval:  10 * time.Second,
+		batchSize: 50,
+		lease:     5 * time.Minute,
+	}
+}
+
+// Run processes pending events until ctx is cancelled
+func (w *NotificationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.ProcessPending(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to process notification events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessPending fans out one batch of pending events and returns how many
+// were completed. Failed events are retried once their lease runs out.
+func (w *NotificationWorker) ProcessPending(ctx context.Context) (int, error) {
+	events, err := w.repo.ClaimEvents(ctx, w.batchSize, w.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for i := range events {
+		event := &events[i]
+
+		if err := w.fanOut(ctx, event); err != nil {
+			log.Error().Err(err).
+				Str("event_id", event.ID.String()).
+				Int("attempt", event.Attempts).
+				Msg("Failed to fan out notification event")
+			continue
+		}
+		completed++
+	}
+
+	return completed, nil
+}
+
+// fanOut builds an event's notifications and completes it. Mentions and
+// assignments go to the member they name, new feedback to the owners of
+// matching saved views, and other events to the item's subscribers.
+func (w *NotificationWorker) fanOut(ctx context.Context, event *domain.NotificationEvent) error {
+	var inbox []domain.Notification
+	var emails []domain.NotificationQueueEntry
+
+	switch event.EventType {
+	case domain.EventMention, domain.EventAssigned:
+		userID, err := directRecipientID(event)
+		if err != nil {
+			return err
+		}
+		recipient, err := w.repo.GetMemberRecipient(ctx, event.ProjectID, userID)
+		if err != nil {
+			return err
+		}
+		inbox, emails = buildMemberNotifications(event, notificationTypeFor(event), recipient, nil)
+
+	case domain.EventNewFeedback:
+		watchers, err := w.repo.ListViewWatchers(ctx, event)
+		if err != nil {
+			return err
+		}
+		notified := make(map[uuid.UUID]bool)
+		for _, watcher := range watchers {
+			if notified[watcher.UserID] {
+				continue
+			}
+			notified[watcher.UserID] = true
+
+			extra := map[string]interface{}{"view_id": watcher.ViewID, "view_name": watcher.ViewName}
+			i, e := buildMemberNotifications(event, notificationTypeFor(event), &watcher.MemberRecipient, extra)
+			inbox = append(inbox, i...)
+			emails = append(emails, e...)
+		}
+
+	default:
+		subscribers, err := w.repo.ListSubscribers(ctx, event)
+		if err != nil {
+			return err
+		}
+		inbox, emails = buildNotifications(event, subscribers)
+	}
+
+	return w.repo.CompleteEvent(ctx, event.ID, inbox, emails)
+}
+
+// directRecipientID returns the member a mention or assignment event names
+func directRecipientID(event *domain.NotificationEvent) (uuid.UUID, error) {
+	key := "mentioned_user_id"
+	if event.EventType == domain.EventAssigned {
+		key = "assignee_id"
+	}
+
+	userID, err := uuid.Parse(fmt.Sprint(event.Payload[key]))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s event payload: %w", event.EventType, err)
+	}
+	return userID, nil
+}
+
+// buildMemberNotifications returns the in-app and email notifications for
+// an event addressed to one member, as their preferences allow. Members who
+// can't see the change, or made it themselves, get neither.
+func buildMemberNotifications(
+	event *domain.NotificationEvent,
+	notificationType string,
+	recipient *domain.MemberRecipient,
+	extra map[string]interface{},
+) ([]domain.Notification, []domain.NotificationQueueEntry) {
+	if !recipient.CanSee(event) {
+		return nil, nil
+	}
+	if event.ActorUserID != nil && *event.ActorUserID == recipient.UserID {
+		return nil, nil
+	}
+
+	payload := notificationPayload(event, extra)
+	payload["actor_user_id"] = event.ActorUserID
+
+	var inbox []domain.Notification
+	if recipient.Preferences.InboxFor(notificationType) {
+		inbox = append(inbox, inboxNotification(event, recipient.UserID, notificationType, payload))
+	}
+
+	var emails []domain.NotificationQueueEntry
+	if recipient.Preferences.EmailsFor(notificationType) {
+		emails = append(emails, domain.NotificationQueueEntry{
+			UserID:           &recipient.UserID,
+			ProjectID:        event.ProjectID,
+			EventID:          &event.ID,
+			NotificationType: notificationType,
+			Payload:          payload,
+		})
+	}
+
+	return inbox, emails
+}
+
+// buildNotifications returns the notifications for an event on a followed
+// item: for each subscriber who can see the change, except the one who made
+// it, an inbox entry and an email as their preferences allow
+func buildNotifications(event *domain.NotificationEvent, subscribers []domain.Subscriber) ([]domain.Notification, []domain.NotificationQueueEntry) {
+	notificationType := notificationTypeFor(event)
+	if notificationType == "" {
+		return nil, nil
+	}
+
+	var inbox []domain.Notification
+	var emails []domain.NotificationQueueEntry
+	for _, s := range collapseSubscribers(subscribers) {
+		if s.Muted {
+			continue
+		}
+		if s.UserID != nil && event.ActorUserID != nil && *s.UserID == *event.ActorUserID {
+			continue
+		}
+		if !s.CanSee(event) {
+			continue
+		}
+
+		payload := notificationPayload(event, map[string]interface{}{"reason": s.Reason})
+
+		if s.WantsInbox(notificationType) {
+			inbox = append(inbox, inboxNotification(event, *s.UserID, notificationType, payload))
+		}
+		if s.Wants(notificationType) {
+			emails = append(emails, domain.NotificationQueueEntry{
+				UserID:           s.UserID,
+				SDKUserID:        s.SDKUserID,
+				ProjectID:        event.ProjectID,
+				EventID:          &event.ID,
+				NotificationType: notificationType,
+				Payload:          payload,
+			})
+		}
+	}
+
+	return inbox, emails
+}
+
+// notificationPayload returns the item details, extra fields and the
+// event's own payload
+func notificationPayload(event *domain.NotificationEvent, extra map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"feedback_id":    event.FeedbackID,
+		"feedback_title": event.FeedbackTitle,
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	for k, v := range event.Payload {
+		payload[k] = v
+	}
+	return payload
+}
+
+func inboxNotification(event *domain.NotificationEvent, userID uuid.UUID, notificationType string, payload map[string]interface{}) domain.Notification {
+	return domain.Notification{
+		UserID:           userID,
+		ProjectID:        event.ProjectID,
+		FeedbackID:       &event.FeedbackID,
+		EventID:          &event.ID,
+		NotificationType: notificationType,
+		Payload:          payload,
+	}
+}
+
+// notificationTypeFor maps an event to the notification type it queues
+func notificationTypeFor(event *domain.NotificationEvent) string {
+	switch event.EventType {
+	case domain.EventNewComment:
+		return domain.NotificationTypeNewComment
+	case domain.EventMention:
+		return domain.NotificationTypeMention
+	case domain.EventAssigned:
+		return domain.NotificationTypeAssigned
+	case domain.EventNewFeedback:
+		return domain.NotificationTypeSavedViewMatch
+	case domain.EventStatusChanged:
+		if to, _ := event.Payload["to"].(string); domain.FeedbackStatus(to).IsResolved() {
+			return domain.NotificationTypeFeedbackResolved
+		}
+		return domain.NotificationTypeStatusChanged
+	}
+	return ""
+}
+
+// subscriptionReasonRank orders reasons from the one that always notifies
+// to the one most restricted by preferences
+var subscriptionReasonRank = map[domain.SubscriptionReason]int{
+	domain.SubscriptionManual:    0,
+	domain.SubscriptionCommented: 1,
+	domain.SubscriptionSubmitted: 2,
+	domain.SubscriptionVoted:     3,
+}
+
+// collapseSubscribers merges the rows of recipients who follow an item
+// through several linked identities. A recipient is muted only if every
+// row is muted, and keeps the reason that notifies most.
+func collapseSubscribers(subscribers []domain.Subscriber) []domain.Subscriber {
+	var merged []domain.Subscriber
+	index := make(map[string]int)
+
+	for _, s := range subscribers {
+		var key string
+		switch {
+		case s.UserID != nil:
+			key = "user:" + s.UserID.String()
+		case s.SDKUserID != nil:
+			key = "sdk:" + s.SDKUserID.String()
+		default:
+			continue
+		}
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, s)
+			continue
+		}
+
+		m := &merged[i]
+		if !s.Muted && (m.Muted || subscriptionReasonRank[s.Reason] < subscriptionReasonRank[m.Reason]) {
+			m.Reason = s.Reason
+		}
+		m.Muted = m.Muted && s.Muted
+		m.IsTeam = m.IsTeam || s.IsTeam
+		m.IsSubmitter = m.IsSubmitter || s.IsSubmitter
+	}
+
+	return merged
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+)
+
+func subscriber(reason domain.SubscriptionReason, mutate func(*domain.Subscriber)) domain.Subscriber {
+	id := uuid.New()
+	s := domain.Subscriber{
+		UserID:      &id,
+		Reason:      reason,
+		Preferences: domain.DefaultPortalNotificationPreferences(),
+	}
+	if mutate != nil {
+		mutate(&s)
+	}
+	return s
+}
+
+func commentEvent(visibility, feedbackVisibility domain.Visibility, actor *uuid.UUID) *domain.NotificationEvent {
+	return &domain.NotificationEvent{
+		ID:                 uuid.New(),
+		ProjectID:          uuid.New(),
+		FeedbackID:         uuid.New(),
+		FeedbackTitle:      "Dark mode",
+		FeedbackVisibility: feedbackVisibility,
+		EventType:          domain.EventNewComment,
+		ActorUserID:        actor,
+		Visibility:         visibility,
+		Payload:            map[string]interface{}{"comment_id": uuid.NewString()},
+	}
+}
+
+func TestBuildNotifications(t *testing.T) {
+	t.Run("skips the actor and muted subscribers", func(t *testing.T) {
+		actor := subscriber(domain.SubscriptionCommented, nil)
+		muted := subscriber(domain.SubscriptionManual, func(s *domain.Subscriber) { s.Muted = true })
+		follower := subscriber(domain.SubscriptionManual, nil)
+		event := commentEvent(domain.VisibilityCommunity, domain.VisibilityCommunity, actor.UserID)
+
+		_, notifications := buildNotifications(event, []domain.Subscriber{actor, muted, follower})
+
+		require.Len(t, notifications, 1)
+		assert.Equal(t, follower.UserID, notifications[0].UserID)
+		assert.Equal(t, domain.NotificationTypeNewComment, notifications[0].NotificationType)
+		assert.Equal(t, "Dark mode", notifications[0].Payload["feedback_title"])
+		assert.Equal(t, event.Payload["comment_id"], notifications[0].Payload["comment_id"])
+	})
+
+	t.Run("team-only comments reach team members only", func(t *testing.T) {
+		team := subscriber(domain.SubscriptionManual, func(s *domain.Subscriber) { s.IsTeam = true })
+		portal := subscriber(domain.SubscriptionManual, nil)
+		event := commentEvent(domain.VisibilityTeamOnly, domain.VisibilityCommunity, nil)
+
+		_, notifications := buildNotifications(event, []domain.Subscriber{team, portal})
+
+		require.Len(t, notifications, 1)
+		assert.Equal(t, team.UserID, notifications[0].UserID)
+	})
+
+	t.Run("team-only items reach their submitter", func(t *testing.T) {
+		submitter := subscriber(domain.SubscriptionSubmitted, func(s *domain.Subscriber) { s.IsSubmitter = true })
+		other := subscriber(domain.SubscriptionManual, nil)
+		event := commentEvent(domain.VisibilityCommunity, domain.VisibilityTeamOnly, nil)
+
+		_, notifications := buildNotifications(event, []domain.Subscriber{submitter, other})
+
+		require.Len(t, notifications, 1)
+		assert.Equal(t, submitter.UserID, notifications[0].UserID)
+	})
+
+	t.Run("preferences apply to automatic subscriptions", func(t *testing.T) {
+		voter := subscriber(domain.SubscriptionVoted, nil) // new_comments_on_voted_feedback is off by default
+		optedIn := subscriber(domain.SubscriptionVoted, func(s *domain.Subscriber) {
+			s.Preferences.NewCommentsOnVotedFeedback = true
+		})
+		event := commentEvent(domain.VisibilityCommunity, domain.VisibilityCommunity, nil)
+
+		_, notifications := buildNotifications(event, []domain.Subscriber{voter, optedIn})
+
+		require.Len(t, notifications, 1)
+		assert.Equal(t, optedIn.UserID, notifications[0].UserID)
+	})
+
+	t.Run("resolving statuses queue feedback_resolved", func(t *testing.T) {
+		follower := subscriber(domain.SubscriptionManual, nil)
+		event := commentEvent(domain.VisibilityCommunity, domain.VisibilityCommunity, nil)
+		event.EventType = domain.EventStatusChanged
+		event.Payload = map[string]interface{}{"from": "planned", "to": "completed"}
+
+		_, notifications := buildNotifications(event, []domain.Subscriber{follower})
+
+		require.Len(t, notifications, 1)
+		assert.Equal(t, domain.NotificationTypeFeedbackResolved, notifications[0].NotificationType)
+	})
+}
+
+func TestCollapseSubscribers(t *testing.T) {
+	userID := uuid.New()
+	viaSDK := domain.Subscriber{UserID: &userID, Reason: domain.SubscriptionVoted}
+	viaPortal := domain.Subscriber{UserID: &userID, Reason: domain.SubscriptionManual, Muted: true}
+
+	t.Run("muted only if every linked subscription is muted", func(t *testing.T) {
+		merged := collapseSubscribers([]domain.Subscriber{viaPortal, viaSDK})
+
+		require.Len(t, merged, 1)
+		assert.False(t, merged[0].Muted)
+		assert.Equal(t, domain.SubscriptionVoted, merged[0].Reason)
+	})
+
+	t.Run("keeps the reason that notifies most", func(t *testing.T) {
+		manual := viaPortal
+		manual.Muted = false
+
+		merged := collapseSubscribers([]domain.Subscriber{viaSDK, manual})
+
+		require.Len(t, merged, 1)
+		assert.Equal(t, domain.SubscriptionManual, merged[0].Reason)
+	})
+}
+
+func TestBuildNotificationsInbox(t *testing.T) {
+	statusEvent := func(feedbackVisibility domain.Visibility) *domain.NotificationEvent {
+		event := commentEvent(domain.VisibilityCommunity, feedbackVisibility, nil)
+		event.EventType = domain.EventStatusChanged
+		event.Payload = map[string]interface{}{"from": "new", "to": "planned"}
+		return event
+	}
+
+	t.Run("status changes reach portal and team inboxes", func(t *testing.T) {
+		portal := subscriber(domain.SubscriptionVoted, nil)
+		team := subscriber(domain.SubscriptionManual, func(s *domain.Subscriber) {
+			s.IsTeam = true
+			s.TeamPreferences = domain.DefaultNotificationPreferences()
+		})
+
+		inbox, _ := buildNotifications(statusEvent(domain.VisibilityCommunity), []domain.Subscriber{portal, team})
+
+		require.Len(t, inbox, 2)
+		assert.Equal(t, *portal.UserID, inbox[0].UserID)
+		assert.Equal(t, *team.UserID, inbox[1].UserID)
+		assert.Equal(t, domain.NotificationTypeStatusChanged, inbox[0].NotificationType)
+	})
+
+	t.Run("team members choose inbox and email separately", func(t *testing.T) {
+		team := subscriber(domain.SubscriptionManual, func(s *domain.Subscriber) {
+			s.IsTeam = true
+			s.TeamPreferences = domain.DefaultNotificationPreferences()
+			s.TeamPreferences.StatusChanges = false
+		})
+
+		inbox, emails := buildNotifications(statusEvent(domain.VisibilityCommunity), []domain.Subscriber{team})
+
+		assert.Len(t, inbox, 1)
+		assert.Empty(t, emails)
+	})
+
+	t.Run("comments and SDK users stay out of the inbox", func(t *testing.T) {
+		sdkUserID := uuid.New()
+		sdkUser := domain.Subscriber{SDKUserID: &sdkUserID, Reason: domain.SubscriptionManual, Preferences: domain.DefaultPortalNotificationPreferences()}
+		follower := subscriber(domain.SubscriptionManual, nil)
+
+		inbox, emails := buildNotifications(statusEvent(domain.VisibilityCommunity), []domain.Subscriber{sdkUser})
+		assert.Empty(t, inbox)
+		assert.Len(t, emails, 1)
+
+		inbox, emails = buildNotifications(commentEvent(domain.VisibilityCommunity, domain.VisibilityCommunity, nil), []domain.Subscriber{follower})
+		assert.Empty(t, inbox)
+		assert.Len(t, emails, 1)
+	})
+}
+
+func TestBuildMemberNotifications(t *testing.T) {
+	member := func(role domain.Role, mutate func(*domain.NotificationPreferences)) *domain.MemberRecipient {
+		prefs := domain.DefaultNotificationPreferences()
+		if mutate != nil {
+			mutate(&prefs)
+		}
+		return &domain.MemberRecipient{UserID: uuid.New(), Role: &role, Preferences: prefs}
+	}
+	event := func(eventType string, visibility domain.Visibility) *domain.NotificationEvent {
+		e := commentEvent(visibility, domain.VisibilityCommunity, nil)
+		e.EventType = eventType
+		return e
+	}
+
+	t.Run("mentions reach the inbox and email by default", func(t *testing.T) {
+		recipient := member(domain.RoleMember, nil)
+
+		inbox, emails := buildMemberNotifications(event(domain.EventMention, domain.VisibilityTeamOnly), domain.NotificationTypeMention, recipient, nil)
+
+		require.Len(t, inbox, 1)
+		assert.Equal(t, recipient.UserID, inbox[0].UserID)
+		assert.Equal(t, domain.NotificationTypeMention, inbox[0].NotificationType)
+		require.Len(t, emails, 1)
+		assert.Equal(t, &recipient.UserID, emails[0].UserID)
+	})
+
+	t.Run("inbox only when emails for the type are off", func(t *testing.T) {
+		recipient := member(domain.RoleMember, func(p *domain.NotificationPreferences) { p.Mentions = false })
+
+		inbox, emails := buildMemberNotifications(event(domain.EventMention, domain.VisibilityTeamOnly), domain.NotificationTypeMention, recipient, nil)
+
+		assert.Len(t, inbox, 1)
+		assert.Empty(t, emails)
+	})
+
+	t.Run("saved view matches carry the view and skip email by default", func(t *testing.T) {
+		recipient := member(domain.RoleViewer, nil)
+		viewID := uuid.New()
+
+		inbox, emails := buildMemberNotifications(
+			event(domain.EventNewFeedback, domain.VisibilityCommunity), domain.NotificationTypeSavedViewMatch,
+			recipient, map[string]interface{}{"view_id": viewID, "view_name": "Bugs"},
+		)
+
+		require.Len(t, inbox, 1)
+		assert.Equal(t, viewID, inbox[0].Payload["view_id"])
+		assert.Empty(t, emails)
+	})
+
+	t.Run("nothing for members who can't see the change or made it", func(t *testing.T) {
+		inbox, emails := buildMemberNotifications(event(domain.EventMention, domain.VisibilityTeamOnly), domain.NotificationTypeMention, member(domain.RoleCommunity, nil), nil)
+		assert.Empty(t, inbox)
+		assert.Empty(t, emails)
+
+		former := &domain.MemberRecipient{UserID: uuid.New(), Preferences: domain.DefaultNotificationPreferences()}
+		inbox, emails = buildMemberNotifications(event(domain.EventAssigned, domain.VisibilityTeamOnly), domain.NotificationTypeAssigned, former, nil)
+		assert.Empty(t, inbox)
+		assert.Empty(t, emails)
+
+		self := member(domain.RoleMember, nil)
+		e := event(domain.EventNewFeedback, domain.VisibilityCommunity)
+		e.ActorUserID = &self.UserID
+		inbox, emails = buildMemberNotifications(e, domain.NotificationTypeSavedViewMatch, self, nil)
+		assert.Empty(t, inbox)
+		assert.Empty(t, emails)
+	})
+}
+
+func TestDirectRecipientID(t *testing.T) {
+	assignee := uuid.New()
+	event := &domain.NotificationEvent{
+		EventType: domain.EventAssigned,
+		Payload:   map[string]interface{}{"assignee_id": assignee.String()},
+	}
+
+	got, err := directRecipientID(event)
+	require.NoError(t, err)
+	assert.Equal(t, assignee, got)
+
+	event.Payload = map[string]interface{}{}
+	_, err = directRecipientID(event)
+	assert.Error(t, err)
+}
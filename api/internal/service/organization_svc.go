@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// Cross-project search bounds
+const (
+	minOrganizationSearchLength = 2
+	organizationSearchLimit     = 50
+)
+
+type organizationService struct {
+	repo        repository.OrganizationRepository
+	maxProjects int
+	maxMembers  int
+}
+
+// NewOrganizationService creates a new organization service. New
+// organizations get maxProjects and maxMembers as limits; 0 means unlimited.
+func NewOrganizationService(repo repository.OrganizationRepository, maxProjects, maxMembers int) OrganizationService {
+	return &organizationService{
+		repo:        repo,
+		maxProjects: maxProjects,
+		maxMembers:  maxMembers,
+	}
+}
+
+func (s *organizationService) Create(ctx context.Context, ownerID uuid.UUID, req domain.CreateOrganizationRequest) (*domain.Organization, error) {
+	org := &domain.Organization{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Slug:        generateSlug(req.Name),
+		MaxProjects: limit(s.maxProjects),
+		MaxMembers:  limit(s.maxMembers),
+	}
+	if org.Slug == "" {
+		org.Slug = "org"
+	}
+
+	err := s.repo.Create(ctx, org, ownerID)
+	if err == domain.ErrSlugTaken {
+		// Append random suffix
+		suffix := make([]byte, 4)
+		rand.Read(suffix)
+		org.Slug = org.Slug + "-" + hex.EncodeToString(suffix)
+		err = s.repo.Create(ctx, org, ownerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *organizationService) Get(ctx context.Context, id uuid.UUID) (*domain.OrganizationDetail, error) {
+	org, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.repo.Usage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OrganizationDetail{Organization: *org, Usage: *usage}, nil
+}
+
+func (s *organizationService) Update(ctx context.Context, id uuid.UUID, req domain.UpdateOrganizationRequest) (*domain.Organization, error) {
+	org, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		org.Name = *req.Name
+	}
+	if err := s.repo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *organizationService) ListForUser(ctx context.Context, userID uuid.UUID) ([]domain.OrganizationWithRole, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *organizationService) ListProjects(ctx context.Context, id uuid.UUID) ([]domain.Project, error) {
+	return s.repo.ListProjects(ctx, id)
+}
+
+func (s *organizationService) ListMembers(ctx context.Context, id uuid.UUID) ([]domain.OrganizationMember, error) {
+	return s.repo.ListMembers(ctx, id)
+}
+
+func (s *organizationService) AddMember(ctx context.Context, actor *domain.OrganizationMember, req domain.OrganizationMemberRequest) (*domain.OrganizationMember, error) {
+	if !actor.CanManageRole(req.Role) {
+		return nil, domain.ErrForbidden.WithMessage("you cannot grant this role")
+	}
+
+	member := &domain.OrganizationMember{
+		ID:             uuid.New(),
+		OrganizationID: actor.OrganizationID,
+		UserID:         req.UserID,
+		Role:           req.Role,
+	}
+	if err := s.repo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *organizationService) UpdateMemberRole(ctx context.Context, actor *domain.OrganizationMember, userID uuid.UUID, role domain.Role) (*domain.OrganizationMember, error) {
+	target, err := s.member(ctx, actor.OrganizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManageRole(target.Role) || !actor.CanManageRole(role) {
+		return nil, domain.ErrForbidden.WithMessage("you cannot change this member's role")
+	}
+	if target.Role.IsOwner() && !role.IsOwner() {
+		if err := s.requireAnotherOwner(ctx, actor.OrganizationID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	target.Role = role
+	if err := s.repo.UpdateMemberRole(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, actor *domain.OrganizationMember, userID uuid.UUID) error {
+	target, err := s.member(ctx, actor.OrganizationID, userID)
+	if err != nil {
+		return err
+	}
+	// Anyone may leave; removing others needs a role above theirs
+	if userID != actor.UserID && !actor.CanManageRole(target.Role) {
+		return domain.ErrForbidden.WithMessage("you cannot remove this member")
+	}
+	if target.Role.IsOwner() {
+		if err := s.requireAnotherOwner(ctx, actor.OrganizationID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.RemoveMember(ctx, actor.OrganizationID, userID)
+}
+
+func (s *organizationService) Search(ctx context.Context, id uuid.UUID, query string) ([]domain.OrganizationSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if len(query) < minOrganizationSearchLength {
+		return nil, domain.ErrValidation.WithMessage("search query must be at least 2 characters")
+	}
+	return s.repo.SearchFeedback(ctx, id, query, organizationSearchLimit)
+}
+
+func (s *organizationService) member(ctx context.Context, organizationID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	member, err := s.repo.GetMember(ctx, organizationID.String(), userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, domain.ErrMemberNotFound
+	}
+	return member, nil
+}
+
+// requireAnotherOwner fails if userID is the organization's only owner
+func (s *organizationService) requireAnotherOwner(ctx context.Context, organizationID, userID uuid.UUID) error {
+	members, err := s.repo.ListMembers(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Role.IsOwner() && m.UserID != userID {
+			return nil
+		}
+	}
+	return domain.ErrLastOrganizationOwner
+}
+
+func limit(n int) *int {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
+
+type membershipResolver struct {
+	memberships   repository.MembershipRepository
+	organizations repository.OrganizationRepository
+}
+
+// NewMembershipResolver creates a resolver for a user's effective role in a
+// project. A team membership in the project overrides the role inherited
+// from the organization that owns it.
+func NewMembershipResolver(memberships repository.MembershipRepository, organizations repository.OrganizationRepository) MembershipResolver {
+	return &membershipResolver{
+		memberships:   memberships,
+		organizations: organizations,
+	}
+}
+
+func (r *membershipResolver) GetByProjectAndUser(ctx context.Context, projectID, userID string) (*domain.Membership, error) {
+	membership, err := r.memberships.GetByProjectAndUser(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership != nil && membership.Role.IsTeamRole() {
+		return membership, nil
+	}
+
+	// Community memberships don't override an organization role
+	orgMember, err := r.organizations.GetMemberForProject(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if orgMember != nil {
+		id, err := uuid.Parse(projectID)
+		if err != nil {
+			return nil, err
+		}
+		return orgMember.ProjectMembership(id), nil
+	}
+
+	return membership, nil
+}
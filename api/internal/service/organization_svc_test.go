@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func TestMembershipResolver(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	orgAdmin, overridden, community, outsider := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	memberships := fakeMembershipRepo{roles: map[uuid.UUID]domain.Role{
+		overridden: domain.RoleViewer,
+		community:  domain.RoleCommunity,
+	}}
+	orgs := repository.NewMockOrganizationRepository()
+	for _, id := range []uuid.UUID{orgAdmin, overridden, community} {
+		orgs.On("GetMemberForProject", ctx, projectID.String(), id.String()).
+			Return(&domain.OrganizationMember{UserID: id, Role: domain.RoleAdmin}, nil)
+	}
+	orgs.On("GetMemberForProject", ctx, projectID.String(), outsider.String()).Return(nil, nil)
+	resolver := NewMembershipResolver(memberships, orgs)
+
+	t.Run("inherits the organization role", func(t *testing.T) {
+		m, err := resolver.GetByProjectAndUser(ctx, projectID.String(), orgAdmin.String())
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, m.Role)
+		assert.True(t, m.Inherited)
+		assert.Equal(t, projectID, m.ProjectID)
+	})
+
+	t.Run("project role overrides the organization role", func(t *testing.T) {
+		m, err := resolver.GetByProjectAndUser(ctx, projectID.String(), overridden.String())
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleViewer, m.Role)
+		assert.False(t, m.Inherited)
+	})
+
+	t.Run("community membership does not override", func(t *testing.T) {
+		m, err := resolver.GetByProjectAndUser(ctx, projectID.String(), community.String())
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, m.Role)
+	})
+
+	t.Run("outsider has no membership", func(t *testing.T) {
+		m, err := resolver.GetByProjectAndUser(ctx, projectID.String(), outsider.String())
+		require.NoError(t, err)
+		assert.Nil(t, m)
+	})
+}
+
+func TestOrganizationService_Members(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	owner := domain.OrganizationMember{OrganizationID: orgID, UserID: uuid.New(), Role: domain.RoleOwner}
+	admin := domain.OrganizationMember{OrganizationID: orgID, UserID: uuid.New(), Role: domain.RoleAdmin}
+	member := domain.OrganizationMember{OrganizationID: orgID, UserID: uuid.New(), Role: domain.RoleMember}
+
+	setup := func() *repository.MockOrganizationRepository {
+		repo := repository.NewMockOrganizationRepository()
+		repo.On("ListMembers", ctx, orgID).Return([]domain.OrganizationMember{owner, admin, member}, nil)
+		for _, m := range []domain.OrganizationMember{owner, admin, member} {
+			m := m
+			repo.On("GetMember", ctx, orgID.String(), m.UserID.String()).Return(&m, nil)
+		}
+		return repo
+	}
+
+	t.Run("admins cannot grant admin", func(t *testing.T) {
+		repo := setup()
+		_, err := NewOrganizationService(repo, 0, 0).AddMember(ctx, &admin, domain.OrganizationMemberRequest{UserID: uuid.New(), Role: domain.RoleAdmin})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		repo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admins manage lower roles", func(t *testing.T) {
+		repo := setup()
+		repo.On("UpdateMemberRole", ctx, mock.Anything).Return(nil)
+		m, err := NewOrganizationService(repo, 0, 0).UpdateMemberRole(ctx, &admin, member.UserID, domain.RoleViewer)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleViewer, m.Role)
+	})
+
+	t.Run("the last owner cannot leave", func(t *testing.T) {
+		repo := setup()
+		err := NewOrganizationService(repo, 0, 0).RemoveMember(ctx, &owner, owner.UserID)
+		assert.ErrorIs(t, err, domain.ErrLastOrganizationOwner)
+		repo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("members may leave", func(t *testing.T) {
+		repo := setup()
+		repo.On("RemoveMember", ctx, orgID, member.UserID).Return(nil)
+		err := NewOrganizationService(repo, 0, 0).RemoveMember(ctx, &member, member.UserID)
+		require.NoError(t, err)
+	})
+}
+
+func TestOrganizationService_Create(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	repo := repository.NewMockOrganizationRepository()
+	repo.On("Create", ctx, mock.Anything, ownerID).Return(nil)
+
+	org, err := NewOrganizationService(repo, 10, 0).Create(ctx, ownerID, domain.CreateOrganizationRequest{Name: "Acme Apps"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme-apps", org.Slug)
+	require.NotNil(t, org.MaxProjects)
+	assert.Equal(t, 10, *org.MaxProjects)
+	assert.Nil(t, org.MaxMembers)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+type projectLifecycleService struct {
+	repo           repository.ProjectLifecycleRepository
+	projectRepo    repository.ProjectRepository
+	membershipRepo repository.MembershipRepository
+	deletionGrace  time.Duration
+	now            func() time.Time
+}
+
+// NewProjectLifecycleService creates a new project lifecycle service.
+// Deleted projects are purged once deletionGrace has passed.
+func NewProjectLifecycleService(
+	repo repository.ProjectLifecycleRepository,
+	projectRepo repository.ProjectRepository,
+	membershipRepo repository.MembershipRepository,
+	deletionGrace time.Duration,
+) ProjectLifecycleService {
+	if deletionGrace <= 0 {
+		deletionGrace = domain.DefaultProjectDeletionGrace
+	}
+	return &projectLifecycleService{
+		repo:           repo,
+		projectRepo:    projectRepo,
+		membershipRepo: membershipRepo,
+		deletionGrace:  deletionGrace,
+		now:            time.Now,
+	}
+}
+
+func (s *projectLifecycleService) Archive(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	return s.repo.Archive(ctx, projectID)
+}
+
+func (s *projectLifecycleService) Restore(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	return s.repo.Restore(ctx, projectID)
+}
+
+func (s *projectLifecycleService) Delete(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	return s.repo.ScheduleDeletion(ctx, projectID, s.now().Add(s.deletionGrace))
+}
+
+func (s *projectLifecycleService) RequestTransfer(ctx context.Context, projectID, fromUserID, toUserID uuid.UUID) (*domain.ProjectTransfer, error) {
+	if toUserID == fromUserID {
+		return nil, domain.ErrValidation.WithMessage("you already own this project")
+	}
+	if err := s.requireActive(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	// Ownership can only go to someone already on the team
+	member, err := s.membershipRepo.GetByProjectAndUser(ctx, projectID.String(), toUserID.String())
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || !member.Role.IsTeamRole() {
+		return nil, domain.ErrMemberNotFound.WithMessage("the new owner must be a team member of this project")
+	}
+
+	transfer := &domain.ProjectTransfer{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		ExpiresAt:  s.now().Add(domain.ProjectTransferExpiry),
+	}
+	if err := s.repo.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (s *projectLifecycleService) GetPendingTransfer(ctx context.Context, projectID uuid.UUID) (*domain.ProjectTransfer, error) {
+	return s.repo.GetPendingTransfer(ctx, projectID)
+}
+
+func (s *projectLifecycleService) CancelTransfer(ctx context.Context, projectID uuid.UUID) error {
+	return s.repo.CancelTransfer(ctx, projectID)
+}
+
+func (s *projectLifecycleService) AcceptTransfer(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectTransfer, error) {
+	transfer, err := s.repo.GetPendingTransfer(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	// Don't reveal transfers addressed to someone else
+	if transfer.ToUserID != userID {
+		return nil, domain.ErrTransferNotFound
+	}
+	if transfer.IsExpired(s.now()) {
+		return nil, domain.ErrTransferExpired
+	}
+	if err := s.requireActive(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AcceptTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// requireActive returns ErrProjectArchived for archived projects
+func (s *projectLifecycleService) requireActive(ctx context.Context, projectID uuid.UUID) error {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if project.IsArchived() {
+		return domain.ErrProjectArchived
+	}
+	return nil
+}
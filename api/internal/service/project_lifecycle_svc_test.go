@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// fakeMembershipRepo serves memberships keyed by user ID
+type fakeMembershipRepo struct {
+	repository.MembershipRepository
+	roles map[uuid.UUID]domain.Role
+}
+
+func (f fakeMembershipRepo) GetByProjectAndUser(_ context.Context, projectID, userID string) (*domain.Membership, error) {
+	role, ok := f.roles[uuid.MustParse(userID)]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.Membership{ProjectID: uuid.MustParse(projectID), UserID: uuid.MustParse(userID), Role: role}, nil
+}
+
+func TestProjectLifecycleService_RequestTransfer(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	owner, admin, community := uuid.New(), uuid.New(), uuid.New()
+	members := fakeMembershipRepo{roles: map[uuid.UUID]domain.Role{
+		owner:     domain.RoleOwner,
+		admin:     domain.RoleAdmin,
+		community: domain.RoleCommunity,
+	}}
+
+	setup := func(archived bool) (*repository.MockProjectLifecycleRepository, ProjectLifecycleService) {
+		project := &domain.Project{ID: projectID}
+		if archived {
+			now := time.Now()
+			project.ArchivedAt = &now
+		}
+		projects := repository.NewMockProjectRepository()
+		projects.On("GetByID", ctx, projectID).Return(project, nil)
+		repo := repository.NewMockProjectLifecycleRepository()
+		return repo, NewProjectLifecycleService(repo, projects, members, 0)
+	}
+
+	t.Run("to a team member", func(t *testing.T) {
+		repo, svc := setup(false)
+		repo.On("CreateTransfer", ctx, mock.AnythingOfType("*domain.ProjectTransfer")).Return(nil)
+
+		transfer, err := svc.RequestTransfer(ctx, projectID, owner, admin)
+		require.NoError(t, err)
+		assert.Equal(t, admin, transfer.ToUserID)
+		assert.WithinDuration(t, time.Now().Add(domain.ProjectTransferExpiry), transfer.ExpiresAt, time.Minute)
+	})
+
+	t.Run("to a community member or stranger", func(t *testing.T) {
+		_, svc := setup(false)
+		for _, to := range []uuid.UUID{community, uuid.New()} {
+			_, err := svc.RequestTransfer(ctx, projectID, owner, to)
+			assert.ErrorIs(t, err, domain.ErrMemberNotFound)
+		}
+	})
+
+	t.Run("to themselves", func(t *testing.T) {
+		_, svc := setup(false)
+		_, err := svc.RequestTransfer(ctx, projectID, owner, owner)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	t.Run("archived project", func(t *testing.T) {
+		_, svc := setup(true)
+		_, err := svc.RequestTransfer(ctx, projectID, owner, admin)
+		assert.ErrorIs(t, err, domain.ErrProjectArchived)
+	})
+}
+
+func TestProjectLifecycleService_AcceptTransfer(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	from, to := uuid.New(), uuid.New()
+
+	setup := func(expiresAt time.Time) (*repository.MockProjectLifecycleRepository, ProjectLifecycleService, *domain.ProjectTransfer) {
+		transfer := &domain.ProjectTransfer{ID: uuid.New(), ProjectID: projectID, FromUserID: from, ToUserID: to, ExpiresAt: expiresAt}
+		projects := repository.NewMockProjectRepository()
+		projects.On("GetByID", ctx, projectID).Return(&domain.Project{ID: projectID}, nil)
+		repo := repository.NewMockProjectLifecycleRepository()
+		repo.On("GetPendingTransfer", ctx, projectID).Return(transfer, nil)
+		return repo, NewProjectLifecycleService(repo, projects, fakeMembershipRepo{}, 0), transfer
+	}
+
+	t.Run("by the recipient", func(t *testing.T) {
+		repo, svc, transfer := setup(time.Now().Add(time.Hour))
+		repo.On("AcceptTransfer", ctx, transfer).Return(nil)
+
+		_, err := svc.AcceptTransfer(ctx, projectID, to)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("by anyone else", func(t *testing.T) {
+		repo, svc, _ := setup(time.Now().Add(time.Hour))
+		for _, caller := range []uuid.UUID{from, uuid.New()} {
+			_, err := svc.AcceptTransfer(ctx, projectID, caller)
+			assert.ErrorIs(t, err, domain.ErrTransferNotFound)
+		}
+		repo.AssertNotCalled(t, "AcceptTransfer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("after it expired", func(t *testing.T) {
+		repo, svc, _ := setup(time.Now().Add(-time.Minute))
+		_, err := svc.AcceptTransfer(ctx, projectID, to)
+		assert.ErrorIs(t, err, domain.ErrTransferExpired)
+		repo.AssertNotCalled(t, "AcceptTransfer", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProjectLifecycleService_Delete(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	repo := repository.NewMockProjectLifecycleRepository()
+	svc := NewProjectLifecycleService(repo, repository.NewMockProjectRepository(), fakeMembershipRepo{}, 48*time.Hour)
+
+	repo.On("ScheduleDeletion", ctx, projectID, mock.MatchedBy(func(at time.Time) bool {
+		return at.Sub(time.Now().Add(48*time.Hour)).Abs() < time.Minute
+	})).Return(&domain.Project{ID: projectID}, nil)
+
+	_, err := svc.Delete(ctx, projectID)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+// fakeStorage records deleted paths and fails for paths in failOn
+type fakeStorage struct {
+	deleted  []string
+	failOn   map[string]bool
+	existing map[string]bool
+}
+
+func (f *fakeStorage) GenerateUploadURL(context.Context, string, string, time.Duration) (string, error) {
+	return "", nil
+}
+
+func (f *fakeStorage) GenerateDownloadURL(_ context.Context, path string, _ time.Duration) (string, error) {
+	return "https://storage.test/" + path, nil
+}
+
+func (f *fakeStorage) Upload(context.Context, string, string, io.Reader) error { return nil }
+
+func (f *fakeStorage) Delete(_ context.Context, path string) error {
+	if f.failOn[path] {
+		return errors.New("storage unavailable")
+	}
+	f.deleted = append(f.deleted, path)
+	return nil
+}
+
+func (f *fakeStorage) Exists(_ context.Context, path string) (bool, error) {
+	return f.existing[path], nil
+}
+
+func TestProjectPurgeWorker_PurgeDue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes objects before the project", func(t *testing.T) {
+		a, b := uuid.New(), uuid.New()
+		repo := repository.NewMockProjectLifecycleRepository()
+		repo.On("ListDueForDeletion", ctx, 10).Return([]uuid.UUID{a, b}, nil)
+		repo.On("ListStoragePaths", ctx, a).Return([]string{"uploads/a/1.png", "exports/a.csv"}, nil)
+		repo.On("ListStoragePaths", ctx, b).Return([]string{"uploads/b/1.png"}, nil)
+		repo.On("Purge", ctx, a).Return(nil)
+		store := &fakeStorage{failOn: map[string]bool{"uploads/b/1.png": true}}
+
+		purged, err := NewProjectPurgeWorker(repo, store).PurgeDue(ctx)
+		require.NoError(t, err)
+
+		// b keeps its row so the purge is retried
+		assert.Equal(t, 1, purged)
+		assert.Equal(t, []string{"uploads/a/1.png", "exports/a.csv"}, store.deleted)
+		repo.AssertNotCalled(t, "Purge", ctx, b)
+	})
+
+	t.Run("keeps projects with objects when storage is not configured", func(t *testing.T) {
+		withFiles, withoutFiles := uuid.New(), uuid.New()
+		repo := repository.NewMockProjectLifecycleRepository()
+		repo.On("ListDueForDeletion", ctx, 10).Return([]uuid.UUID{withFiles, withoutFiles}, nil)
+		repo.On("ListStoragePaths", ctx, withFiles).Return([]string{"uploads/x.png"}, nil)
+		repo.On("ListStoragePaths", ctx, withoutFiles).Return([]string(nil), nil)
+		repo.On("Purge", ctx, withoutFiles).Return(nil)
+
+		purged, err := NewProjectPurgeWorker(repo, nil).PurgeDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		repo.AssertNotCalled(t, "Purge", ctx, withFiles)
+	})
+}
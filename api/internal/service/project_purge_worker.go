@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+	"github.com/fulldisclosure/api/internal/storage"
+)
+
+// ProjectPurgeWorker hard-deletes projects whose deletion grace period has
+// passed, removing their attachments and exports from object storage first.
+// Several API instances may run it; purging is idempotent.
+type ProjectPurgeWorker struct {
+	repo      repository.ProjectLifecycleRepository
+	storage   storage.ObjectStorage
+	interval  time.Duration
+	batchSize int
+}
+
+// NewProjectPurgeWorker creates a new project purge worker. Storage may be
+// nil when none is configured; projects with stored objects are then kept
+// until it is.
+func NewProjectPurgeWorker(repo repository.ProjectLifecycleRepository, storage storage.ObjectStorage) *ProjectPurgeWorker {
+	return &ProjectPurgeWorker{
+		repo:      repo,
+		storage:   storage,
+		interval:  time.Hour,
+		batchSize: 10,
+	}
+}
+
+// Run purges due projects until ctx is cancelled
+func (w *ProjectPurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.PurgeDue(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to purge deleted projects")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeDue purges one batch of due projects and returns how many were deleted
+func (w *ProjectPurgeWorker) PurgeDue(ctx context.Context) (int, error) {
+	ids, err := w.repo.ListDueForDeletion(ctx, w.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := w.purge(ctx, id); err != nil {
+			log.Error().Err(err).Str("project_id", id.String()).Msg("Failed to purge project")
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purge removes the project's stored objects and then the project. Objects
+// go first so a failure leaves the project due and the purge is retried.
+func (w *ProjectPurgeWorker) purge(ctx context.Context, projectID uuid.UUID) error {
+	paths, err := w.repo.ListStoragePaths(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if len(paths) > 0 && w.storage == nil {
+		return fmt.Errorf("object storage is not configured; %d objects would be orphaned", len(paths))
+	}
+	for _, path := range paths {
+		if err := w.storage.Delete(ctx, path); err != nil {
+			return err
+		}
+	}
+
+	if err := w.repo.Purge(ctx, projectID); err != nil {
+		// Purged by another instance since it was listed; due projects
+		// can't be restored
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	log.Info().Str("project_id", projectID.String()).Int("objects", len(paths)).Msg("Purged deleted project")
+	return nil
+}
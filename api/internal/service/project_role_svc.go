@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+type projectRoleService struct {
+	roles       repository.ProjectRoleRepository
+	memberships repository.MembershipRepository
+}
+
+// NewProjectRoleService creates a new custom project role service
+func NewProjectRoleService(roles repository.ProjectRoleRepository, memberships repository.MembershipRepository) ProjectRoleService {
+	return &projectRoleService{
+		roles:       roles,
+		memberships: memberships,
+	}
+}
+
+func (s *projectRoleService) List(ctx context.Context, projectID uuid.UUID) (*domain.ProjectRolesResponse, error) {
+	custom, err := s.roles.List(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	resp := domain.NewProjectRolesResponse(custom)
+	return &resp, nil
+}
+
+func (s *projectRoleService) Create(ctx context.Context, actor *domain.Membership, req domain.ProjectRoleRequest) (*domain.ProjectRole, error) {
+	// A role can't grant more than its creator has
+	if !actor.HasPermissions(req.Permissions) {
+		return nil, domain.ErrForbidden.WithMessage("you cannot grant permissions you don't have")
+	}
+
+	role := &domain.ProjectRole{
+		ID:        uuid.New(),
+		ProjectID: actor.ProjectID,
+	}
+	req.Apply(role)
+	if err := s.roles.Create(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *projectRoleService) Update(ctx context.Context, actor *domain.Membership, roleID uuid.UUID, req domain.ProjectRoleRequest) (*domain.ProjectRole, error) {
+	role, err := s.roles.GetByID(ctx, actor.ProjectID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasPermissions(req.Permissions) {
+		return nil, domain.ErrForbidden.WithMessage("you cannot grant permissions you don't have")
+	}
+
+	// Editing a role changes what its holders can do, so it follows the
+	// same rule as assigning it: only to members below the actor
+	if !actor.Role.IsOwner() {
+		holders, err := s.roles.HolderRoles(ctx, actor.ProjectID, roleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, held := range holders {
+			if held.Level() >= actor.Role.Level() {
+				return nil, domain.ErrForbidden.WithMessage("you cannot change a role held by members at or above your level")
+			}
+		}
+	}
+
+	req.Apply(role)
+	if err := s.roles.Update(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *projectRoleService) Delete(ctx context.Context, projectID, roleID uuid.UUID) error {
+	return s.roles.Delete(ctx, projectID, roleID)
+}
+
+func (s *projectRoleService) Assign(ctx context.Context, actor *domain.Membership, membershipID uuid.UUID, req domain.AssignProjectRoleRequest) (*domain.Membership, error) {
+	target, err := s.memberships.GetByID(ctx, membershipID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ProjectID != actor.ProjectID {
+		return nil, domain.ErrMemberNotFound
+	}
+
+	var role *domain.ProjectRole
+	if req.CustomRoleID != nil {
+		role, err = s.roles.GetByID(ctx, actor.ProjectID, *req.CustomRoleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !actor.CanAssignRole(target, role) {
+		return nil, domain.ErrForbidden.WithMessage("you cannot change this member's role")
+	}
+
+	if err := s.roles.Assign(ctx, actor.ProjectID, membershipID, req.CustomRoleID); err != nil {
+		return nil, err
+	}
+	target.CustomRoleID = req.CustomRoleID
+	target.CustomRole = role
+	return target, nil
+}
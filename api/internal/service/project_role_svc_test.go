@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// membershipsByID serves memberships keyed by membership ID
+type membershipsByID struct {
+	repository.MembershipRepository
+	byID map[uuid.UUID]*domain.Membership
+}
+
+func (f membershipsByID) GetByID(_ context.Context, id uuid.UUID) (*domain.Membership, error) {
+	m, ok := f.byID[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func TestProjectRoleService_Create(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	t.Run("admins create roles", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		roles.On("Create", ctx, mock.Anything).Return(nil)
+		admin := &domain.Membership{ProjectID: projectID, Role: domain.RoleAdmin}
+
+		name := "Support"
+		req := domain.ProjectRoleRequest{Name: &name, Permissions: []domain.Permission{domain.PermissionCommentsModerate}}
+		require.NoError(t, req.Validate(true))
+		role, err := NewProjectRoleService(roles, membershipsByID{}).Create(ctx, admin, req)
+		require.NoError(t, err)
+		assert.Equal(t, projectID, role.ProjectID)
+		assert.ElementsMatch(t, []domain.Permission{domain.PermissionFeedbackView, domain.PermissionCommentsModerate}, role.Permissions)
+	})
+
+	t.Run("cannot grant permissions the creator lacks", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		manager := &domain.Membership{ProjectID: projectID, Role: domain.RoleMember, CustomRole: &domain.ProjectRole{
+			Permissions: []domain.Permission{domain.PermissionFeedbackView, domain.PermissionMembersManage},
+		}}
+
+		name := "Token keeper"
+		req := domain.ProjectRoleRequest{Name: &name, Permissions: []domain.Permission{domain.PermissionTokensManage}}
+		require.NoError(t, req.Validate(true))
+		_, err := NewProjectRoleService(roles, membershipsByID{}).Create(ctx, manager, req)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		roles.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProjectRoleService_Update(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	role := &domain.ProjectRole{ID: uuid.New(), ProjectID: projectID, Name: "Support",
+		Permissions: []domain.Permission{domain.PermissionFeedbackView}}
+	name := "Support lead"
+	req := domain.ProjectRoleRequest{Name: &name, Permissions: []domain.Permission{domain.PermissionCommentsModerate}}
+	require.NoError(t, req.Validate(false))
+
+	t.Run("admins update roles held by members", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		copied := *role
+		roles.On("GetByID", ctx, projectID, role.ID).Return(&copied, nil)
+		roles.On("HolderRoles", ctx, projectID, role.ID).Return([]domain.Role{domain.RoleMember, domain.RoleViewer}, nil)
+		roles.On("Update", ctx, mock.Anything).Return(nil)
+		admin := &domain.Membership{ProjectID: projectID, Role: domain.RoleAdmin}
+
+		updated, err := NewProjectRoleService(roles, membershipsByID{}).Update(ctx, admin, role.ID, req)
+		require.NoError(t, err)
+		assert.Equal(t, "Support lead", updated.Name)
+	})
+
+	t.Run("cannot change a role held at the actor's level", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		copied := *role
+		roles.On("GetByID", ctx, projectID, role.ID).Return(&copied, nil)
+		roles.On("HolderRoles", ctx, projectID, role.ID).Return([]domain.Role{domain.RoleAdmin}, nil)
+		admin := &domain.Membership{ProjectID: projectID, Role: domain.RoleAdmin}
+
+		_, err := NewProjectRoleService(roles, membershipsByID{}).Update(ctx, admin, role.ID, req)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		roles.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("owners change any role", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		copied := *role
+		roles.On("GetByID", ctx, projectID, role.ID).Return(&copied, nil)
+		roles.On("Update", ctx, mock.Anything).Return(nil)
+		owner := &domain.Membership{ProjectID: projectID, Role: domain.RoleOwner}
+
+		_, err := NewProjectRoleService(roles, membershipsByID{}).Update(ctx, owner, role.ID, req)
+		require.NoError(t, err)
+		roles.AssertNotCalled(t, "HolderRoles", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProjectRoleRequest_Validate(t *testing.T) {
+	name := "Owner"
+	req := domain.ProjectRoleRequest{Name: &name, Permissions: []domain.Permission{domain.PermissionFeedbackView}}
+	assert.Error(t, req.Validate(true), "built-in role names are reserved")
+
+	name = "Janitor"
+	req = domain.ProjectRoleRequest{Name: &name, Permissions: []domain.Permission{domain.PermissionProjectManage}}
+	assert.Error(t, req.Validate(true), "project.manage stays with the owner")
+
+	req = domain.ProjectRoleRequest{Name: &name, Permissions: []domain.Permission{"feedback.everything"}}
+	assert.Error(t, req.Validate(true))
+}
+
+func TestProjectRoleService_Assign(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	admin := &domain.Membership{ID: uuid.New(), ProjectID: projectID, Role: domain.RoleAdmin}
+	member := &domain.Membership{ID: uuid.New(), ProjectID: projectID, Role: domain.RoleMember}
+	owner := &domain.Membership{ID: uuid.New(), ProjectID: projectID, Role: domain.RoleOwner}
+	other := &domain.Membership{ID: uuid.New(), ProjectID: uuid.New(), Role: domain.RoleMember}
+	memberships := membershipsByID{byID: map[uuid.UUID]*domain.Membership{
+		admin.ID: admin, member.ID: member, owner.ID: owner, other.ID: other,
+	}}
+	triager := &domain.ProjectRole{ID: uuid.New(), ProjectID: projectID, Name: "Triager",
+		Permissions: []domain.Permission{domain.PermissionFeedbackView, domain.PermissionFeedbackTriage}}
+
+	t.Run("assigns a custom role", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		roles.On("GetByID", ctx, projectID, triager.ID).Return(triager, nil)
+		roles.On("Assign", ctx, projectID, member.ID, &triager.ID).Return(nil)
+
+		m, err := NewProjectRoleService(roles, memberships).Assign(ctx, admin, member.ID, domain.AssignProjectRoleRequest{CustomRoleID: &triager.ID})
+		require.NoError(t, err)
+		assert.Equal(t, &triager.ID, m.CustomRoleID)
+		assert.False(t, m.HasPermission(domain.PermissionFeedbackCreate))
+	})
+
+	t.Run("owners keep their role", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+		roles.On("GetByID", ctx, projectID, triager.ID).Return(triager, nil)
+
+		_, err := NewProjectRoleService(roles, memberships).Assign(ctx, admin, owner.ID, domain.AssignProjectRoleRequest{CustomRoleID: &triager.ID})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		roles.AssertNotCalled(t, "Assign", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("members of other projects are not found", func(t *testing.T) {
+		roles := repository.NewMockProjectRoleRepository()
+
+		_, err := NewProjectRoleService(roles, memberships).Assign(ctx, admin, other.ID, domain.AssignProjectRoleRequest{})
+		assert.ErrorIs(t, err, domain.ErrMemberNotFound)
+	})
+}
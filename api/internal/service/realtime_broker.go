@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+// RealtimeBroker relays realtime events from Postgres to the SSE clients
+// connected to this instance. Every instance listens to the same channel,
+// so clients see the same events whichever instance they are connected to.
+type RealtimeBroker struct {
+	repo      repository.RealtimeRepository
+	retention time.Duration
+	replayMax int
+	buffer    int
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*RealtimeSubscription]struct{}
+}
+
+// RealtimeSubscription is one client's view of a project's events
+type RealtimeSubscription struct {
+	projectID  uuid.UUID
+	feedbackID *uuid.UUID
+	isTeam     bool
+	events     chan domain.RealtimeEvent
+}
+
+// NewRealtimeBroker creates a new realtime broker
+func NewRealtimeBroker(repo repository.RealtimeRepository) *RealtimeBroker {
+	return &RealtimeBroker{
+		repo:        repo,
+		retention:   time.Hour,
+		replayMax:   500,
+		buffer:      64,
+		subscribers: make(map[uuid.UUID]map[*RealtimeSubscription]struct{}),
+	}
+}
+
+// Run listens for events until ctx is cancelled, reconnecting with backoff
+// when the connection drops, and prunes events past their retention
+func (b *RealtimeBroker) Run(ctx context.Context) {
+	go b.prune(ctx)
+
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := b.repo.Listen(ctx, b.Publish)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Dur("retry_in", backoff).Msg("Realtime listener stopped")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if time.Since(started) > time.Minute {
+			backoff = time.Second
+		} else if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (b *RealtimeBroker) prune(ctx context.Context) {
+	ticker := time.NewTicker(b.retention / 6)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := b.repo.Prune(ctx, time.Now().Add(-b.retention)); err != nil && ctx.Err() == nil {
+				log.Error().Err(err).Msg("Failed to prune realtime events")
+			}
+		}
+	}
+}
+
+// Subscribe starts delivering a project's events, or one item's if
+// feedbackID is set, that the audience may see
+func (b *RealtimeBroker) Subscribe(projectID uuid.UUID, feedbackID *uuid.UUID, isTeam bool) *RealtimeSubscription {
+	sub := &RealtimeSubscription{
+		projectID:  projectID,
+		feedbackID: feedbackID,
+		isTeam:     isTeam,
+		events:     make(chan domain.RealtimeEvent, b.buffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[projectID] == nil {
+		b.subscribers[projectID] = make(map[*RealtimeSubscription]struct{})
+	}
+	b.subscribers[projectID][sub] = struct{}{}
+
+	return sub
+}
+
+// Unsubscribe stops delivering events to the subscription
+func (b *RealtimeBroker) Unsubscribe(sub *RealtimeSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(sub)
+}
+
+// DisconnectAll ends every subscription, so that open streams finish and
+// the server can shut down
+func (b *RealtimeBroker) DisconnectAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			b.remove(sub)
+		}
+	}
+}
+
+// Publish delivers an event to the matching subscriptions. A client too
+// slow to keep up is disconnected rather than holding up the others; it
+// resumes from its Last-Event-ID when it reconnects.
+func (b *RealtimeBroker) Publish(event domain.RealtimeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[event.ProjectID] {
+		if !sub.Matches(&event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+// Replay returns the subscription's events after afterID, and false if
+// some can no longer be replayed and the client should reload instead
+func (b *RealtimeBroker) Replay(ctx context.Context, sub *RealtimeSubscription, afterID int64) ([]domain.RealtimeEvent, bool, error) {
+	events, complete, err := b.repo.ListSince(ctx, sub.projectID, afterID, b.replayMax)
+	if err != nil || !complete {
+		return nil, complete, err
+	}
+
+	var matched []domain.RealtimeEvent
+	for i := range events {
+		if sub.Matches(&events[i]) {
+			matched = append(matched, events[i])
+		}
+	}
+
+	return matched, true, nil
+}
+
+// remove drops the subscription and closes its channel; callers hold mu
+func (b *RealtimeBroker) remove(sub *RealtimeSubscription) {
+	subs := b.subscribers[sub.projectID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.projectID)
+	}
+	close(sub.events)
+}
+
+// Events returns the channel events are delivered on. It is closed when
+// the subscription ends.
+func (s *RealtimeSubscription) Events() <-chan domain.RealtimeEvent {
+	return s.events
+}
+
+// Matches returns true if the event belongs to the subscription's project
+// or item and the audience may see it
+func (s *RealtimeSubscription) Matches(e *domain.RealtimeEvent) bool {
+	if e.ProjectID != s.projectID || !e.IsVisibleTo(s.isTeam) {
+		return false
+	}
+	if s.feedbackID != nil {
+		return e.FeedbackID != nil && *e.FeedbackID == *s.feedbackID
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fulldisclosure/api/internal/domain"
+	"github.com/fulldisclosure/api/internal/repository"
+)
+
+func realtimeEvent(id int64, projectID uuid.UUID, feedbackID *uuid.UUID, visibility domain.Visibility) domain.RealtimeEvent {
+	return domain.RealtimeEvent{
+		ID:         id,
+		ProjectID:  projectID,
+		FeedbackID: feedbackID,
+		EventType:  domain.RealtimeVotesChanged,
+		Visibility: visibility,
+	}
+}
+
+func drain(sub *RealtimeSubscription) []int64 {
+	var ids []int64
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestRealtimeBroker_Publish(t *testing.T) {
+	projectID := uuid.New()
+	feedbackID := uuid.New()
+	otherFeedback := uuid.New()
+
+	t.Run("filters by project, item and visibility", func(t *testing.T) {
+		b := NewRealtimeBroker(repository.NewMockRealtimeRepository())
+		team := b.Subscribe(projectID, nil, true)
+		public := b.Subscribe(projectID, nil, false)
+		item := b.Subscribe(projectID, &feedbackID, false)
+
+		b.Publish(realtimeEvent(1, projectID, &feedbackID, domain.VisibilityCommunity))
+		b.Publish(realtimeEvent(2, projectID, &feedbackID, domain.VisibilityTeamOnly))
+		b.Publish(realtimeEvent(3, projectID, &otherFeedback, domain.VisibilityCommunity))
+		b.Publish(realtimeEvent(4, uuid.New(), &feedbackID, domain.VisibilityCommunity))
+
+		assert.Equal(t, []int64{1, 2, 3}, drain(team))
+		assert.Equal(t, []int64{1, 3}, drain(public))
+		assert.Equal(t, []int64{1}, drain(item))
+	})
+
+	t.Run("disconnects clients that fall behind", func(t *testing.T) {
+		b := NewRealtimeBroker(repository.NewMockRealtimeRepository())
+		b.buffer = 2
+		slow := b.Subscribe(projectID, nil, true)
+
+		for id := int64(1); id <= 3; id++ {
+			b.Publish(realtimeEvent(id, projectID, &feedbackID, domain.VisibilityCommunity))
+		}
+
+		assert.Equal(t, []int64{1, 2}, drain(slow))
+		_, open := <-slow.Events()
+		assert.False(t, open)
+
+		// Unsubscribing after being dropped is harmless
+		b.Unsubscribe(slow)
+	})
+}
+
+func TestRealtimeBroker_Replay(t *testing.T) {
+	projectID := uuid.New()
+	feedbackID := uuid.New()
+
+	t.Run("returns the events the subscription may see", func(t *testing.T) {
+		repo := repository.NewMockRealtimeRepository()
+		b := NewRealtimeBroker(repo)
+		sub := b.Subscribe(projectID, nil, false)
+
+		repo.On("ListSince", mock.Anything, projectID, int64(10), b.replayMax).Return([]domain.RealtimeEvent{
+			realtimeEvent(11, projectID, &feedbackID, domain.VisibilityTeamOnly),
+			realtimeEvent(12, projectID, &feedbackID, domain.VisibilityCommunity),
+		}, true, nil)
+
+		events, complete, err := b.Replay(context.Background(), sub, 10)
+
+		require.NoError(t, err)
+		assert.True(t, complete)
+		require.Len(t, events, 1)
+		assert.Equal(t, int64(12), events[0].ID)
+	})
+
+	t.Run("reports events that can no longer be replayed", func(t *testing.T) {
+		repo := repository.NewMockRealtimeRepository()
+		b := NewRealtimeBroker(repo)
+		sub := b.Subscribe(projectID, nil, true)
+
+		repo.On("ListSince", mock.Anything, projectID, int64(1), b.replayMax).Return(nil, false, nil)
+
+		events, complete, err := b.Replay(context.Background(), sub, 1)
+
+		require.NoError(t, err)
+		assert.False(t, complete)
+		assert.Empty(t, events)
+	})
+}
@@ -26,62 +26,90 @@ func NewVoteService(
 	}
 }
 
-func (s *voteService) Vote(ctx context.Context, projectID, feedbackID, userID uuid.UUID) (*VoteResult, error) {
-	// Verify feedback exists and belongs to project
-	feedback, err := s.feedbackRepo.GetByID(ctx, feedbackID)
-	if err != nil {
-		return nil, err
+func (s *voteService) Vote(ctx context.Context, projectID, feedbackID uuid.UUID, voter domain.Voter, details domain.VoteDetails) (*VoteResult, error) {
+	return s.castVote(ctx, projectID, &domain.Vote{
+		ID:          uuid.New(),
+		FeedbackID:  feedbackID,
+		Voter:       voter,
+		VoteDetails: details,
+	})
+}
+
+func (s *voteService) RecordVote(ctx context.Context, projectID, feedbackID uuid.UUID, customer domain.VoteCustomer, details domain.VoteDetails, recordedBy uuid.UUID) (*VoteResult, error) {
+	if err := customer.Validate(); err != nil {
+		return nil, domain.ErrValidation.WithMessage(err.Error())
 	}
 
-	if feedback.ProjectID != projectID {
-		return nil, domain.ErrNotFound
+	// Verify feedback before creating a customer for it
+	if _, err := s.projectFeedback(ctx, projectID, feedbackID); err != nil {
+		return nil, err
 	}
 
-	// Check if already voted
-	exists, err := s.voteRepo.Exists(ctx, feedbackID, userID)
+	sdkUserID, err := s.voteRepo.ResolveCustomer(ctx, projectID, customer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check vote: %w", err)
+		return nil, err
 	}
 
-	if exists {
-		// Already voted - return current state (idempotent)
-		count, err := s.voteRepo.CountByFeedback(ctx, feedbackID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to count votes: %w", err)
-		}
-		return &VoteResult{
-			FeedbackID: feedbackID,
-			VoteCount:  count,
-			HasVoted:   true,
-		}, nil
+	return s.castVote(ctx, projectID, &domain.Vote{
+		ID:          uuid.New(),
+		FeedbackID:  feedbackID,
+		Voter:       domain.SDKUserVoter(sdkUserID),
+		VoteDetails: details,
+		RecordedBy:  &recordedBy,
+	})
+}
+
+// castVote stores a vote, or updates the details of the voter's existing
+// vote (idempotent)
+func (s *voteService) castVote(ctx context.Context, projectID uuid.UUID, vote *domain.Vote) (*VoteResult, error) {
+	if err := vote.Voter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := vote.VoteDetails.Validate(); err != nil {
+		return nil, domain.ErrValidation.WithMessage(err.Error())
 	}
 
-	// Create vote
-	vote := &domain.Vote{
-		ID:         uuid.New(),
-		FeedbackID: feedbackID,
-		UserID:     userID,
+	feedback, err := s.projectFeedback(ctx, projectID, vote.FeedbackID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.voteRepo.Create(ctx, vote); err != nil {
+	// Team-recorded votes and votes on resolved items don't use the budget
+	if vote.RecordedBy == nil && !feedback.Status.IsResolved() {
+		err = s.voteRepo.CreateWithinBudget(ctx, projectID, vote)
+	} else {
+		err = s.voteRepo.Create(ctx, vote)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to create vote: %w", err)
 	}
 
-	// Get updated count (trigger updates denormalized count)
-	count, err := s.voteRepo.CountByFeedback(ctx, feedbackID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count votes: %w", err)
+	// Re-read the trigger-maintained count and score
+	return s.result(ctx, vote.FeedbackID, true)
+}
+
+func (s *voteService) Unvote(ctx context.Context, projectID, feedbackID uuid.UUID, voter domain.Voter) (*VoteResult, error) {
+	if _, err := s.projectFeedback(ctx, projectID, feedbackID); err != nil {
+		return nil, err
 	}
 
-	return &VoteResult{
-		FeedbackID: feedbackID,
-		VoteCount:  count,
-		HasVoted:   true,
-	}, nil
+	// Delete vote (will return ErrNotFound if doesn't exist)
+	if err := s.voteRepo.Delete(ctx, feedbackID, voter); err != nil {
+		if err != domain.ErrNotFound {
+			return nil, fmt.Errorf("failed to delete vote: %w", err)
+		}
+		// Not voted - return current state (idempotent)
+	}
+
+	return s.result(ctx, feedbackID, false)
 }
 
-func (s *voteService) Unvote(ctx context.Context, projectID, feedbackID, userID uuid.UUID) (*VoteResult, error) {
-	// Verify feedback exists and belongs to project
+func (s *voteService) Budget(ctx context.Context, projectID uuid.UUID, voter domain.Voter) (*domain.VoteBudgetUsage, error) {
+	return s.voteRepo.Budget(ctx, projectID, voter)
+}
+
+// projectFeedback loads feedback and verifies it belongs to the project
+func (s *voteService) projectFeedback(ctx context.Context, projectID, feedbackID uuid.UUID) (*domain.Feedback, error) {
 	feedback, err := s.feedbackRepo.GetByID(ctx, feedbackID)
 	if err != nil {
 		return nil, err
@@ -91,41 +119,28 @@ func (s *voteService) Unvote(ctx context.Context, projectID, feedbackID, userID
 		return nil, domain.ErrNotFound
 	}
 
-	// Delete vote (will return ErrNotFound if doesn't exist)
-	if err := s.voteRepo.Delete(ctx, feedbackID, userID); err != nil {
-		if err == domain.ErrNotFound {
-			// Not voted - return current state (idempotent)
-			count, countErr := s.voteRepo.CountByFeedback(ctx, feedbackID)
-			if countErr != nil {
-				return nil, fmt.Errorf("failed to count votes: %w", countErr)
-			}
-			return &VoteResult{
-				FeedbackID: feedbackID,
-				VoteCount:  count,
-				HasVoted:   false,
-			}, nil
-		}
-		return nil, fmt.Errorf("failed to delete vote: %w", err)
-	}
+	return feedback, nil
+}
 
-	// Get updated count
-	count, err := s.voteRepo.CountByFeedback(ctx, feedbackID)
+func (s *voteService) result(ctx context.Context, feedbackID uuid.UUID, hasVoted bool) (*VoteResult, error) {
+	feedback, err := s.feedbackRepo.GetByID(ctx, feedbackID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count votes: %w", err)
 	}
 
 	return &VoteResult{
 		FeedbackID: feedbackID,
-		VoteCount:  count,
-		HasVoted:   false,
+		VoteCount:  feedback.VoteCount,
+		VoteScore:  feedback.VoteScore,
+		HasVoted:   hasVoted,
 	}, nil
 }
 
-func (s *voteService) HasVoted(ctx context.Context, feedbackID, userID uuid.UUID) (bool, error) {
-	return s.voteRepo.Exists(ctx, feedbackID, userID)
+func (s *voteService) HasVoted(ctx context.Context, feedbackID uuid.UUID, voter domain.Voter) (bool, error) {
+	return s.voteRepo.Exists(ctx, feedbackID, voter)
 }
 
-func (s *voteService) EnrichWithVoteStatus(ctx context.Context, feedbacks []domain.Feedback, userID uuid.UUID) error {
+func (s *voteService) EnrichWithVoteStatus(ctx context.Context, feedbacks []domain.Feedback, voter domain.Voter) error {
 	if len(feedbacks) == 0 {
 		return nil
 	}
@@ -137,7 +152,7 @@ func (s *voteService) EnrichWithVoteStatus(ctx context.Context, feedbacks []doma
 	}
 
 	// Get votes for all feedbacks
-	votes, err := s.voteRepo.ListByUser(ctx, userID, feedbackIDs)
+	votes, err := s.voteRepo.ListByVoter(ctx, voter, feedbackIDs)
 	if err != nil {
 		return fmt.Errorf("failed to list votes: %w", err)
 	}
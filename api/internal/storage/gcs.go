@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -63,6 +64,27 @@ func (c *GCSClient) GenerateDownloadURL(ctx context.Context, path string, expire
 	return url, nil
 }
 
+func (c *GCSClient) Upload(ctx context.Context, path string, contentType string, r io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := c.client.Bucket(c.bucketName).Object(path).NewWriter(ctx)
+	w.ContentType = contentType
+
+	if _, err := io.Copy(w, r); err != nil {
+		// Closing the writer would finalize a truncated object; cancelling
+		// its context abandons the upload instead
+		cancel()
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finalize upload: %w", err)
+	}
+
+	return nil
+}
+
 func (c *GCSClient) Delete(ctx context.Context, path string) error {
 	obj := c.client.Bucket(c.bucketName).Object(path)
 	if err := obj.Delete(ctx); err != nil {
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -13,6 +14,9 @@ type ObjectStorage interface {
 	// GenerateDownloadURL creates a signed URL for downloading a file
 	GenerateDownloadURL(ctx context.Context, path string, expiresIn time.Duration) (string, error)
 
+	// Upload writes an object from the server side, streaming from r
+	Upload(ctx context.Context, path string, contentType string, r io.Reader) error
+
 	// Delete removes an object from storage
 	Delete(ctx context.Context, path string) error
 
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStorage implements ObjectStorage on the local filesystem for
+// development and single-node deployments. Objects are served by the API
+// itself through URLs signed with the shared URLSigner.
+type LocalStorage struct {
+	root    string
+	baseURL string // e.g. http://localhost:8080/api/storage
+	signer  *URLSigner
+}
+
+// NewLocalStorage creates a local storage backend rooted at dir. baseURL is
+// the public URL the storage handlers are mounted at.
+func NewLocalStorage(dir, baseURL string, signer *URLSigner) (*LocalStorage, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage directory: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		root:    root,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		signer:  signer,
+	}, nil
+}
+
+func (s *LocalStorage) GenerateUploadURL(ctx context.Context, path string, contentType string, expiresIn time.Duration) (string, error) {
+	return s.signedURL(http.MethodPut, path, contentType, expiresIn)
+}
+
+func (s *LocalStorage) GenerateDownloadURL(ctx context.Context, path string, expiresIn time.Duration) (string, error) {
+	return s.signedURL(http.MethodGet, path, "", expiresIn)
+}
+
+func (s *LocalStorage) Upload(ctx context.Context, path string, contentType string, r io.Reader) error {
+	full, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial object
+	tmp, err := os.CreateTemp(filepath.Dir(full), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to finalize upload: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), full); err != nil {
+		return fmt.Errorf("failed to finalize upload: %w", err)
+	}
+
+	return nil
+}
+
+// Open opens an object for reading
+func (s *LocalStorage) Open(path string) (*os.File, error) {
+	full, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(full)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, path string) error {
+	full, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Exists(ctx context.Context, path string) (bool, error) {
+	full, err := s.resolve(path)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(full); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check object: %w", err)
+	}
+	return true, nil
+}
+
+// Verify checks the signed query parameters of a request for path
+func (s *LocalStorage) Verify(method, path string, q url.Values) (*SignedRequest, error) {
+	return s.signer.Verify(method, path, q)
+}
+
+func (s *LocalStorage) signedURL(method, path, contentType string, expiresIn time.Duration) (string, error) {
+	if _, err := s.resolve(path); err != nil {
+		return "", err
+	}
+	q := s.signer.Sign(SignedRequest{
+		Method:      method,
+		Resource:    path,
+		ContentType: contentType,
+		ExpiresAt:   time.Now().Add(expiresIn),
+	})
+	return s.baseURL + "/" + (&url.URL{Path: path}).EscapedPath() + "?" + q.Encode(), nil
+}
+
+// resolve maps an object path to a file under the storage root, rejecting
+// paths that would escape it
+func (s *LocalStorage) resolve(path string) (string, error) {
+	clean := filepath.Clean("/" + filepath.FromSlash(path))
+	if clean == string(filepath.Separator) {
+		return "", fmt.Errorf("invalid object path %q", path)
+	}
+	return filepath.Join(s.root, clean), nil
+}
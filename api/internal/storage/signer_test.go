@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSigner_Verify(t *testing.T) {
+	signer := NewURLSigner("test-key")
+	now := time.Unix(1_700_000_000, 0)
+	signer.now = func() time.Time { return now }
+
+	req := SignedRequest{
+		Method:      http.MethodPut,
+		Resource:    "attachments/123",
+		ContentType: "image/png",
+		MaxBytes:    1024,
+		ExpiresAt:   now.Add(time.Minute),
+	}
+	q := signer.Sign(req)
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := signer.Verify(http.MethodPut, "attachments/123", q)
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", got.ContentType)
+		assert.Equal(t, int64(1024), got.MaxBytes)
+	})
+
+	t.Run("other resource", func(t *testing.T) {
+		_, err := signer.Verify(http.MethodPut, "attachments/456", q)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("other method", func(t *testing.T) {
+		_, err := signer.Verify(http.MethodGet, "attachments/123", q)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	for _, param := range []string{"max_bytes", "content_type", "expires"} {
+		t.Run("tampered "+param, func(t *testing.T) {
+			tampered := cloneValues(q)
+			tampered.Set(param, map[string]string{
+				"max_bytes":    "999999999",
+				"content_type": "text/html",
+				"expires":      "1900000000",
+			}[param])
+			_, err := signer.Verify(http.MethodPut, "attachments/123", tampered)
+			assert.ErrorIs(t, err, ErrInvalidSignature)
+		})
+	}
+
+	t.Run("dropped limit", func(t *testing.T) {
+		tampered := cloneValues(q)
+		tampered.Del("max_bytes")
+		_, err := signer.Verify(http.MethodPut, "attachments/123", tampered)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		_, err := NewURLSigner("other-key").Verify(http.MethodPut, "attachments/123", q)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		defer func() { now = now.Add(-2 * time.Minute) }()
+		_, err := signer.Verify(http.MethodPut, "attachments/123", q)
+		assert.ErrorIs(t, err, ErrURLExpired)
+	})
+}
+
+func cloneValues(q url.Values) url.Values {
+	out := make(url.Values, len(q))
+	for k, v := range q {
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
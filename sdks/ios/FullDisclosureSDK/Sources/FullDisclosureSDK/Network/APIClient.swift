@@ -62,6 +62,11 @@ public actor APIClient {
         request.setValue("application/json", forHTTPHeaderField: "Accept")
         request.setValue(sdkVersion, forHTTPHeaderField: "X-SDK-Version")
 
+        // Native apps send no Origin; tokens are allow-listed by bundle ID
+        if let bundleId = Bundle.main.bundleIdentifier {
+            request.setValue(bundleId, forHTTPHeaderField: "X-SDK-Bundle-ID")
+        }
+
         if let body = body {
             request.httpBody = try encoder.encode(body)
         }
@@ -26,6 +26,8 @@ function SDKTokensPage() {
   const [creating, setCreating] = useState(false)
   const [newTokenName, setNewTokenName] = useState('')
   const [newAllowedOrigins, setNewAllowedOrigins] = useState('')
+  const [newAllowedBundleIds, setNewAllowedBundleIds] = useState('')
+  const [newAllowAnyOrigin, setNewAllowAnyOrigin] = useState(false)
   const [newRateLimit, setNewRateLimit] = useState('60')
 
   // Token created modal state
@@ -60,14 +62,19 @@ function SDKTokensPage() {
 
     setCreating(true)
     try {
-      const origins = newAllowedOrigins
-        .split(',')
-        .map((o) => o.trim())
-        .filter((o) => o.length > 0)
+      const splitList = (value: string) =>
+        value
+          .split(',')
+          .map((o) => o.trim())
+          .filter((o) => o.length > 0)
+      const origins = splitList(newAllowedOrigins)
+      const bundleIds = splitList(newAllowedBundleIds)
 
       const token = (await api.creator.createSDKToken(projectId, {
         name: newTokenName.trim(),
         allowed_origins: origins.length > 0 ? origins : undefined,
+        allowed_bundle_ids: bundleIds.length > 0 ? bundleIds : undefined,
+        allow_any_origin: newAllowAnyOrigin,
         rate_limit_per_minute: parseInt(newRateLimit) || 60,
       })) as SDKToken
 
@@ -75,6 +82,8 @@ function SDKTokensPage() {
       setShowCreateModal(false)
       setNewTokenName('')
       setNewAllowedOrigins('')
+      setNewAllowedBundleIds('')
+      setNewAllowAnyOrigin(false)
       setNewRateLimit('60')
       fetchTokens()
     } catch (err) {
@@ -268,18 +277,44 @@ function SDKTokensPage() {
 
                   <div>
                     <label className="block text-sm font-medium text-gray-700 mb-1">
-                      Allowed Origins (optional)
+                      Allowed Origins
                     </label>
                     <input
                       type="text"
                       value={newAllowedOrigins}
                       onChange={(e) => setNewAllowedOrigins(e.target.value)}
+                      disabled={newAllowAnyOrigin}
+                      className="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-primary focus:border-transparent disabled:bg-gray-100"
+                      placeholder="https://example.com, https://*.example.com"
+                    />
+                    <p className="mt-1 text-xs text-gray-500">
+                      Comma-separated list of web origins. Use *.example.com to
+                      allow every subdomain.
+                    </p>
+                    <label className="mt-2 flex items-center gap-2 text-sm text-gray-700">
+                      <input
+                        type="checkbox"
+                        checked={newAllowAnyOrigin}
+                        onChange={(e) => setNewAllowAnyOrigin(e.target.checked)}
+                      />
+                      Allow any origin
+                    </label>
+                  </div>
+
+                  <div>
+                    <label className="block text-sm font-medium text-gray-700 mb-1">
+                      Allowed App Bundle IDs
+                    </label>
+                    <input
+                      type="text"
+                      value={newAllowedBundleIds}
+                      onChange={(e) => setNewAllowedBundleIds(e.target.value)}
                       className="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-primary focus:border-transparent"
-                      placeholder="https://example.com, https://app.example.com"
+                      placeholder="com.example.app"
                     />
                     <p className="mt-1 text-xs text-gray-500">
-                      Comma-separated list of allowed origins. Leave empty to
-                      allow all.
+                      Comma-separated list of native app bundle IDs allowed to
+                      use this token.
                     </p>
                   </div>
 
@@ -263,6 +263,8 @@ export interface SDKToken {
   token?: string // Only returned on creation
   token_prefix: string
   allowed_origins: string[]
+  allow_any_origin: boolean
+  allowed_bundle_ids: string[]
   rate_limit: number
   is_active: boolean
   last_used_at?: string
@@ -272,6 +274,8 @@ export interface SDKToken {
 export interface CreateSDKTokenRequest {
   name: string
   allowed_origins?: string[]
+  allow_any_origin?: boolean
+  allowed_bundle_ids?: string[]
   rate_limit_per_minute?: number
 }
 